@@ -0,0 +1,96 @@
+package assert
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/stretchr/testify/clock"
+)
+
+// Eventually asserts that the condition becomes true within waitFor,
+// checking it every tick.
+//
+//  assert.Eventually(t, func() bool { return server.Ready() }, time.Second, 10*time.Millisecond)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func Eventually(t TestingT, condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return EventuallyWith(t, clock.Real{}, condition, waitFor, tick, msgAndArgs...)
+}
+
+// EventuallyWith asserts that the condition becomes true within waitFor,
+// checking it every tick, as Eventually does, with the time measured by
+// the specified clock.  With a fake clock, the test decides when the
+// condition is checked by advancing the time.
+//
+//  fake := clock.NewFake(time.Now())
+//  go func() {
+//    fake.BlockUntil(2) // The timer and the ticker of EventuallyWith.
+//    fake.Advance(time.Second)
+//  }()
+//  assert.EventuallyWith(t, fake, cache.Expired, time.Minute, time.Second)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func EventuallyWith(t TestingT, c clock.Clock, condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if poll(c, condition, waitFor, tick) {
+		return true
+	}
+	return Fail(t, fmt.Sprintf("Condition never satisfied within %v", waitFor), msgAndArgs...)
+}
+
+// Never asserts that the condition stays false for waitFor, checking it
+// every tick.
+//
+//  assert.Never(t, func() bool { return queue.Len() > 0 }, time.Second, 10*time.Millisecond)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func Never(t TestingT, condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return NeverWith(t, clock.Real{}, condition, waitFor, tick, msgAndArgs...)
+}
+
+// NeverWith asserts that the condition stays false for waitFor, checking
+// it every tick, as Never does, with the time measured by the specified
+// clock.
+//
+//  assert.NeverWith(t, fake, func() bool { return queue.Len() > 0 }, time.Minute, time.Second)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func NeverWith(t TestingT, c clock.Clock, condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if poll(c, condition, waitFor, tick) {
+		return Fail(t, fmt.Sprintf("Condition satisfied within %v", waitFor), msgAndArgs...)
+	}
+	return true
+}
+
+// poll checks the condition at each tick of the clock until it is true or
+// waitFor has elapsed, and returns whether it became true.  The condition
+// is checked once more when the time is up, so that the result does not
+// depend on whether the last tick or the timer is read first.
+func poll(c clock.Clock, condition func() bool, waitFor, tick time.Duration) bool {
+	timer := c.NewTimer(waitFor)
+	defer timer.Stop()
+	ticker := c.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-timer.C():
+			return condition()
+		case <-ticker.C():
+			if condition() {
+				return true
+			}
+		}
+	}
+}
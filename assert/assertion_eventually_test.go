@@ -0,0 +1,101 @@
+package assert
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/clock"
+)
+
+func TestEventually(t *testing.T) {
+	mockT := new(bufferT)
+	True(t, Eventually(mockT, func() bool { return true }, time.Second, time.Millisecond))
+
+	var checks int32
+	True(t, Eventually(mockT, func() bool { return atomic.AddInt32(&checks, 1) == 3 }, time.Second, time.Millisecond))
+	Equal(t, int32(3), atomic.LoadInt32(&checks))
+	Empty(t, mockT.messages)
+
+	False(t, Eventually(mockT, func() bool { return false }, 20*time.Millisecond, time.Millisecond))
+	if Len(t, mockT.messages, 1) {
+		Contains(t, mockT.messages[0], "Condition never satisfied within 20ms")
+	}
+}
+
+func TestNever(t *testing.T) {
+	mockT := new(bufferT)
+	True(t, Never(mockT, func() bool { return false }, 20*time.Millisecond, time.Millisecond))
+	Empty(t, mockT.messages)
+
+	False(t, Never(mockT, func() bool { return true }, time.Second, time.Millisecond))
+	if Len(t, mockT.messages, 1) {
+		Contains(t, mockT.messages[0], "Condition satisfied within 1s")
+	}
+}
+
+// pollWithFake runs assertion with a fake clock, advancing it by tick
+// until the condition has been checked the specified number of times,
+// and then to the end of waitFor.
+func pollWithFake(assertion func(TestingT, clock.Clock, func() bool, time.Duration, time.Duration, ...interface{}) bool, result bool, checks int) (bool, int, *bufferT) {
+	fake := clock.NewFake(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	checked := make(chan int, 100)
+	count := 0
+	condition := func() bool {
+		count++
+		checked <- count
+		return count == checks && result
+	}
+
+	mockT := new(bufferT)
+	done := make(chan bool)
+	go func() {
+		done <- assertion(mockT, fake, condition, time.Minute, time.Second)
+	}()
+
+	fake.BlockUntil(2)
+	for i := 0; i < checks && i < 59; i++ {
+		fake.Advance(time.Second)
+		<-checked
+	}
+	select {
+	case ok := <-done:
+		return ok, count, mockT
+	default:
+	}
+	fake.Set(time.Date(2020, 1, 1, 0, 1, 0, 0, time.UTC))
+	ok := <-done
+	return ok, count, mockT
+}
+
+func TestEventuallyWith(t *testing.T) {
+	ok, count, mockT := pollWithFake(EventuallyWith, true, 3)
+	True(t, ok)
+	Equal(t, 3, count)
+	Empty(t, mockT.messages)
+
+	ok, count, mockT = pollWithFake(EventuallyWith, false, 3)
+	False(t, ok)
+	True(t, count >= 3, "the condition is checked at each tick")
+	if Len(t, mockT.messages, 1) {
+		Contains(t, mockT.messages[0], "Condition never satisfied within 1m0s")
+	}
+
+	ok, count, _ = pollWithFake(EventuallyWith, true, 60)
+	True(t, ok, "the condition is checked when the time is up")
+	Equal(t, 60, count)
+}
+
+func TestNeverWith(t *testing.T) {
+	ok, count, mockT := pollWithFake(NeverWith, false, 3)
+	True(t, ok)
+	True(t, count >= 3, "the condition is checked at each tick")
+	Empty(t, mockT.messages)
+
+	ok, count, mockT = pollWithFake(NeverWith, true, 2)
+	False(t, ok)
+	Equal(t, 2, count)
+	if Len(t, mockT.messages, 1) {
+		Contains(t, mockT.messages[0], "Condition satisfied within 1m0s")
+	}
+}
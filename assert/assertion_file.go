@@ -0,0 +1,448 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// FileExists asserts that the specified path exists and is not a
+// directory.
+//
+//  assert.FileExists(t, "testdata/config.yaml")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func FileExists(t TestingT, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Fail(t, fmt.Sprintf("File %s does not exist", path), msgAndArgs...)
+		}
+		return Fail(t, fmt.Sprintf("Cannot access file %s: %s", path, err), msgAndArgs...)
+	}
+	if info.IsDir() {
+		return Fail(t, fmt.Sprintf("%s is a directory, not a file", path), msgAndArgs...)
+	}
+	return true
+}
+
+// NoFileExists asserts that no file exists at the specified path.  A
+// directory at the path fails the assertion too.
+//
+//  assert.NoFileExists(t, "output/tmp.lock")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func NoFileExists(t TestingT, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return true
+	}
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Cannot access %s: %s", path, err), msgAndArgs...)
+	}
+	if info.IsDir() {
+		return Fail(t, fmt.Sprintf("Directory %s exists", path), msgAndArgs...)
+	}
+	return Fail(t, fmt.Sprintf("File %s exists", path), msgAndArgs...)
+}
+
+// DirExists asserts that the specified path exists and is a directory.
+//
+//  assert.DirExists(t, "output")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func DirExists(t TestingT, path string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Fail(t, fmt.Sprintf("Directory %s does not exist", path), msgAndArgs...)
+		}
+		return Fail(t, fmt.Sprintf("Cannot access directory %s: %s", path, err), msgAndArgs...)
+	}
+	if !info.IsDir() {
+		return Fail(t, fmt.Sprintf("%s is a file, not a directory", path), msgAndArgs...)
+	}
+	return true
+}
+
+// readFile reads the file at path, reporting a failure if it cannot be
+// read.
+func readFile(t TestingT, path string, msgAndArgs ...interface{}) ([]byte, bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, Fail(t, fmt.Sprintf("File %s does not exist", path), msgAndArgs...)
+		}
+		return nil, Fail(t, fmt.Sprintf("Cannot read file %s: %s", path, err), msgAndArgs...)
+	}
+	return content, true
+}
+
+// FileContains asserts that the content of the file at the specified path
+// contains the specified substring.
+//
+//  assert.FileContains(t, "output/log.txt", "server started")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func FileContains(t TestingT, path string, contains string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	content, ok := readFile(t, path, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	if !bytes.Contains(content, []byte(contains)) {
+		return Fail(t, fmt.Sprintf("File %s does not contain %q:\n%s", path, contains, content), msgAndArgs...)
+	}
+	return true
+}
+
+// FileEqual asserts that the content of the file at the specified path is
+// equal to expected, which is either a string, compared as text, or a
+// []byte, compared byte by byte.  Text differences are reported as a
+// unified diff.
+//
+//  assert.FileEqual(t, "output/greeting.txt", "Hello\n")
+//  assert.FileEqual(t, "output/image.png", pngBytes)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func FileEqual(t TestingT, path string, expected interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	var want []byte
+	text := false
+	switch expected := expected.(type) {
+	case string:
+		want, text = []byte(expected), true
+	case []byte:
+		want = expected
+	default:
+		return Fail(t, fmt.Sprintf("Expected content of %s must be a string or a []byte, not %T", path, expected), msgAndArgs...)
+	}
+
+	content, ok := readFile(t, path, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	if bytes.Equal(want, content) {
+		return true
+	}
+	if text {
+		return Fail(t, fmt.Sprintf("File %s is not as expected.\n\nDiff:\n%s", path, textDiff(string(want), string(content))), msgAndArgs...)
+	}
+	return Fail(t, fmt.Sprintf("File %s is not as expected: %s", path, bytesDifference(want, content)), msgAndArgs...)
+}
+
+// textDiff returns the unified diff of two texts.
+func textDiff(expected, actual string) string {
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(expected),
+		B:        difflib.SplitLines(actual),
+		FromFile: "Expected",
+		ToFile:   "Actual",
+		Context:  1,
+	})
+	return diff
+}
+
+// bytesDifference describes where two different byte slices differ.
+func bytesDifference(expected, actual []byte) string {
+	i := 0
+	for i < len(expected) && i < len(actual) && expected[i] == actual[i] {
+		i++
+	}
+	switch {
+	case i == len(actual):
+		return fmt.Sprintf("got %d byte(s) instead of %d, missing % x...", len(actual), len(expected), truncateBytes(expected[i:]))
+	case i == len(expected):
+		return fmt.Sprintf("got %d byte(s) instead of %d, with extra % x...", len(actual), len(expected), truncateBytes(actual[i:]))
+	}
+	return fmt.Sprintf("byte %d is 0x%02x instead of 0x%02x (%d byte(s), expected %d)", i, actual[i], expected[i], len(actual), len(expected))
+}
+
+// truncateBytes returns at most the first 16 bytes of b.
+func truncateBytes(b []byte) []byte {
+	if len(b) > 16 {
+		return b[:16]
+	}
+	return b
+}
+
+// FileMode asserts that the file at the specified path has the specified
+// permissions.  If mode has type bits too, such as os.ModeDir, they must
+// match as well.
+//
+//  assert.FileMode(t, "bin/run.sh", 0755)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func FileMode(t TestingT, path string, mode os.FileMode, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Cannot access %s: %s", path, err), msgAndArgs...)
+	}
+	actual := info.Mode().Perm()
+	if mode&os.ModeType != 0 {
+		actual = info.Mode() & (os.ModeType | os.ModePerm)
+	}
+	if actual != mode {
+		return Fail(t, fmt.Sprintf("%s has mode %s instead of %s", path, actual, mode), msgAndArgs...)
+	}
+	return true
+}
+
+// Tree describes the content of a directory, keyed by the slash-separated
+// paths of its files relative to the directory.  Values are the contents
+// of the files, as strings or byte slices, or Trees for subdirectories.  A
+// path ending with a slash, with a nil value, is an empty directory.
+//
+//    assert.Tree{
+//      "go.mod":      "module example\n",
+//      "cmd/main.go": mainSource,
+//      "internal/":   nil,
+//      "testdata":    assert.Tree{"in.txt": "input"},
+//    }
+type Tree map[string]interface{}
+
+// treeEntry is a file or a directory of a flattened tree, keyed by its
+// slash-separated path with a trailing slash for directories.
+type treeEntry struct {
+	dir     bool
+	content []byte
+}
+
+// flatten adds the entries of the tree to entries, with their path
+// prefixed by prefix.  The parent directories of the entries are added
+// too.
+func (tree Tree) flatten(prefix string, entries map[string]treeEntry) error {
+	for name, value := range tree {
+		name = strings.Trim(filepath.ToSlash(name), "/")
+		if name == "" {
+			return fmt.Errorf("empty path in tree")
+		}
+		path := prefix + name
+		for dir := filepath.ToSlash(filepath.Dir(path)); dir != "."; dir = filepath.ToSlash(filepath.Dir(dir)) {
+			entries[dir+"/"] = treeEntry{dir: true}
+		}
+		switch value := value.(type) {
+		case string:
+			entries[path] = treeEntry{content: []byte(value)}
+		case []byte:
+			entries[path] = treeEntry{content: value}
+		case nil:
+			entries[path+"/"] = treeEntry{dir: true}
+		case Tree:
+			entries[path+"/"] = treeEntry{dir: true}
+			if err := value.flatten(path+"/", entries); err != nil {
+				return err
+			}
+		case map[string]interface{}:
+			entries[path+"/"] = treeEntry{dir: true}
+			if err := Tree(value).flatten(path+"/", entries); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("invalid value of %s in tree: %T", path, value)
+		}
+	}
+	return nil
+}
+
+// Write creates the files and directories of the tree in dir, which is
+// created if it does not exist.
+func (tree Tree) Write(dir string) error {
+	entries := map[string]treeEntry{}
+	if err := tree.flatten("", entries); err != nil {
+		return err
+	}
+	for _, path := range sortedEntryPaths(entries) {
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if entries[path].dir {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(target, entries[path].content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TempTree writes the tree in a new temporary directory, and returns its
+// path.  The directory is removed when the test completes if t has a
+// Cleanup method, as *testing.T has, and must be removed by the caller
+// otherwise.  It returns "" if the tree cannot be written.
+//
+//    dir := assert.TempTree(t, assert.Tree{"config.yaml": "debug: true\n"})
+func TempTree(t TestingT, tree Tree, msgAndArgs ...interface{}) string {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	dir, err := ioutil.TempDir("", "testify-tree")
+	if err != nil {
+		Fail(t, fmt.Sprintf("Cannot create temporary directory: %s", err), msgAndArgs...)
+		return ""
+	}
+	if c, ok := t.(interface {
+		Cleanup(func())
+	}); ok {
+		c.Cleanup(func() { os.RemoveAll(dir) })
+	}
+	if err := tree.Write(dir); err != nil {
+		Fail(t, fmt.Sprintf("Cannot write tree in %s: %s", dir, err), msgAndArgs...)
+		return ""
+	}
+	return dir
+}
+
+// readTree returns the flattened entries of the directory.
+func readTree(dir string) (map[string]treeEntry, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	entries := map[string]treeEntry{}
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || path == dir {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if info.IsDir() {
+			entries[rel+"/"] = treeEntry{dir: true}
+			return nil
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		entries[rel] = treeEntry{content: content}
+		return nil
+	})
+	return entries, err
+}
+
+// sortedEntryPaths returns the paths of the entries, sorted so that the
+// entries of a directory follow it.
+func sortedEntryPaths(entries map[string]treeEntry) []string {
+	paths := make([]string, 0, len(entries))
+	for path := range entries {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// DirMatches asserts that the directory holds exactly the files and
+// directories of the expected tree, with the same contents.  The expected
+// tree is either a Tree or the path of a directory, such as
+// "testdata/expected".  Failures show the tree of both directories, with
+// the missing (-), extra (+) and different (~) entries marked, followed
+// by the diffs of the different files.
+//
+//  assert.DirMatches(t, outputDir, assert.Tree{"a.txt": "A", "b/c.txt": "C"})
+//  assert.DirMatches(t, outputDir, "testdata/golden")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func DirMatches(t TestingT, dir string, expectedTree interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	var expected map[string]treeEntry
+	var err error
+	switch tree := expectedTree.(type) {
+	case string:
+		expected, err = readTree(tree)
+	case Tree:
+		expected = map[string]treeEntry{}
+		err = tree.flatten("", expected)
+	case map[string]interface{}:
+		expected = map[string]treeEntry{}
+		err = Tree(tree).flatten("", expected)
+	default:
+		err = fmt.Errorf("expected tree must be a Tree or a directory, not %T", expectedTree)
+	}
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid expected tree: %s", err), msgAndArgs...)
+	}
+	actual, err := readTree(dir)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Cannot read directory %s: %s", dir, err), msgAndArgs...)
+	}
+
+	union := map[string]treeEntry{}
+	for path, entry := range expected {
+		union[path] = entry
+	}
+	for path, entry := range actual {
+		union[path] = entry
+	}
+
+	var tree, diffs bytes.Buffer
+	matches := true
+	for _, path := range sortedEntryPaths(union) {
+		e, inExpected := expected[path]
+		a, inActual := actual[path]
+		marker := " "
+		switch {
+		case !inActual:
+			marker = "-"
+		case !inExpected:
+			marker = "+"
+		case !e.dir && !bytes.Equal(e.content, a.content):
+			marker = "~"
+			if utf8.Valid(e.content) && utf8.Valid(a.content) {
+				fmt.Fprintf(&diffs, "\n%s:\n%s", path, textDiff(string(e.content), string(a.content)))
+			} else {
+				fmt.Fprintf(&diffs, "\n%s: %s\n", path, bytesDifference(e.content, a.content))
+			}
+		}
+		matches = matches && marker == " "
+
+		depth := strings.Count(strings.TrimSuffix(path, "/"), "/")
+		name := strings.TrimSuffix(path, "/")
+		name = name[strings.LastIndex(name, "/")+1:]
+		if strings.HasSuffix(path, "/") {
+			name += "/"
+		}
+		fmt.Fprintf(&tree, "%s %s%s\n", marker, strings.Repeat("  ", depth), name)
+	}
+	if matches {
+		return true
+	}
+	return Fail(t, fmt.Sprintf("Directory %s does not match the expected tree (- missing, + extra, ~ different):\n%s%s", dir, tree.String(), diffs.String()), msgAndArgs...)
+}
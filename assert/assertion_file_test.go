@@ -0,0 +1,163 @@
+package assert
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileExists(t *testing.T) {
+	dir := TempTree(t, Tree{"file.txt": "content", "dir/": nil})
+
+	mockT := new(bufferT)
+	True(t, FileExists(mockT, filepath.Join(dir, "file.txt")))
+	True(t, DirExists(mockT, filepath.Join(dir, "dir")))
+	True(t, NoFileExists(mockT, filepath.Join(dir, "missing.txt")))
+	Empty(t, mockT.messages)
+
+	False(t, FileExists(mockT, filepath.Join(dir, "missing.txt")))
+	False(t, FileExists(mockT, filepath.Join(dir, "dir")))
+	False(t, DirExists(mockT, filepath.Join(dir, "missing")))
+	False(t, DirExists(mockT, filepath.Join(dir, "file.txt")))
+	False(t, NoFileExists(mockT, filepath.Join(dir, "file.txt")))
+	False(t, NoFileExists(mockT, filepath.Join(dir, "dir")))
+	if Len(t, mockT.messages, 6) {
+		Contains(t, mockT.messages[0], "missing.txt does not exist")
+		Contains(t, mockT.messages[1], "dir is a directory, not a file")
+		Contains(t, mockT.messages[2], "missing does not exist")
+		Contains(t, mockT.messages[3], "file.txt is a file, not a directory")
+		Contains(t, mockT.messages[4], "file.txt exists")
+		Contains(t, mockT.messages[5], "dir exists")
+	}
+}
+
+func TestFileContains(t *testing.T) {
+	dir := TempTree(t, Tree{"log.txt": "starting\nserver started\n"})
+	path := filepath.Join(dir, "log.txt")
+
+	mockT := new(bufferT)
+	True(t, FileContains(mockT, path, "server started"))
+	False(t, FileContains(mockT, path, "stopped"))
+	False(t, FileContains(mockT, filepath.Join(dir, "missing.txt"), "x"))
+	if Len(t, mockT.messages, 2) {
+		Contains(t, mockT.messages[0], `does not contain "stopped"`)
+		Contains(t, mockT.messages[1], "missing.txt does not exist")
+	}
+}
+
+func TestFileEqual(t *testing.T) {
+	dir := TempTree(t, Tree{"text.txt": "a\nb\nc\n", "data.bin": []byte{1, 2, 3}})
+	text, data := filepath.Join(dir, "text.txt"), filepath.Join(dir, "data.bin")
+
+	mockT := new(bufferT)
+	True(t, FileEqual(mockT, text, "a\nb\nc\n"))
+	True(t, FileEqual(mockT, data, []byte{1, 2, 3}))
+	Empty(t, mockT.messages)
+
+	False(t, FileEqual(mockT, text, "a\nB\nc\n"))
+	False(t, FileEqual(mockT, data, []byte{1, 4, 3}))
+	False(t, FileEqual(mockT, data, []byte{1, 2}))
+	False(t, FileEqual(mockT, data, []byte{1, 2, 3, 4}))
+	False(t, FileEqual(mockT, data, 123))
+	if Len(t, mockT.messages, 5) {
+		Contains(t, mockT.messages[0], "-B")
+		Contains(t, mockT.messages[0], "+b")
+		Contains(t, mockT.messages[1], "byte 1 is 0x02 instead of 0x04 (3 byte(s), expected 3)")
+		Contains(t, mockT.messages[2], "got 3 byte(s) instead of 2, with extra 03")
+		Contains(t, mockT.messages[3], "got 3 byte(s) instead of 4, missing 04")
+		Contains(t, mockT.messages[4], "must be a string or a []byte, not int")
+	}
+}
+
+func TestFileMode(t *testing.T) {
+	dir := TempTree(t, Tree{"run.sh": "#!/bin/sh\n", "sub/": nil})
+	path := filepath.Join(dir, "run.sh")
+	NoError(t, os.Chmod(path, 0755))
+
+	mockT := new(bufferT)
+	True(t, FileMode(mockT, path, 0755))
+	True(t, FileMode(mockT, filepath.Join(dir, "sub"), os.ModeDir|0755))
+	False(t, FileMode(mockT, path, 0644))
+	False(t, FileMode(mockT, filepath.Join(dir, "missing"), 0644))
+	if Len(t, mockT.messages, 2) {
+		Contains(t, mockT.messages[0], "has mode -rwxr-xr-x instead of -rw-r--r--")
+		Contains(t, mockT.messages[1], "Cannot access")
+	}
+}
+
+func TestTempTree(t *testing.T) {
+	var dir string
+	t.Run("tree", func(t *testing.T) {
+		dir = TempTree(t, Tree{
+			"a.txt":     "A",
+			"b/c.txt":   []byte("C"),
+			"d":         Tree{"e.txt": "E", "f/": nil},
+			"g/":        nil,
+			"h\\i.txt":  "windows separators are not special",
+			"/j/k.txt/": nil,
+		})
+		FileEqual(t, filepath.Join(dir, "a.txt"), "A")
+		FileEqual(t, filepath.Join(dir, "b", "c.txt"), "C")
+		FileEqual(t, filepath.Join(dir, "d", "e.txt"), "E")
+		DirExists(t, filepath.Join(dir, "d", "f"))
+		DirExists(t, filepath.Join(dir, "g"))
+		DirExists(t, filepath.Join(dir, "j", "k.txt"))
+	})
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("%s was not removed", dir)
+	}
+
+	mockT := new(bufferT)
+	Equal(t, "", TempTree(mockT, Tree{"a": 1}))
+	if Len(t, mockT.messages, 1) {
+		Contains(t, mockT.messages[0], "invalid value of a in tree: int")
+	}
+}
+
+func TestDirMatches(t *testing.T) {
+	dir := TempTree(t, Tree{"a.txt": "A\n", "sub/c.txt": "C\n"})
+
+	mockT := new(bufferT)
+	True(t, DirMatches(mockT, dir, Tree{"a.txt": "A\n", "sub": Tree{"c.txt": "C\n"}}))
+	True(t, DirMatches(mockT, dir, map[string]interface{}{"a.txt": "A\n", "sub/c.txt": []byte("C\n")}))
+	True(t, DirMatches(mockT, dir, filepath.Join("testdata", "tree")))
+	Empty(t, mockT.messages)
+
+	False(t, DirMatches(mockT, dir, Tree{"a.txt": "a\n", "sub/d.txt": "D\n", "empty/": nil}))
+	if Len(t, mockT.messages, 1) {
+		message := mockT.messages[0]
+		Contains(t, message, "does not match the expected tree (- missing, + extra, ~ different)")
+		lines := []string{}
+		for _, line := range strings.Split(message, "\n") {
+			if strings.HasPrefix(strings.TrimSpace(line), "~") || strings.HasPrefix(strings.TrimSpace(line), "-") || strings.HasPrefix(strings.TrimSpace(line), "+") {
+				lines = append(lines, strings.TrimSpace(line))
+			}
+		}
+		Equal(t, []string{"~ a.txt", "- empty/", "+   c.txt", "-   d.txt", "--- Expected", "+++ Actual", "-a", "+A"}, lines)
+		Contains(t, message, "  sub/")
+		Contains(t, message, "a.txt:")
+	}
+
+	mockT = new(bufferT)
+	False(t, DirMatches(mockT, filepath.Join(dir, "missing"), Tree{}))
+	False(t, DirMatches(mockT, dir, 42))
+	False(t, DirMatches(mockT, dir, "testdata/missing"))
+	if Len(t, mockT.messages, 3) {
+		Contains(t, mockT.messages[0], "Cannot read directory")
+		Contains(t, mockT.messages[1], "expected tree must be a Tree or a directory, not int")
+		Contains(t, mockT.messages[2], "Invalid expected tree")
+	}
+}
+
+func TestFileWrappers(t *testing.T) {
+	dir := TempTree(t, Tree{"a.txt": "A"})
+	assert := New(t)
+	assert.FileExists(filepath.Join(dir, "a.txt"))
+	assert.NoFileExists(filepath.Join(dir, "b.txt"))
+	assert.DirExists(dir)
+	assert.FileContains(filepath.Join(dir, "a.txt"), "A")
+	assert.FileEqual(filepath.Join(dir, "a.txt"), "A")
+	assert.FileMode(dir, os.ModeDir|0700)
+	assert.DirMatches(dir, Tree{"a.txt": "A"})
+}
@@ -0,0 +1,150 @@
+package assert
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultGoroutineGracePeriod is how long NoGoroutineLeaks waits for the
+// goroutines started by a function to stop, by default.
+const defaultGoroutineGracePeriod = time.Second
+
+// ignoredGoroutines match the stacks of goroutines that are started by
+// the runtime or the testing package, and are never leaks.
+var ignoredGoroutines = []*regexp.Regexp{
+	// Parallel tests and subtests, which are run after their parent.
+	regexp.MustCompile(`\ntesting\.tRunner\(`),
+	// Signal handling, started by the first call to signal.Notify.
+	regexp.MustCompile(`\nos/signal\.(signal_recv|loop)\(`),
+	regexp.MustCompile(`\nruntime\.ensureSigM\.`),
+	// Execution tracing, started by -trace.
+	regexp.MustCompile(`\nruntime(/trace)?\.(ReadTrace|Start)\b`),
+}
+
+// GoroutineLeakOptions configures how NoGoroutineLeaksWith detects leaked
+// goroutines.  The zero value waits for one second and ignores only the
+// goroutines of the runtime and the testing package.
+type GoroutineLeakOptions struct {
+	// Ignore holds regular expressions matched against the stacks of the
+	// new goroutines, as printed by runtime.Stack.  Goroutines whose stack
+	// matches any of them are not leaks, such as those of a connection
+	// pool meant to outlive the test.
+	Ignore []*regexp.Regexp
+
+	// GracePeriod is how long to wait for the new goroutines to stop, as
+	// goroutines often stop shortly after the function that started them
+	// returns.  It defaults to one second.
+	GracePeriod time.Duration
+}
+
+// goroutineStacks returns the stacks of all the goroutines, keyed by
+// goroutine ID.
+func goroutineStacks() map[int]string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	stacks := make(map[int]string)
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		var id int
+		if _, err := fmt.Sscanf(stack, "goroutine %d ", &id); err == nil {
+			stacks[id] = strings.TrimSpace(stack)
+		}
+	}
+	return stacks
+}
+
+// leakedGoroutines returns the stacks of the goroutines that are not in
+// before and are not ignored, sorted by goroutine ID.
+func leakedGoroutines(before map[int]string, ignore []*regexp.Regexp) []string {
+	stacks := goroutineStacks()
+	ids := []int{}
+	for id, stack := range stacks {
+		if _, ok := before[id]; ok || matchesAny(stack, ignoredGoroutines) || matchesAny(stack, ignore) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	leaked := make([]string, 0, len(ids))
+	for _, id := range ids {
+		leaked = append(leaked, stacks[id])
+	}
+	return leaked
+}
+
+func matchesAny(s string, patterns []*regexp.Regexp) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// NoGoroutineLeaks asserts that the specified function does not leave
+// goroutines running once it returns.  The goroutines started by the
+// function are given one second to stop, and those still running then
+// are reported with their stacks, which end with the function that
+// created them.  Running the tests with GODEBUG=tracebackancestors=10
+// adds the stacks of the goroutines that created them.
+//
+//  assert.NoGoroutineLeaks(t, func() {
+//    server := NewServer()
+//    server.Close()
+//  })
+//
+// Returns whether the assertion was successful (true) or not (false).
+func NoGoroutineLeaks(t TestingT, f func(), msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return NoGoroutineLeaksWith(t, GoroutineLeakOptions{}, f, msgAndArgs...)
+}
+
+// NoGoroutineLeaksWith asserts that the specified function does not leave
+// goroutines running once it returns, as NoGoroutineLeaks does, with the
+// specified options.
+//
+//  assert.NoGoroutineLeaksWith(t, assert.GoroutineLeakOptions{
+//    Ignore: []*regexp.Regexp{regexp.MustCompile(`pool\.\(\*Pool\)\.reap`)},
+//  }, func() {
+//    ...
+//  })
+//
+// Returns whether the assertion was successful (true) or not (false).
+func NoGoroutineLeaksWith(t TestingT, options GoroutineLeakOptions, f func(), msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	gracePeriod := options.GracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = defaultGoroutineGracePeriod
+	}
+
+	before := goroutineStacks()
+	f()
+
+	deadline := time.Now().Add(gracePeriod)
+	for {
+		leaked := leakedGoroutines(before, options.Ignore)
+		if len(leaked) == 0 {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return Fail(t, fmt.Sprintf("%d goroutine(s) still running %v after the function returned:\n\n%s", len(leaked), gracePeriod, strings.Join(leaked, "\n\n")), msgAndArgs...)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
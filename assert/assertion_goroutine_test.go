@@ -0,0 +1,65 @@
+package assert
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNoGoroutineLeaks(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	mockT := new(bufferT)
+	True(t, NoGoroutineLeaks(mockT, func() {}))
+	True(t, NoGoroutineLeaks(mockT, func() {
+		done := make(chan struct{})
+		go close(done)
+		<-done
+	}))
+	True(t, NoGoroutineLeaks(mockT, func() {
+		go time.Sleep(20 * time.Millisecond)
+	}), "goroutines stopping within the grace period are not leaks")
+	True(t, NoGoroutineLeaks(mockT, func() {
+		t.Run("parallel", func(t *testing.T) {
+			t.Parallel()
+		})
+	}), "parallel subtests are not leaks")
+	Empty(t, mockT.messages)
+
+	start := time.Now()
+	False(t, NoGoroutineLeaksWith(mockT, GoroutineLeakOptions{GracePeriod: 50 * time.Millisecond}, func() {
+		go func() { <-release }()
+	}))
+	True(t, time.Since(start) >= 50*time.Millisecond, "the grace period is waited for")
+	if Len(t, mockT.messages, 1) {
+		Contains(t, mockT.messages[0], "1 goroutine(s) still running 50ms after the function returned")
+		Contains(t, mockT.messages[0], "[chan receive]")
+		Contains(t, mockT.messages[0], "created by github.com/stretchr/testify/assert.TestNoGoroutineLeaks")
+	}
+}
+
+func TestNoGoroutineLeaksIgnore(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	mockT := new(bufferT)
+	True(t, NoGoroutineLeaksWith(mockT, GoroutineLeakOptions{
+		Ignore:      []*regexp.Regexp{regexp.MustCompile(`assert\.TestNoGoroutineLeaksIgnore`)},
+		GracePeriod: 10 * time.Millisecond,
+	}, func() {
+		go func() { <-release }()
+	}))
+	Empty(t, mockT.messages)
+}
+
+func TestGoroutineStacks(t *testing.T) {
+	stacks := goroutineStacks()
+	found := false
+	for id, stack := range stacks {
+		Regexp(t, `^goroutine \d+ \[`, stack)
+		found = found || regexp.MustCompile(`assert\.TestGoroutineStacks\(`).MatchString(stack)
+		NotZero(t, id)
+	}
+	True(t, found, "the stack of the current goroutine is found")
+}
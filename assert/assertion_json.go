@@ -0,0 +1,325 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// parseJSON parses a JSON document, keeping its numbers as json.Number so
+// that they can be compared exactly.
+func parseJSON(s string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid character after top-level value")
+	}
+	return v, nil
+}
+
+// toJSONValue converts a Go value to the generic value its JSON encoding
+// decodes to, as parseJSON does.
+func toJSONValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return parseJSON(string(data))
+}
+
+// jsonNumberRat returns the exact value of a JSON number.
+func jsonNumberRat(n json.Number) (*big.Rat, bool) {
+	return new(big.Rat).SetString(string(n))
+}
+
+// jsonNumbersEqual returns whether two JSON numbers have the same value,
+// so that 1 and 1.0 are equal.
+func jsonNumbersEqual(a, b json.Number) bool {
+	ra, okA := jsonNumberRat(a)
+	rb, okB := jsonNumberRat(b)
+	if !okA || !okB {
+		return a == b
+	}
+	return ra.Cmp(rb) == 0
+}
+
+// jsonType returns the JSON type of a value returned by parseJSON.
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// jsonString renders a value returned by parseJSON as compact JSON.
+func jsonString(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// jsonPointer returns the JSON pointer of the child of the value at path
+// with the key or index token.
+func jsonPointer(path, token string) string {
+	token = strings.Replace(token, "~", "~0", -1)
+	token = strings.Replace(token, "/", "~1", -1)
+	return path + "/" + token
+}
+
+// displayPointer returns the JSON pointer for messages, where the root
+// is shown as "/".
+func displayPointer(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// jsonDiff returns the differences between two values returned by
+// parseJSON, one per line prefixed with the JSON pointer of the value that
+// differs.  If subset is true, the keys of the objects of actual that are
+// missing from expected are ignored.
+func jsonDiff(expected, actual interface{}, path string, subset bool) []string {
+	if jsonType(expected) != jsonType(actual) {
+		return []string{fmt.Sprintf("%s: expected %s %s but got %s %s", displayPointer(path), jsonType(expected), jsonString(expected), jsonType(actual), jsonString(actual))}
+	}
+
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a := actual.(map[string]interface{})
+		diffs := []string{}
+		keys := make([]string, 0, len(e)+len(a))
+		for key := range e {
+			keys = append(keys, key)
+		}
+		for key := range a {
+			if _, ok := e[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			ev, inExpected := e[key]
+			av, inActual := a[key]
+			switch {
+			case !inActual:
+				diffs = append(diffs, fmt.Sprintf("%s: expected %s but it is missing", jsonPointer(path, key), jsonString(ev)))
+			case !inExpected:
+				if !subset {
+					diffs = append(diffs, fmt.Sprintf("%s: unexpected %s", jsonPointer(path, key), jsonString(av)))
+				}
+			default:
+				diffs = append(diffs, jsonDiff(ev, av, jsonPointer(path, key), subset)...)
+			}
+		}
+		return diffs
+	case []interface{}:
+		a := actual.([]interface{})
+		if len(e) != len(a) {
+			return []string{fmt.Sprintf("%s: expected an array of %d element(s) but got %d: %s", displayPointer(path), len(e), len(a), jsonString(a))}
+		}
+		diffs := []string{}
+		for i := range e {
+			diffs = append(diffs, jsonDiff(e[i], a[i], jsonPointer(path, strconv.Itoa(i)), subset)...)
+		}
+		return diffs
+	case json.Number:
+		if !jsonNumbersEqual(e, actual.(json.Number)) {
+			return []string{fmt.Sprintf("%s: expected %s but got %s", displayPointer(path), e, actual)}
+		}
+	default:
+		if expected != actual {
+			return []string{fmt.Sprintf("%s: expected %s but got %s", displayPointer(path), jsonString(expected), jsonString(actual))}
+		}
+	}
+	return nil
+}
+
+// parseJSONPair parses the expected and actual JSON documents of an
+// assertion, reporting a failure if either is invalid.
+func parseJSONPair(t TestingT, expected, actual string, msgAndArgs ...interface{}) (interface{}, interface{}, bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	expectedJSON, err := parseJSON(expected)
+	if err != nil {
+		return nil, nil, Fail(t, fmt.Sprintf("Expected value ('%s') is not valid json.\nJSON parsing error: '%s'", expected, err.Error()), msgAndArgs...)
+	}
+	actualJSON, err := parseJSON(actual)
+	if err != nil {
+		return nil, nil, Fail(t, fmt.Sprintf("Input ('%s') needs to be valid json.\nJSON parsing error: '%s'", actual, err.Error()), msgAndArgs...)
+	}
+	return expectedJSON, actualJSON, true
+}
+
+// JSONContains asserts that the actual JSON document contains the
+// expected one: its objects have at least the keys of the expected
+// objects, with values containing the expected values.  Arrays must have
+// the same length, and numbers the same value.
+//
+//  assert.JSONContains(t, `{"name": "Mat"}`, `{"id": 1, "name": "Mat"}`)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func JSONContains(t TestingT, expected string, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	expectedJSON, actualJSON, ok := parseJSONPair(t, expected, actual, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	if diffs := jsonDiff(expectedJSON, actualJSON, "", true); len(diffs) > 0 {
+		return failWith(t, Failure{
+			Message:  fmt.Sprintf("JSON document does not contain the expected one:\n%s", strings.Join(diffs, "\n")),
+			Expected: expected,
+			Actual:   actual,
+			Diff:     strings.Join(diffs, "\n"),
+		}, msgAndArgs...)
+	}
+	return true
+}
+
+// JSONPathEq asserts that the value found at path in the JSON document is
+// equal to the JSON encoding of expected.  The path starts with $, which
+// is the document, followed by .key or ["key"] to select the member of
+// an object and [index] to select the element of an array.
+//
+//  assert.JSONPathEq(t, `{"items": [{"id": 42}]}`, "$.items[0].id", 42)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func JSONPathEq(t TestingT, actual string, path string, expected interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	actualJSON, err := parseJSON(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Input ('%s') needs to be valid json.\nJSON parsing error: '%s'", actual, err.Error()), msgAndArgs...)
+	}
+	expectedJSON, err := toJSONValue(expected)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Expected value (%#v) cannot be encoded as json: '%s'", expected, err.Error()), msgAndArgs...)
+	}
+
+	tokens, err := parseJSONPath(path)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Invalid JSON path %q: %s", path, err), msgAndArgs...)
+	}
+	value, pointer, err := lookupJSONPath(actualJSON, tokens)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("JSON path %s not found: %s", path, err), msgAndArgs...)
+	}
+	if diffs := jsonDiff(expectedJSON, value, pointer, false); len(diffs) > 0 {
+		return failWith(t, Failure{
+			Message:  fmt.Sprintf("JSON path %s does not match:\n%s", path, strings.Join(diffs, "\n")),
+			Expected: jsonString(expectedJSON),
+			Actual:   jsonString(value),
+			Diff:     strings.Join(diffs, "\n"),
+		}, msgAndArgs...)
+	}
+	return true
+}
+
+// jsonPathToken is a step of a JSON path, selecting either the member of
+// an object by key or the element of an array by index.
+type jsonPathToken struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parseJSONPath parses a JSON path such as $.items[0]["name"].
+func parseJSONPath(path string) ([]jsonPathToken, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("path must start with $")
+	}
+	tokens := []jsonPathToken{}
+	rest := path[1:]
+	for rest != "" {
+		switch {
+		case rest[0] == '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if key == "" {
+				return nil, fmt.Errorf("empty key at %q", rest)
+			}
+			tokens = append(tokens, jsonPathToken{key: key})
+			rest = rest[end+1:]
+		case rest[0] == '[' && len(rest) > 1 && (rest[1] == '"' || rest[1] == '\''):
+			// A quoted key, which may hold ] and ends with its quote.
+			end := strings.IndexByte(rest[2:], rest[1])
+			if end < 0 || !strings.HasPrefix(rest[end+3:], "]") {
+				return nil, fmt.Errorf("unclosed [ at %q", rest)
+			}
+			tokens = append(tokens, jsonPathToken{key: rest[2 : end+2]})
+			rest = rest[end+4:]
+		case rest[0] == '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed [ at %q", rest)
+			}
+			inside := rest[1:end]
+			index, err := strconv.Atoi(inside)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid index %q", inside)
+			}
+			tokens = append(tokens, jsonPathToken{index: index, isIndex: true})
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q", rest)
+		}
+	}
+	return tokens, nil
+}
+
+// lookupJSONPath returns the value selected by the tokens of a path in a
+// value returned by parseJSON, along with its JSON pointer.
+func lookupJSONPath(v interface{}, tokens []jsonPathToken) (interface{}, string, error) {
+	pointer := ""
+	for _, token := range tokens {
+		if token.isIndex {
+			array, ok := v.([]interface{})
+			if !ok {
+				return nil, "", fmt.Errorf("%s is %s, not an array", displayPointer(pointer), jsonType(v))
+			}
+			if token.index >= len(array) {
+				return nil, "", fmt.Errorf("%s has %d element(s), no index %d", displayPointer(pointer), len(array), token.index)
+			}
+			v, pointer = array[token.index], jsonPointer(pointer, strconv.Itoa(token.index))
+			continue
+		}
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, "", fmt.Errorf("%s is %s, not an object", displayPointer(pointer), jsonType(v))
+		}
+		member, ok := object[token.key]
+		if !ok {
+			return nil, "", fmt.Errorf("%s has no key %q", displayPointer(pointer), token.key)
+		}
+		v, pointer = member, jsonPointer(pointer, token.key)
+	}
+	return v, pointer, nil
+}
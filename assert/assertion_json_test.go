@@ -0,0 +1,242 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJSONEqNormalizesNumbers(t *testing.T) {
+	mockT := new(bufferT)
+	True(t, JSONEq(mockT, `{"n": 1, "f": [0.5, 1e2]}`, `{"f": [5e-1, 100.0], "n": 1.0}`))
+	True(t, JSONEq(mockT, `12345678901234567890`, `12345678901234567890.0`))
+	Empty(t, mockT.messages)
+
+	False(t, JSONEq(mockT, `12345678901234567890`, `12345678901234567891`))
+	if Len(t, mockT.messages, 1) {
+		Contains(t, mockT.messages[0], "/: expected 12345678901234567890 but got 12345678901234567891")
+	}
+}
+
+func TestJSONEqReportsPaths(t *testing.T) {
+	mockT := new(bufferT)
+	False(t, JSONEq(mockT,
+		`{"items": [{"name": "a"}, {"name": "b"}], "id": 1, "gone": true}`,
+		`{"items": [{"name": "a"}, {"name": "c"}], "id": "1", "extra": null}`))
+	if Len(t, mockT.messages, 1) {
+		message := mockT.messages[0]
+		for _, line := range []string{
+			`/extra: unexpected null`,
+			`/gone: expected true but it is missing`,
+			`/id: expected number 1 but got string "1"`,
+			`/items/1/name: expected "b" but got "c"`,
+		} {
+			Contains(t, message, line)
+		}
+		NotContains(t, message, "/items/0")
+	}
+
+	mockT = new(bufferT)
+	False(t, JSONEq(mockT, `{"a/b": {"c~d": [1]}}`, `{"a/b": {"c~d": [1, 2]}}`))
+	Contains(t, mockT.messages[0], "/a~1b/c~0d: expected an array of 1 element(s) but got 2: [1,2]")
+
+	mockT = new(bufferT)
+	False(t, JSONEq(mockT, `[1]`, `{}`))
+	Contains(t, mockT.messages[0], "/: expected array [1] but got object {}")
+
+	mockT = new(bufferT)
+	False(t, JSONEq(mockT, `{}`, `{} {}`))
+	Contains(t, mockT.messages[0], "needs to be valid json")
+}
+
+func TestJSONContains(t *testing.T) {
+	mockT := new(bufferT)
+	True(t, JSONContains(mockT, `{"name": "Mat"}`, `{"id": 1, "name": "Mat"}`))
+	True(t, JSONContains(mockT, `{"items": [{"id": 1}, {}]}`, `{"items": [{"id": 1.0, "x": 2}, {"y": 3}], "total": 2}`))
+	True(t, JSONContains(mockT, `"a"`, `"a"`))
+	Empty(t, mockT.messages)
+
+	False(t, JSONContains(mockT, `{"items": [{"id": 2}]}`, `{"items": [{"id": 1}]}`))
+	False(t, JSONContains(mockT, `{"items": [{}]}`, `{"items": [{}, {}]}`))
+	False(t, JSONContains(mockT, `{"name": "Mat"}`, `{"id": 1}`))
+	False(t, JSONContains(mockT, `not json`, `{}`))
+	if Len(t, mockT.messages, 4) {
+		Contains(t, mockT.messages[0], "JSON document does not contain the expected one")
+		Contains(t, mockT.messages[0], "/items/0/id: expected 2 but got 1")
+		Contains(t, mockT.messages[1], "/items: expected an array of 1 element(s) but got 2")
+		Contains(t, mockT.messages[2], "/name: expected \"Mat\" but it is missing")
+		Contains(t, mockT.messages[3], "is not valid json")
+	}
+}
+
+func TestJSONPathEq(t *testing.T) {
+	doc := `{"items": [{"id": 42, "tags": ["a", "b"]}, {"id": 7.0}], "a.b": {"c": null}}`
+
+	mockT := new(bufferT)
+	True(t, JSONPathEq(mockT, doc, "$.items[0].id", 42))
+	True(t, JSONPathEq(mockT, doc, "$.items[0].id", 42.0))
+	True(t, JSONPathEq(mockT, doc, "$.items[1].id", 7))
+	True(t, JSONPathEq(mockT, doc, "$.items[0].tags", []string{"a", "b"}))
+	True(t, JSONPathEq(mockT, doc, `$["a.b"].c`, nil))
+	True(t, JSONPathEq(mockT, doc, `$['a.b']`, map[string]interface{}{"c": nil}))
+	True(t, JSONPathEq(mockT, doc, "$", map[string]interface{}{
+		"items": []interface{}{map[string]interface{}{"id": 42, "tags": []string{"a", "b"}}, map[string]int{"id": 7}},
+		"a.b":   map[string]interface{}{"c": nil},
+	}))
+	Empty(t, mockT.messages)
+
+	for _, c := range []struct {
+		path     string
+		expected interface{}
+		message  string
+	}{
+		{"$.items[0].id", 43, "/items/0/id: expected 43 but got 42"},
+		{"$.items[0].tags", []string{"a"}, "/items/0/tags: expected an array of 1 element(s) but got 2"},
+		{"$.items[2].id", 1, "/items has 2 element(s), no index 2"},
+		{"$.items.id", 1, "/items is array, not an object"},
+		{"$.items[0].id[0]", 1, "/items/0/id is number, not an array"},
+		{"$.missing", 1, `/ has no key "missing"`},
+		{"items", 1, "path must start with $"},
+		{"$.items[x]", 1, `invalid index "x"`},
+		{"$.items[0", 1, "unclosed ["},
+		{"$..items", 1, "empty key"},
+		{"$.items[0].id", func() {}, "cannot be encoded as json"},
+	} {
+		mockT := new(bufferT)
+		False(t, JSONPathEq(mockT, doc, c.path, c.expected), c.path)
+		if Len(t, mockT.messages, 1, c.path) {
+			Contains(t, mockT.messages[0], c.message, c.path)
+		}
+	}
+}
+
+func TestParseJSONPath(t *testing.T) {
+	tokens, err := parseJSONPath(`$.a[1]["b c"]['d'].e`)
+	NoError(t, err)
+	Equal(t, []jsonPathToken{{key: "a"}, {index: 1, isIndex: true}, {key: "b c"}, {key: "d"}, {key: "e"}}, tokens)
+
+	tokens, err = parseJSONPath("$")
+	NoError(t, err)
+	Empty(t, tokens)
+
+	tokens, err = parseJSONPath(`$["a]b"]['[c]'][0]`)
+	NoError(t, err)
+	Equal(t, []jsonPathToken{{key: "a]b"}, {key: "[c]"}, {index: 0, isIndex: true}}, tokens)
+
+	for _, path := range []string{`$["a]`, `$["a"`, `$["a"b]`, `$[a]`, `$[-1]`} {
+		_, err = parseJSONPath(path)
+		Error(t, err, path)
+	}
+}
+
+func TestJSONMatchesSchema(t *testing.T) {
+	for _, c := range []struct {
+		schema, valid, invalid, message string
+	}{
+		{`true`, `1`, ``, ``},
+		{`false`, ``, `1`, "/: no value is allowed here, got 1"},
+		{`{"type": "integer"}`, `1.0`, `1.5`, "/: expected integer but got number 1.5"},
+		{`{"type": ["string", "null"]}`, `null`, `1`, "/: expected string or null but got number 1"},
+		{`{"enum": [1, "a"]}`, `1.0`, `2`, `/: expected one of [1,"a"] but got 2`},
+		{`{"const": {"a": [1]}}`, `{"a": [1]}`, `{"a": [2]}`, `/: expected {"a":[1]} but got {"a":[2]}`},
+		{`{"minimum": 1, "exclusiveMaximum": 3}`, `2.5`, `3`, "/: expected a number less than 3 but got 3"},
+		{`{"maximum": 1, "exclusiveMinimum": 0}`, `1`, `0`, "/: expected a number greater than 0 but got 0"},
+		{`{"minimum": 1}`, `"a"`, `0.5`, "/: expected a number greater than or equal to 1 but got 0.5"},
+		{`{"multipleOf": 0.1}`, `0.3`, `0.35`, "/: expected a multiple of 0.1 but got 0.35"},
+		{`{"minLength": 2, "maxLength": 3}`, `"éé"`, `"abcd"`, `/: expected a string of at most 3 character(s) but got "abcd"`},
+		{`{"pattern": "^a+$"}`, `"aa"`, `"ab"`, `/: expected a string matching "^a+$" but got "ab"`},
+		{`{"items": {"type": "string"}}`, `["a"]`, `["a", 1]`, "/1: expected string but got number 1"},
+		{`{"type": "array", "items": {"$ref": "#"}}`, `[[], [[]]]`, `[[1]]`, "/0/0: expected array but got number 1"},
+		{`{"items": [{"type": "string"}], "additionalItems": false}`, `["a"]`, `["a", 1]`, "/1: no value is allowed here, got 1"},
+		{`{"minItems": 1, "maxItems": 2}`, `[1, 2]`, `[]`, "/: expected an array of at least 1 element(s) but got 0"},
+		{`{"uniqueItems": true}`, `[1, "1"]`, `[1, 2, 1.0]`, "/: expected unique elements but elements 0 and 2 are both 1"},
+		{`{"contains": {"type": "null"}}`, `[1, null]`, `[1]`, `/: expected an array containing an element matching {"type":"null"}`},
+		{`{"properties": {"a": {"type": "string"}}}`, `{"a": "x", "b": 1}`, `{"a": 1}`, "/a: expected string but got number 1"},
+		{`{"patternProperties": {"^x-": {"type": "integer"}}, "additionalProperties": false}`, `{"x-a": 1}`, `{"x-a": 1, "b": 2}`, "/b: unexpected property 2"},
+		{`{"additionalProperties": {"type": "integer"}}`, `{"a": 1}`, `{"a": "1"}`, `/a: expected integer but got string "1"`},
+		{`{"required": ["a", "b"]}`, `{"a": 1, "b": 2}`, `{"a": 1}`, "/b: required but missing"},
+		{`{"minProperties": 1, "maxProperties": 1}`, `{"a": 1}`, `{"a": 1, "b": 2}`, "/: expected an object of at most 1 property(ies) but got 2"},
+		{`{"propertyNames": {"pattern": "^[a-z]+$"}}`, `{"ab": 1}`, `{"a-b": 1}`, `property name /a-b: expected a string matching`},
+		{`{"dependencies": {"a": ["b"]}}`, `{"a": 1, "b": 2}`, `{"a": 1}`, `/b: required by "a" but missing`},
+		{`{"dependencies": {"a": {"required": ["c"]}}}`, `{"b": 1}`, `{"a": 1}`, "/c: required but missing"},
+		{`{"allOf": [{"type": "integer"}, {"minimum": 2}]}`, `2`, `1`, "/: expected a number greater than or equal to 2 but got 1"},
+		{`{"anyOf": [{"type": "string"}, {"type": "null"}]}`, `"a"`, `1`, "/: expected a value matching any of the anyOf schemas"},
+		{`{"oneOf": [{"type": "integer"}, {"minimum": 2}]}`, `1`, `3`, "/: expected a value matching exactly one of the oneOf schemas but 2 match"},
+		{`{"not": {"type": "string"}}`, `1`, `"a"`, `/: expected a value not matching {"type":"string"} but got "a"`},
+		{`{"if": {"type": "string"}, "then": {"minLength": 1}, "else": {"type": "integer"}}`, `"a"`, `1.5`, "/: expected integer but got number 1.5"},
+		{`{"if": {"type": "string"}, "then": {"minLength": 1}}`, `1.5`, `""`, `/: expected a string of at least 1 character(s) but got ""`},
+		{`{"definitions": {"n": {"type": "integer"}}, "properties": {"a": {"$ref": "#/definitions/n"}}}`, `{"a": 1}`, `{"a": "1"}`, `/a: expected integer but got string "1"`},
+		{`{"properties": {"next": {"$ref": "#"}}, "required": ["v"]}`, `{"v": 1, "next": {"v": 2}}`, `{"v": 1, "next": {}}`, "/next/v: required but missing"},
+	} {
+		mockT := new(bufferT)
+		if c.valid != "" {
+			True(t, JSONMatchesSchema(mockT, c.schema, c.valid), "%s matches %s: %v", c.valid, c.schema, mockT.messages)
+		}
+		if c.invalid != "" {
+			False(t, JSONMatchesSchema(mockT, c.schema, c.invalid), "%s does not match %s", c.invalid, c.schema)
+			if Len(t, mockT.messages, 1, c.schema) {
+				Contains(t, mockT.messages[0], "JSON document does not match the schema")
+				Contains(t, mockT.messages[0], c.message, c.schema)
+			}
+		}
+	}
+}
+
+func TestJSONMatchesSchemaInvalid(t *testing.T) {
+	for _, c := range []struct {
+		schema, message string
+	}{
+		{`not json`, "Schema ('not json') is not valid json"},
+		{`1`, "schema must be an object or a boolean, not 1"},
+		{`{"type": 1}`, `invalid "type" keyword: 1`},
+		{`{"minLength": -1}`, `invalid "minLength" keyword: -1`},
+		{`{"pattern": "("}`, `invalid "pattern" keyword: "("`},
+		{`{"$ref": "other.json"}`, `unsupported $ref "other.json"`},
+		{`{"$ref": "#/definitions/missing"}`, `$ref "#/definitions/missing" does not point to a schema`},
+		{`{"$ref": "#"}`, `$ref "#" loops at / without validating any value`},
+		{`{"definitions": {"a": {"allOf": [{"$ref": "#/definitions/b"}]}, "b": {"not": {"$ref": "#/definitions/a"}}}, "$ref": "#/definitions/a"}`, `loops at /`},
+	} {
+		mockT := new(bufferT)
+		False(t, JSONMatchesSchema(mockT, c.schema, `"a"`), c.schema)
+		if Len(t, mockT.messages, 1, c.schema) {
+			Contains(t, mockT.messages[0], c.message, c.schema)
+		}
+	}
+
+	mockT := new(bufferT)
+	False(t, JSONMatchesSchema(mockT, `{}`, `not json`))
+	Contains(t, mockT.messages[0], "needs to be valid json")
+}
+
+func TestJSONMatchesSchemaFile(t *testing.T) {
+	mockT := new(bufferT)
+	True(t, JSONMatchesSchemaFile(mockT, "testdata/user.schema.json", `{"id": 1, "name": "Mat", "tags": ["admin"]}`))
+	Empty(t, mockT.messages)
+
+	False(t, JSONMatchesSchemaFile(mockT, "testdata/user.schema.json", `{"id": 0, "tags": ["Admin", "x", "x"], "age": 3}`))
+	if Len(t, mockT.messages, 1) {
+		message := mockT.messages[0]
+		for _, line := range []string{
+			"/age: unexpected property 3",
+			"/id: expected a number greater than or equal to 1 but got 0",
+			"/name: required but missing",
+			`/tags/0: expected a string matching "^[a-z]+$" but got "Admin"`,
+			`/tags: expected unique elements but elements 1 and 2 are both "x"`,
+		} {
+			Contains(t, message, line)
+		}
+		Equal(t, 1, strings.Count(message, "/age"))
+	}
+
+	mockT = new(bufferT)
+	False(t, JSONMatchesSchemaFile(mockT, "testdata/missing.schema.json", `{}`))
+	Contains(t, mockT.messages[0], "Cannot read schema file testdata/missing.schema.json")
+}
+
+func TestJSONWrappers(t *testing.T) {
+	assert := New(new(testing.T))
+	True(t, assert.JSONContains(`{"a": 1}`, `{"a": 1, "b": 2}`))
+	True(t, assert.JSONPathEq(`{"a": [1]}`, "$.a[0]", 1))
+	True(t, assert.JSONMatchesSchema(`{"type": "object"}`, `{}`))
+	True(t, assert.JSONMatchesSchemaFile("testdata/user.schema.json", `{"id": 1, "name": "a"}`))
+	False(t, assert.JSONPathEq(`{"a": [1]}`, "$.a[0]", 2))
+}
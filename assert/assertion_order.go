@@ -0,0 +1,345 @@
+package assert
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// compare compares two numbers, strings, times or durations, and returns
+// -1, 0 or 1 if obj1 is less than, equal to or greater than obj2.
+// Integers are compared exactly, whatever their kinds, and numbers are
+// compared as floats when either is a float.  NaN cannot be compared, as
+// it is neither less than, equal to nor greater than any number.
+func compare(obj1, obj2 interface{}) (int, error) {
+	if t1, ok := obj1.(time.Time); ok {
+		t2, ok := obj2.(time.Time)
+		if !ok {
+			return 0, fmt.Errorf("Cannot compare %T and %T", obj1, obj2)
+		}
+		switch {
+		case t1.Before(t2):
+			return -1, nil
+		case t1.After(t2):
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	v1, v2 := reflect.ValueOf(obj1), reflect.ValueOf(obj2)
+	if !v1.IsValid() || !v2.IsValid() {
+		return 0, fmt.Errorf("Cannot compare %T and %T", obj1, obj2)
+	}
+	k1, k2 := v1.Kind(), v2.Kind()
+	switch {
+	case isIntKind(k1) && isIntKind(k2):
+		return sign(v1.Int() < v2.Int(), v1.Int() > v2.Int()), nil
+	case isUintKind(k1) && isUintKind(k2):
+		return sign(v1.Uint() < v2.Uint(), v1.Uint() > v2.Uint()), nil
+	case isIntKind(k1) && isUintKind(k2):
+		return compareIntUint(v1.Int(), v2.Uint()), nil
+	case isUintKind(k1) && isIntKind(k2):
+		return -compareIntUint(v2.Int(), v1.Uint()), nil
+	case k1 == reflect.String && k2 == reflect.String:
+		return sign(v1.String() < v2.String(), v1.String() > v2.String()), nil
+	case isNumberKind(k1) && isNumberKind(k2):
+		f1, _ := toFloat(obj1)
+		f2, _ := toFloat(obj2)
+		if math.IsNaN(f1) || math.IsNaN(f2) {
+			return 0, fmt.Errorf("Cannot compare NaN")
+		}
+		return sign(f1 < f2, f1 > f2), nil
+	}
+	return 0, fmt.Errorf("Cannot compare %T and %T", obj1, obj2)
+}
+
+// sign returns -1 if less, 1 if greater and 0 otherwise.
+func sign(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	}
+	return 0
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return isIntKind(k) || isUintKind(k) || k == reflect.Float32 || k == reflect.Float64
+}
+
+// compareIntUint compares a signed and an unsigned integer exactly, as
+// compare does.
+func compareIntUint(i int64, u uint64) int {
+	if i < 0 {
+		return -1
+	}
+	return sign(uint64(i) < u, uint64(i) > u)
+}
+
+// compareOrdered asserts that comparing e1 to e2 gives one of the allowed
+// results, and reports that e1 is not in relation to e2 otherwise.
+func compareOrdered(t TestingT, e1, e2 interface{}, allowed []int, relation string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	result, err := compare(e1, e2)
+	if err != nil {
+		return Fail(t, err.Error(), msgAndArgs...)
+	}
+	for _, a := range allowed {
+		if result == a {
+			return true
+		}
+	}
+	return failWith(t, Failure{
+		Message:  fmt.Sprintf("\"%v\" is not %s \"%v\"", e1, relation, e2),
+		Expected: fmt.Sprintf("%s %v", relation, e2),
+		Actual:   fmt.Sprintf("%v", e1),
+	}, msgAndArgs...)
+}
+
+// compareZero asserts that comparing the number e to zero gives result,
+// and reports that e is not as described otherwise.
+func compareZero(t TestingT, e interface{}, result int, description string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !isNumberKind(reflect.ValueOf(e).Kind()) {
+		return Fail(t, fmt.Sprintf("Cannot compare %T to zero", e), msgAndArgs...)
+	}
+	actual, err := compare(e, reflect.Zero(reflect.TypeOf(e)).Interface())
+	if err != nil {
+		return Fail(t, err.Error(), msgAndArgs...)
+	}
+	if actual != result {
+		return failWith(t, Failure{
+			Message:  fmt.Sprintf("\"%v\" is not %s", e, description),
+			Expected: description,
+			Actual:   fmt.Sprintf("%v", e),
+		}, msgAndArgs...)
+	}
+	return true
+}
+
+// Greater asserts that the first element is greater than the second.
+//
+//    assert.Greater(t, 2, 1)
+//    assert.Greater(t, float64(2), float64(1))
+//    assert.Greater(t, "b", "a")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func Greater(t TestingT, e1, e2 interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return compareOrdered(t, e1, e2, []int{1}, "greater than", msgAndArgs...)
+}
+
+// GreaterOrEqual asserts that the first element is greater than or equal
+// to the second.
+//
+//    assert.GreaterOrEqual(t, 2, 1)
+//    assert.GreaterOrEqual(t, 2, 2)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func GreaterOrEqual(t TestingT, e1, e2 interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return compareOrdered(t, e1, e2, []int{1, 0}, "greater than or equal to", msgAndArgs...)
+}
+
+// Less asserts that the first element is less than the second.
+//
+//    assert.Less(t, 1, 2)
+//    assert.Less(t, time.Second, time.Minute)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func Less(t TestingT, e1, e2 interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return compareOrdered(t, e1, e2, []int{-1}, "less than", msgAndArgs...)
+}
+
+// LessOrEqual asserts that the first element is less than or equal to the
+// second.
+//
+//    assert.LessOrEqual(t, 1, 2)
+//    assert.LessOrEqual(t, 2, 2)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func LessOrEqual(t TestingT, e1, e2 interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return compareOrdered(t, e1, e2, []int{-1, 0}, "less than or equal to", msgAndArgs...)
+}
+
+// Positive asserts that the specified number is positive.
+//
+//    assert.Positive(t, 1)
+//    assert.Positive(t, time.Second)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func Positive(t TestingT, e interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return compareZero(t, e, 1, "positive", msgAndArgs...)
+}
+
+// Negative asserts that the specified number is negative.
+//
+//    assert.Negative(t, -1)
+//    assert.Negative(t, -time.Second)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func Negative(t TestingT, e interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return compareZero(t, e, -1, "negative", msgAndArgs...)
+}
+
+// InRange asserts that the specified value is between min and max,
+// inclusive.
+//
+//    assert.InRange(t, 5, 1, 10)
+//    assert.InRange(t, elapsed, time.Second, 2*time.Second)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func InRange(t TestingT, value, min, max interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	low, err := compare(value, min)
+	if err != nil {
+		return Fail(t, err.Error(), msgAndArgs...)
+	}
+	high, err := compare(value, max)
+	if err != nil {
+		return Fail(t, err.Error(), msgAndArgs...)
+	}
+	if low < 0 || high > 0 {
+		return failWith(t, Failure{
+			Message:  fmt.Sprintf("\"%v\" is not in range [\"%v\", \"%v\"]", value, min, max),
+			Expected: fmt.Sprintf("in range [%v, %v]", min, max),
+			Actual:   fmt.Sprintf("%v", value),
+		}, msgAndArgs...)
+	}
+	return true
+}
+
+// isOrdered asserts that comparing each element of list to the next one
+// gives one of the allowed results, and reports the offending index
+// otherwise.
+func isOrdered(t TestingT, list interface{}, allowed []int, description string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return Fail(t, fmt.Sprintf("\"%v\" is not a slice or an array", list), msgAndArgs...)
+	}
+	for i := 1; i < v.Len(); i++ {
+		prev, next := v.Index(i-1).Interface(), v.Index(i).Interface()
+		result, err := compare(prev, next)
+		if err != nil {
+			return Fail(t, err.Error(), msgAndArgs...)
+		}
+		ok := false
+		for _, a := range allowed {
+			ok = ok || result == a
+		}
+		if !ok {
+			return failWith(t, Failure{
+				Message:  fmt.Sprintf("List is not %s: \"%v\" at index %d is followed by \"%v\" at index %d", description, prev, i-1, next, i),
+				Expected: description,
+				Actual:   fmt.Sprintf("%v", list),
+				Diff:     fmt.Sprintf("[%d]: %v\n[%d]: %v", i-1, prev, i, next),
+			}, msgAndArgs...)
+		}
+	}
+	return true
+}
+
+// IsIncreasing asserts that the elements of the collection are strictly
+// increasing.
+//
+//    assert.IsIncreasing(t, []int{1, 2, 3})
+//    assert.IsIncreasing(t, []string{"a", "b"})
+//
+// Returns whether the assertion was successful (true) or not (false).
+func IsIncreasing(t TestingT, list interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return isOrdered(t, list, []int{-1}, "increasing", msgAndArgs...)
+}
+
+// IsDecreasing asserts that the elements of the collection are strictly
+// decreasing.
+//
+//    assert.IsDecreasing(t, []int{3, 2, 1})
+//
+// Returns whether the assertion was successful (true) or not (false).
+func IsDecreasing(t TestingT, list interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return isOrdered(t, list, []int{1}, "decreasing", msgAndArgs...)
+}
+
+// IsSorted asserts that the elements of the collection are sorted
+// according to less, which must be a func(a, b T) bool reporting whether
+// a sorts before b for elements of type T, as for sort.Slice.  Equal
+// elements may follow each other.  A nil less sorts numbers, strings,
+// times and durations in their natural order.
+//
+//    assert.IsSorted(t, users, func(a, b User) bool { return a.Age < b.Age })
+//
+// Returns whether the assertion was successful (true) or not (false).
+func IsSorted(t TestingT, list interface{}, less interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if less == nil {
+		return isOrdered(t, list, []int{-1, 0}, "sorted", msgAndArgs...)
+	}
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return Fail(t, fmt.Sprintf("\"%v\" is not a slice or an array", list), msgAndArgs...)
+	}
+	lessFunc := reflect.ValueOf(less)
+	lessType := lessFunc.Type()
+	elemType := v.Type().Elem()
+	if lessFunc.Kind() != reflect.Func || lessType.NumIn() != 2 || lessType.NumOut() != 1 ||
+		!elemType.AssignableTo(lessType.In(0)) || !elemType.AssignableTo(lessType.In(1)) ||
+		lessType.Out(0).Kind() != reflect.Bool {
+		return Fail(t, fmt.Sprintf("Less function must be a func(a, b %s) bool, not %T", elemType, less), msgAndArgs...)
+	}
+
+	for i := 1; i < v.Len(); i++ {
+		prev, next := v.Index(i-1), v.Index(i)
+		if lessFunc.Call([]reflect.Value{next, prev})[0].Bool() {
+			return failWith(t, Failure{
+				Message:  fmt.Sprintf("List is not sorted: \"%v\" at index %d sorts before \"%v\" at index %d", next.Interface(), i, prev.Interface(), i-1),
+				Expected: "sorted",
+				Actual:   fmt.Sprintf("%v", list),
+				Diff:     fmt.Sprintf("[%d]: %v\n[%d]: %v", i-1, prev.Interface(), i, next.Interface()),
+			}, msgAndArgs...)
+		}
+	}
+	return true
+}
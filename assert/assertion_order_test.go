@@ -0,0 +1,149 @@
+package assert
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+type celsius float64
+
+func TestCompare(t *testing.T) {
+	now := time.Now()
+	for _, c := range []struct {
+		less, greater interface{}
+	}{
+		{1, 2},
+		{int8(-1), int8(1)},
+		{uint64(1 << 63), uint64(1<<63 + 1)},
+		{int64(1<<62 + 1), int64(1<<62 + 2)},
+		{float32(1.5), float32(2.5)},
+		{1, 1.5},
+		{uint(1), int64(2)},
+		{int64(1<<62 + 1), uint64(1<<62 + 2)},
+		{int8(-1), uint(0)},
+		{-1, uint64(1 << 63)},
+		{uint64(1<<63 - 2), int64(1<<63 - 1)},
+		{int64(1<<53 + 1), uint64(1<<63 + 1)},
+		{uint8(1), 1.5},
+		{"a", "b"},
+		{time.Second, time.Minute},
+		{now, now.Add(time.Nanosecond)},
+		{celsius(-3), celsius(20)},
+	} {
+		result, err := compare(c.less, c.greater)
+		if NoError(t, err) {
+			Equal(t, -1, result, "%v < %v", c.less, c.greater)
+		}
+		result, _ = compare(c.greater, c.less)
+		Equal(t, 1, result, "%v > %v", c.greater, c.less)
+		result, _ = compare(c.less, c.less)
+		Equal(t, 0, result, "%v == %v", c.less, c.less)
+	}
+
+	for _, c := range [][2]interface{}{{1, "1"}, {now, 1}, {nil, 1}, {[]int{}, []int{}}, {math.NaN(), 1.0}, {1, math.NaN()}, {float32(math.NaN()), float32(math.NaN())}} {
+		_, err := compare(c[0], c[1])
+		Error(t, err, "%T and %T", c[0], c[1])
+	}
+}
+
+func TestGreaterAndLess(t *testing.T) {
+	mockT := new(bufferT)
+
+	True(t, Greater(mockT, 2, 1))
+	True(t, GreaterOrEqual(mockT, 2, 2))
+	True(t, Less(mockT, "a", "b"))
+	True(t, LessOrEqual(mockT, time.Second, time.Second))
+	True(t, Positive(mockT, time.Second))
+	True(t, Negative(mockT, celsius(-1)))
+	True(t, InRange(mockT, 5, 1, 10))
+	True(t, InRange(mockT, 1.0, 1, 10))
+	Empty(t, mockT.messages)
+
+	False(t, Greater(mockT, 1, 2))
+	False(t, GreaterOrEqual(mockT, 1, 2))
+	False(t, Less(mockT, time.Minute, time.Second))
+	False(t, LessOrEqual(mockT, "b", "a"))
+	False(t, Positive(mockT, 0))
+	False(t, Negative(mockT, uint(1)))
+	False(t, Positive(mockT, "1"))
+	False(t, InRange(mockT, 11, 1, 10))
+	False(t, Greater(mockT, 1, "0"))
+	if Len(t, mockT.messages, 9) {
+		Contains(t, mockT.messages[0], `"1" is not greater than "2"`)
+		Contains(t, mockT.messages[1], `"1" is not greater than or equal to "2"`)
+		Contains(t, mockT.messages[2], `"1m0s" is not less than "1s"`)
+		Contains(t, mockT.messages[3], `"b" is not less than or equal to "a"`)
+		Contains(t, mockT.messages[4], `"0" is not positive`)
+		Contains(t, mockT.messages[5], `"1" is not negative`)
+		Contains(t, mockT.messages[6], "Cannot compare string to zero")
+		Contains(t, mockT.messages[7], `"11" is not in range ["1", "10"]`)
+		Contains(t, mockT.messages[8], "Cannot compare int and string")
+	}
+}
+
+func TestIsOrdered(t *testing.T) {
+	mockT := new(bufferT)
+
+	True(t, IsIncreasing(mockT, []int{1, 2, 3}))
+	True(t, IsDecreasing(mockT, [3]string{"c", "b", "a"}))
+	True(t, IsSorted(mockT, []float64{1, 1, 2}, nil))
+	True(t, IsSorted(mockT, []string{"bb", "a", "c"}, func(a, b string) bool { return len(a) > len(b) }))
+	True(t, IsIncreasing(mockT, []int{}))
+	Empty(t, mockT.messages)
+
+	False(t, IsIncreasing(mockT, []int{1, 2, 2}))
+	False(t, IsDecreasing(mockT, []time.Duration{3, 1, 2}))
+	False(t, IsSorted(mockT, []int{1, 3, 2}, nil))
+	False(t, IsSorted(mockT, []string{"a", "bb"}, func(a, b string) bool { return len(a) > len(b) }))
+	False(t, IsSorted(mockT, []string{"a"}, func(a, b int) bool { return a < b }))
+	False(t, IsIncreasing(mockT, 1))
+	if Len(t, mockT.messages, 6) {
+		Contains(t, mockT.messages[0], `List is not increasing: "2" at index 1 is followed by "2" at index 2`)
+		Contains(t, mockT.messages[1], `List is not decreasing: "1ns" at index 1 is followed by "2ns" at index 2`)
+		Contains(t, mockT.messages[2], `List is not sorted: "3" at index 1 is followed by "2" at index 2`)
+		Contains(t, mockT.messages[3], `List is not sorted: "bb" at index 1 sorts before "a" at index 0`)
+		Contains(t, mockT.messages[4], "Less function must be a func(a, b string) bool, not func(int, int) bool")
+		Contains(t, mockT.messages[5], `"1" is not a slice or an array`)
+	}
+}
+
+func TestOrderedNaN(t *testing.T) {
+	mockT := new(bufferT)
+	nan := math.NaN()
+
+	False(t, Greater(mockT, nan, 1.0))
+	False(t, Greater(mockT, 1.0, nan))
+	False(t, GreaterOrEqual(mockT, nan, nan))
+	False(t, Less(mockT, nan, 1))
+	False(t, LessOrEqual(mockT, 1, nan))
+	False(t, Positive(mockT, nan))
+	False(t, Negative(mockT, float32(nan)))
+	False(t, InRange(mockT, nan, 1.0, 10.0))
+	False(t, InRange(mockT, 5.0, nan, 10.0))
+	False(t, InRange(mockT, 5.0, 1.0, nan))
+	False(t, IsIncreasing(mockT, []float64{1, nan, 2}))
+	False(t, IsDecreasing(mockT, []float64{2, 1, nan}))
+	False(t, IsSorted(mockT, []float64{nan, nan}, nil))
+	if Len(t, mockT.messages, 13) {
+		for _, message := range mockT.messages {
+			Contains(t, message, "Cannot compare NaN")
+		}
+	}
+}
+
+func TestOrderedWrappers(t *testing.T) {
+	assert := New(t)
+	mockAssert := New(new(bufferT))
+
+	assert.True(mockAssert.Greater(2, 1))
+	assert.False(mockAssert.GreaterOrEqual(1, 2))
+	assert.True(mockAssert.Less(1, 2))
+	assert.False(mockAssert.LessOrEqual(2, 1))
+	assert.True(mockAssert.Positive(1))
+	assert.False(mockAssert.Negative(1))
+	assert.True(mockAssert.InRange(1, 1, 1))
+	assert.False(mockAssert.IsIncreasing([]int{2, 1}))
+	assert.True(mockAssert.IsDecreasing([]int{2, 1}))
+	assert.True(mockAssert.IsSorted([]int{1, 2}, nil))
+}
@@ -0,0 +1,104 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseYAMLPair parses the expected and actual YAML streams of an
+// assertion, reporting a failure if either is invalid.
+func parseYAMLPair(t TestingT, expected, actual string, msgAndArgs ...interface{}) ([]interface{}, []interface{}, bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	expectedYAML, err := parseYAML(expected)
+	if err != nil {
+		return nil, nil, Fail(t, fmt.Sprintf("Expected value ('%s') is not valid yaml.\nYAML parsing error: '%s'", expected, err.Error()), msgAndArgs...)
+	}
+	actualYAML, err := parseYAML(actual)
+	if err != nil {
+		return nil, nil, Fail(t, fmt.Sprintf("Input ('%s') needs to be valid yaml.\nYAML parsing error: '%s'", actual, err.Error()), msgAndArgs...)
+	}
+	return expectedYAML, actualYAML, true
+}
+
+// yamlDiff returns the differences between the documents of two YAML
+// streams, as jsonDiff does.  The differences are prefixed with the
+// number of their document when the streams hold several.
+func yamlDiff(expected, actual []interface{}, subset bool) []string {
+	if len(expected) != len(actual) {
+		return []string{fmt.Sprintf("expected %d document(s) but got %d", len(expected), len(actual))}
+	}
+	diffs := []string{}
+	for i := range expected {
+		for _, diff := range jsonDiff(expected[i], actual[i], "", subset) {
+			if len(expected) > 1 {
+				diff = fmt.Sprintf("document %d: %s", i+1, diff)
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
+
+// YAMLEq asserts that two YAML strings are equivalent: they hold the same
+// documents, whatever the order of the keys of their mappings and however
+// their scalars are written, so that 0x10 and 16, or 'yes' and "yes", are
+// equal.  Differences are reported by JSON pointer.
+//
+// The strings are parsed as the subset of YAML 1.2 used by configuration
+// files: block and flow collections, plain, quoted and block scalars,
+// anchors and aliases, merge keys (<<) in block mappings, the standard
+// tags !!str, !!null, !!bool, !!int, !!float, !!seq and !!map, and streams
+// of several documents.  Scalars are resolved with the core schema, so
+// that yes is a string, not a boolean.  Explicit keys (?), other tags and
+// directives other than %YAML 1.2 are not supported, and fail the
+// assertion as invalid YAML.
+//
+//  assert.YAMLEq(t, "a: 1\nb: [x, y]\n", "b:\n- x\n- y\na: 1\n")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func YAMLEq(t TestingT, expected string, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	expectedYAML, actualYAML, ok := parseYAMLPair(t, expected, actual, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	if diffs := yamlDiff(expectedYAML, actualYAML, false); len(diffs) > 0 {
+		return failWith(t, Failure{
+			Message:  fmt.Sprintf("YAML documents are not equivalent:\n%s", strings.Join(diffs, "\n")),
+			Expected: expected,
+			Actual:   actual,
+			Diff:     strings.Join(diffs, "\n"),
+		}, msgAndArgs...)
+	}
+	return true
+}
+
+// YAMLContains asserts that each document of the actual YAML stream
+// contains the matching document of the expected one, as JSONContains
+// does.
+//
+//  assert.YAMLContains(t, "name: Mat\n", "id: 1\nname: Mat\n")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func YAMLContains(t TestingT, expected string, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	expectedYAML, actualYAML, ok := parseYAMLPair(t, expected, actual, msgAndArgs...)
+	if !ok {
+		return false
+	}
+	if diffs := yamlDiff(expectedYAML, actualYAML, true); len(diffs) > 0 {
+		return failWith(t, Failure{
+			Message:  fmt.Sprintf("YAML document does not contain the expected one:\n%s", strings.Join(diffs, "\n")),
+			Expected: expected,
+			Actual:   actual,
+			Diff:     strings.Join(diffs, "\n"),
+		}, msgAndArgs...)
+	}
+	return true
+}
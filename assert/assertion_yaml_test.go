@@ -0,0 +1,78 @@
+package assert
+
+import (
+	"testing"
+)
+
+const yamlConfig = `
+# service configuration
+name: api
+replicas: 3
+ports:
+  - name: http
+    port: 8080
+  - name: metrics
+    port: 9090
+limits: {cpu: 0.5, memory: 256Mi}
+`
+
+func TestYAMLEq(t *testing.T) {
+	mockT := new(bufferT)
+	True(t, YAMLEq(mockT, yamlConfig, `
+limits:
+  memory: "256Mi"
+  cpu: .50
+ports: [{name: http, port: 0x1F90}, {port: 9090, name: metrics}]
+replicas: 3.0
+name: 'api'
+`))
+	True(t, YAMLEq(mockT, `{"name": "api", "replicas": 3}`, "replicas: 3\nname: api\n"))
+	True(t, YAMLEq(mockT, "a: 1\n---\nb: 2\n", "---\na: 1.0\n...\n---\nb: 2\n"))
+	Empty(t, mockT.messages)
+
+	False(t, YAMLEq(mockT, yamlConfig, `
+name: api
+replicas: "3"
+ports:
+  - {name: http, port: 8080}
+  - {name: admin, port: 9090}
+limits: {cpu: 0.5, memory: 256Mi, disk: 1Gi}
+`))
+	if Len(t, mockT.messages, 1) {
+		message := mockT.messages[0]
+		Contains(t, message, "YAML documents are not equivalent")
+		Contains(t, message, `/limits/disk: unexpected "1Gi"`)
+		Contains(t, message, `/ports/1/name: expected "metrics" but got "admin"`)
+		Contains(t, message, `/replicas: expected number 3 but got string "3"`)
+	}
+}
+
+func TestYAMLEqStreams(t *testing.T) {
+	mockT := new(bufferT)
+	False(t, YAMLEq(mockT, "a: 1\n---\nb: 2\n", "a: 1\n---\nb: 3\n"))
+	False(t, YAMLEq(mockT, "a: 1\n", "a: 1\n---\na: 1\n"))
+	False(t, YAMLEq(mockT, "a: 1\n", "a: [1\n"))
+	False(t, YAMLEq(mockT, "a: [1\n", "a: 1\n"))
+	if Len(t, mockT.messages, 4) {
+		Contains(t, mockT.messages[0], "document 2: /b: expected 2 but got 3")
+		NotContains(t, mockT.messages[0], "document 1")
+		Contains(t, mockT.messages[1], "expected 1 document(s) but got 2")
+		Contains(t, mockT.messages[2], "needs to be valid yaml")
+		Contains(t, mockT.messages[3], "is not valid yaml")
+	}
+}
+
+func TestYAMLContains(t *testing.T) {
+	mockT := new(bufferT)
+	True(t, YAMLContains(mockT, "name: api\nports:\n- port: 8080\n- {}\n", yamlConfig))
+	True(t, YAMLContains(mockT, "a: 1\n---\n{}\n", "a: 1\nb: 2\n---\nc: 3\n"))
+	Empty(t, mockT.messages)
+
+	False(t, YAMLContains(mockT, "name: api\nreplicas: 2\n", yamlConfig))
+	False(t, YAMLContains(mockT, "ports: [{}]\n", yamlConfig))
+	if Len(t, mockT.messages, 2) {
+		Contains(t, mockT.messages[0], "YAML document does not contain the expected one")
+		Contains(t, mockT.messages[0], "/replicas: expected 2 but got 3")
+		Contains(t, mockT.messages[1], "/ports: expected an array of 1 element(s) but got 2")
+	}
+}
@@ -0,0 +1,170 @@
+package assert
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Collector is a TestingT collecting the failures of assertions, to
+// report them as a single failure instead of one per assertion.  It
+// embeds the Assertions of itself, so that c.Equal(...) and
+// assert.Equal(c, ...) both collect.  A Collector is safe for concurrent
+// use.
+type Collector struct {
+	*Assertions
+
+	t             TestingT
+	mutex         sync.Mutex
+	failures      []Failure
+	stopOnFailure bool
+}
+
+// stopCollecting is the value FailNow panics with to stop the function
+// passed to Collect.
+type stopCollecting struct{}
+
+// failNower is the TestingT of require, which can stop the test.
+type failNower interface {
+	FailNow()
+}
+
+// NewCollector returns a Collector reporting the failures it collects to
+// t when Report is called.
+//
+//  c := assert.NewCollector(t)
+//  defer c.Report()
+//  c.Equal("Mat", user.Name)
+//  c.Equal(42, user.Age)
+func NewCollector(t TestingT) *Collector {
+	c := &Collector{t: t}
+	c.Assertions = New(c)
+	return c
+}
+
+// Batch returns a Collector of the assertions made through it, reporting
+// them to the TestingT of a when Report is called, as NewCollector does.
+//
+//  b := assert.New(t).Batch()
+//  defer b.Report()
+//  b.Equal("Mat", user.Name)
+//  b.Equal(42, user.Age)
+func (a *Assertions) Batch() *Collector {
+	return NewCollector(a.t)
+}
+
+// StopOnFailure makes Report stop the test with FailNow when something
+// failed, provided the TestingT of the Collector has a FailNow method as
+// that of require does.  It returns the Collector.
+func (c *Collector) StopOnFailure() *Collector {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.stopOnFailure = true
+	return c
+}
+
+// Errorf collects a failure reported directly through the TestingT,
+// rather than by an assertion of this package.
+func (c *Collector) Errorf(format string, args ...interface{}) {
+	c.collect(Failure{
+		Message: strings.TrimSpace(fmt.Sprintf(format, args...)),
+		Callers: CallerInfo(),
+	})
+}
+
+// FailNow reports the failures collected so far, and then stops the test
+// with FailNow if the TestingT of the Collector has that method, so that
+// the assertions of require stop the collection.  It panics otherwise,
+// which Collect recovers from.
+func (c *Collector) FailNow() {
+	c.Report()
+	if t, ok := c.t.(failNower); ok {
+		t.FailNow()
+	}
+	panic(stopCollecting{})
+}
+
+// collect records a failure.
+func (c *Collector) collect(failure Failure) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.failures = append(c.failures, failure)
+}
+
+// Failed returns whether the Collector collected failures that were not
+// reported yet.
+func (c *Collector) Failed() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.failures) > 0
+}
+
+// Report reports the failures collected so far as a single failure,
+// numbered in the order they happened and with the file and line numbers
+// of their assertion, and forgets them.
+//
+// Returns whether the assertions were successful (true) or not (false).
+func (c *Collector) Report(msgAndArgs ...interface{}) bool {
+	if h, ok := c.t.(tHelper); ok {
+		h.Helper()
+	}
+	c.mutex.Lock()
+	failures, stop := c.failures, c.stopOnFailure
+	c.failures = nil
+	c.mutex.Unlock()
+
+	if len(failures) == 0 {
+		return true
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%d assertion(s) failed:\n", len(failures))
+	for i, failure := range failures {
+		fmt.Fprintf(&b, "\n%d)", i+1)
+		if len(failure.Callers) > 0 {
+			fmt.Fprintf(&b, " %s", strings.Join(failure.Callers, ", "))
+		}
+		message := failure.Message
+		if len(failure.Messages) > 0 {
+			message += "\nMessages: " + failure.Messages
+		}
+		b.WriteString("\n\t")
+		b.WriteString(strings.Replace(message, "\n", "\n\t", -1))
+		b.WriteString("\n")
+	}
+	Fail(c.t, strings.TrimSuffix(b.String(), "\n"), msgAndArgs...)
+
+	if t, ok := c.t.(failNower); ok && stop {
+		t.FailNow()
+	}
+	return false
+}
+
+// Collect calls f with a Collector, and reports the failures of the
+// assertions made through it as a single failure once f returns, as
+// Collector.Report does.  The assertions of require stop f, like the
+// test.
+//
+//  assert.Collect(t, func(c *assert.Collector) {
+//    c.Equal("Mat", user.Name)
+//    assert.Equal(c, 42, user.Age)
+//  })
+//
+// Returns whether the assertion was successful (true) or not (false).
+func Collect(t TestingT, f func(c *Collector), msgAndArgs ...interface{}) (ok bool) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	c := NewCollector(t)
+	defer func() {
+		if v := recover(); v != nil {
+			if _, stopped := v.(stopCollecting); !stopped {
+				panic(v)
+			}
+			ok = false
+		}
+	}()
+	f(c)
+	return c.Report(msgAndArgs...)
+}
@@ -0,0 +1,120 @@
+package assert
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// stopT is a bufferT recording whether FailNow was called.
+type stopT struct {
+	bufferT
+	stopped bool
+}
+
+func (t *stopT) FailNow() {
+	t.stopped = true
+}
+
+func TestCollect(t *testing.T) {
+	mockT := new(bufferT)
+	True(t, Collect(mockT, func(c *Collector) {
+		c.Equal(1, 1)
+		Equal(c, "a", "a")
+	}))
+	Empty(t, mockT.messages)
+
+	False(t, Collect(mockT, func(c *Collector) {
+		c.Equal(1, 2, "first")
+		Nil(c, errors.New("oops"))
+		c.True(true)
+		c.Errorf("direct %d", 3)
+	}))
+	if Len(t, mockT.messages, 1, "the failures are reported once") {
+		message := mockT.messages[0]
+		Contains(t, message, "3 assertion(s) failed:")
+		Contains(t, message, "1)\n")
+		Contains(t, message, "Not equal: 1 (expected)")
+		Contains(t, message, "Messages: first")
+		Contains(t, message, "2)\n")
+		Contains(t, message, "Expected nil, but got: &errors.errorString")
+		Contains(t, message, "3)\n")
+		Contains(t, message, "direct 3")
+		True(t, strings.Index(message, "Not equal") < strings.Index(message, "Expected nil"), "the failures are in order")
+	}
+}
+
+func TestCollectStopsOnFailNow(t *testing.T) {
+	mockT := new(stopT)
+	after := false
+	False(t, Collect(mockT, func(c *Collector) {
+		c.Equal(1, 2)
+		c.FailNow()
+		after = true
+	}))
+	False(t, after, "FailNow stops the function")
+	True(t, mockT.stopped)
+	Len(t, mockT.messages, 1)
+
+	Panics(t, func() {
+		Collect(mockT, func(c *Collector) { panic("other") })
+	}, "other panics are not recovered")
+}
+
+func TestCollectorConcurrentUse(t *testing.T) {
+	mockT := new(bufferT)
+	False(t, Collect(mockT, func(c *Collector) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.Fail("failed")
+			}()
+		}
+		wg.Wait()
+	}))
+	if Len(t, mockT.messages, 1) {
+		Contains(t, mockT.messages[0], "10 assertion(s) failed:")
+	}
+}
+
+func TestCollectorReport(t *testing.T) {
+	mockT := new(bufferT)
+	c := NewCollector(mockT)
+	c.collect(Failure{
+		Message:  "Not equal:\nsecond line",
+		Messages: "name",
+		Callers:  []string{"user_test.go:12", "user_test.go:30"},
+	})
+	True(t, c.Failed())
+	False(t, c.Report("user %d", 1))
+	False(t, c.Failed(), "reported failures are forgotten")
+	True(t, c.Report())
+
+	if Len(t, mockT.messages, 1) {
+		Contains(t, mockT.messages[0], "1 assertion(s) failed:\n\t\n\t1) user_test.go:12, user_test.go:30\n\t\tNot equal:\n\t\tsecond line\n\t\tMessages: name\n")
+		Contains(t, mockT.messages[0], "Messages:\tuser 1")
+	}
+}
+
+func TestBatch(t *testing.T) {
+	mockT := new(stopT)
+	b := New(mockT).Batch()
+	b.Equal(1, 2)
+	b.Len([]int{}, 1)
+	Empty(t, mockT.messages, "nothing is reported before Report")
+	False(t, b.Report())
+	if Len(t, mockT.messages, 1) {
+		Contains(t, mockT.messages[0], "2 assertion(s) failed:")
+	}
+	False(t, mockT.stopped)
+
+	b = New(mockT).Batch().StopOnFailure()
+	True(t, b.Report())
+	False(t, mockT.stopped, "nothing failed")
+	b.Fail("failed")
+	False(t, b.Report())
+	True(t, mockT.stopped)
+}
@@ -1,6 +1,11 @@
 package assert
 
-import "time"
+import (
+	"os"
+	"time"
+
+	"github.com/stretchr/testify/clock"
+)
 
 // Assertions provides assertion methods around the
 // TestingT interface.
@@ -17,6 +22,9 @@ func New(t TestingT) *Assertions {
 
 // Fail reports a failure through
 func (a *Assertions) Fail(failureMessage string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return Fail(a.t, failureMessage, msgAndArgs...)
 }
 
@@ -24,11 +32,17 @@ func (a *Assertions) Fail(failureMessage string, msgAndArgs ...interface{}) bool
 //
 //    assert.Implements((*MyInterface)(nil), new(MyObject), "MyObject")
 func (a *Assertions) Implements(interfaceObject interface{}, object interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return Implements(a.t, interfaceObject, object, msgAndArgs...)
 }
 
 // IsType asserts that the specified objects are of the same type.
 func (a *Assertions) IsType(expectedType interface{}, object interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return IsType(a.t, expectedType, object, msgAndArgs...)
 }
 
@@ -38,6 +52,9 @@ func (a *Assertions) IsType(expectedType interface{}, object interface{}, msgAnd
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) Equal(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return Equal(a.t, expected, actual, msgAndArgs...)
 }
 
@@ -48,6 +65,9 @@ func (a *Assertions) Equal(expected, actual interface{}, msgAndArgs ...interface
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) EqualValues(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return EqualValues(a.t, expected, actual, msgAndArgs...)
 }
 
@@ -57,6 +77,9 @@ func (a *Assertions) EqualValues(expected, actual interface{}, msgAndArgs ...int
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) Exactly(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return Exactly(a.t, expected, actual, msgAndArgs...)
 }
 
@@ -66,6 +89,9 @@ func (a *Assertions) Exactly(expected, actual interface{}, msgAndArgs ...interfa
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) NotNil(object interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return NotNil(a.t, object, msgAndArgs...)
 }
 
@@ -75,6 +101,9 @@ func (a *Assertions) NotNil(object interface{}, msgAndArgs ...interface{}) bool
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) Nil(object interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return Nil(a.t, object, msgAndArgs...)
 }
 
@@ -85,6 +114,9 @@ func (a *Assertions) Nil(object interface{}, msgAndArgs ...interface{}) bool {
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) Empty(object interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return Empty(a.t, object, msgAndArgs...)
 }
 
@@ -97,6 +129,9 @@ func (a *Assertions) Empty(object interface{}, msgAndArgs ...interface{}) bool {
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) NotEmpty(object interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return NotEmpty(a.t, object, msgAndArgs...)
 }
 
@@ -107,6 +142,9 @@ func (a *Assertions) NotEmpty(object interface{}, msgAndArgs ...interface{}) boo
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) Len(object interface{}, length int, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return Len(a.t, object, length, msgAndArgs...)
 }
 
@@ -116,6 +154,9 @@ func (a *Assertions) Len(object interface{}, length int, msgAndArgs ...interface
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) True(value bool, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return True(a.t, value, msgAndArgs...)
 }
 
@@ -125,6 +166,9 @@ func (a *Assertions) True(value bool, msgAndArgs ...interface{}) bool {
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) False(value bool, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return False(a.t, value, msgAndArgs...)
 }
 
@@ -134,6 +178,9 @@ func (a *Assertions) False(value bool, msgAndArgs ...interface{}) bool {
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) NotEqual(expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return NotEqual(a.t, expected, actual, msgAndArgs...)
 }
 
@@ -143,6 +190,9 @@ func (a *Assertions) NotEqual(expected, actual interface{}, msgAndArgs ...interf
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) Contains(s, contains interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return Contains(a.t, s, contains, msgAndArgs...)
 }
 
@@ -152,11 +202,17 @@ func (a *Assertions) Contains(s, contains interface{}, msgAndArgs ...interface{}
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) NotContains(s, contains interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return NotContains(a.t, s, contains, msgAndArgs...)
 }
 
 // Condition uses a Comparison to assert a complex condition.
 func (a *Assertions) Condition(comp Comparison, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return Condition(a.t, comp, msgAndArgs...)
 }
 
@@ -168,6 +224,9 @@ func (a *Assertions) Condition(comp Comparison, msgAndArgs ...interface{}) bool
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) Panics(f PanicTestFunc, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return Panics(a.t, f, msgAndArgs...)
 }
 
@@ -179,6 +238,9 @@ func (a *Assertions) Panics(f PanicTestFunc, msgAndArgs ...interface{}) bool {
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) NotPanics(f PanicTestFunc, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return NotPanics(a.t, f, msgAndArgs...)
 }
 
@@ -188,6 +250,9 @@ func (a *Assertions) NotPanics(f PanicTestFunc, msgAndArgs ...interface{}) bool
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) WithinDuration(expected, actual time.Time, delta time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return WithinDuration(a.t, expected, actual, delta, msgAndArgs...)
 }
 
@@ -197,6 +262,9 @@ func (a *Assertions) WithinDuration(expected, actual time.Time, delta time.Durat
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) InDelta(expected, actual interface{}, delta float64, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return InDelta(a.t, expected, actual, delta, msgAndArgs...)
 }
 
@@ -204,6 +272,9 @@ func (a *Assertions) InDelta(expected, actual interface{}, delta float64, msgAnd
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) InEpsilon(expected, actual interface{}, epsilon float64, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return InEpsilon(a.t, expected, actual, epsilon, msgAndArgs...)
 }
 
@@ -216,6 +287,9 @@ func (a *Assertions) InEpsilon(expected, actual interface{}, epsilon float64, ms
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) NoError(theError error, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return NoError(a.t, theError, msgAndArgs...)
 }
 
@@ -228,6 +302,9 @@ func (a *Assertions) NoError(theError error, msgAndArgs ...interface{}) bool {
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) Error(theError error, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return Error(a.t, theError, msgAndArgs...)
 }
 
@@ -241,6 +318,9 @@ func (a *Assertions) Error(theError error, msgAndArgs ...interface{}) bool {
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) EqualError(theError error, errString string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return EqualError(a.t, theError, errString, msgAndArgs...)
 }
 
@@ -251,6 +331,9 @@ func (a *Assertions) EqualError(theError error, errString string, msgAndArgs ...
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) Regexp(rx interface{}, str interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return Regexp(a.t, rx, str, msgAndArgs...)
 }
 
@@ -261,16 +344,25 @@ func (a *Assertions) Regexp(rx interface{}, str interface{}, msgAndArgs ...inter
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) NotRegexp(rx interface{}, str interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return NotRegexp(a.t, rx, str, msgAndArgs...)
 }
 
 // Zero asserts that i is the zero value for its type and returns the truth.
 func (a *Assertions) Zero(i interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return Zero(a.t, i, msgAndArgs...)
 }
 
 // NotZero asserts that i is not the zero value for its type and returns the truth.
 func (a *Assertions) NotZero(i interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return NotZero(a.t, i, msgAndArgs...)
 }
 
@@ -280,5 +372,446 @@ func (a *Assertions) NotZero(i interface{}, msgAndArgs ...interface{}) bool {
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) JSONEq(expected string, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return JSONEq(a.t, expected, actual, msgAndArgs...)
 }
+
+// Greater asserts that the first element is greater than the second.
+//
+//    assert.Greater(t, 2, 1)
+//    assert.Greater(t, float64(2), float64(1))
+//    assert.Greater(t, "b", "a")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) Greater(e1, e2 interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return Greater(a.t, e1, e2, msgAndArgs...)
+}
+
+// GreaterOrEqual asserts that the first element is greater than or equal
+// to the second.
+//
+//    assert.GreaterOrEqual(t, 2, 1)
+//    assert.GreaterOrEqual(t, 2, 2)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) GreaterOrEqual(e1, e2 interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return GreaterOrEqual(a.t, e1, e2, msgAndArgs...)
+}
+
+// Less asserts that the first element is less than the second.
+//
+//    assert.Less(t, 1, 2)
+//    assert.Less(t, time.Second, time.Minute)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) Less(e1, e2 interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return Less(a.t, e1, e2, msgAndArgs...)
+}
+
+// LessOrEqual asserts that the first element is less than or equal to the
+// second.
+//
+//    assert.LessOrEqual(t, 1, 2)
+//    assert.LessOrEqual(t, 2, 2)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) LessOrEqual(e1, e2 interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return LessOrEqual(a.t, e1, e2, msgAndArgs...)
+}
+
+// Positive asserts that the specified number is positive.
+//
+//    assert.Positive(t, 1)
+//    assert.Positive(t, time.Second)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) Positive(e interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return Positive(a.t, e, msgAndArgs...)
+}
+
+// Negative asserts that the specified number is negative.
+//
+//    assert.Negative(t, -1)
+//    assert.Negative(t, -time.Second)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) Negative(e interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return Negative(a.t, e, msgAndArgs...)
+}
+
+// InRange asserts that the specified value is between min and max,
+// inclusive.
+//
+//    assert.InRange(t, 5, 1, 10)
+//    assert.InRange(t, elapsed, time.Second, 2*time.Second)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) InRange(value, min, max interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return InRange(a.t, value, min, max, msgAndArgs...)
+}
+
+// IsIncreasing asserts that the elements of the collection are strictly
+// increasing.
+//
+//    assert.IsIncreasing(t, []int{1, 2, 3})
+//    assert.IsIncreasing(t, []string{"a", "b"})
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) IsIncreasing(list interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return IsIncreasing(a.t, list, msgAndArgs...)
+}
+
+// IsDecreasing asserts that the elements of the collection are strictly
+// decreasing.
+//
+//    assert.IsDecreasing(t, []int{3, 2, 1})
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) IsDecreasing(list interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return IsDecreasing(a.t, list, msgAndArgs...)
+}
+
+// IsSorted asserts that the elements of the collection are sorted
+// according to less, which must be a func(a, b T) bool reporting whether
+// a sorts before b for elements of type T, as for sort.Slice.  Equal
+// elements may follow each other.  A nil less sorts numbers, strings,
+// times and durations in their natural order.
+//
+//    assert.IsSorted(t, users, func(a, b User) bool { return a.Age < b.Age })
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) IsSorted(list interface{}, less interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return IsSorted(a.t, list, less, msgAndArgs...)
+}
+
+// JSONPathEq asserts that the value found at path in the JSON document is
+// equal to the JSON encoding of expected.  The path starts with $, which
+// is the document, followed by .key or ["key"] to select the member of
+// an object and [index] to select the element of an array.
+//
+//  assert.JSONPathEq(`{"items": [{"id": 42}]}`, "$.items[0].id", 42)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) JSONPathEq(actual string, path string, expected interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return JSONPathEq(a.t, actual, path, expected, msgAndArgs...)
+}
+
+// JSONContains asserts that the actual JSON document contains the
+// expected one: its objects have at least the keys of the expected
+// objects, with values containing the expected values.  Arrays must have
+// the same length, and numbers the same value.
+//
+//  assert.JSONContains(`{"name": "Mat"}`, `{"id": 1, "name": "Mat"}`)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) JSONContains(expected string, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return JSONContains(a.t, expected, actual, msgAndArgs...)
+}
+
+// JSONMatchesSchema asserts that the JSON document is valid against the
+// draft-07 JSON Schema.  Only local references, such as
+// "#/definitions/item", are supported, and formats are not validated.
+//
+//  assert.JSONMatchesSchema(`{"type": "object", "required": ["id"]}`, `{"id": 1}`)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) JSONMatchesSchema(schema string, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return JSONMatchesSchema(a.t, schema, actual, msgAndArgs...)
+}
+
+// JSONMatchesSchemaFile asserts that the JSON document is valid against
+// the draft-07 JSON Schema stored in the file at path.
+//
+//  assert.JSONMatchesSchemaFile("testdata/user.schema.json", body)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) JSONMatchesSchemaFile(path string, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return JSONMatchesSchemaFile(a.t, path, actual, msgAndArgs...)
+}
+
+// YAMLEq asserts that two YAML strings are equivalent: they hold the same
+// documents, whatever the order of the keys of their mappings and however
+// their scalars are written, so that 0x10 and 16, or 'yes' and "yes", are
+// equal.  Differences are reported by JSON pointer.
+//
+//  assert.YAMLEq("a: 1\nb: [x, y]\n", "b:\n- x\n- y\na: 1\n")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) YAMLEq(expected string, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return YAMLEq(a.t, expected, actual, msgAndArgs...)
+}
+
+// YAMLContains asserts that each document of the actual YAML stream
+// contains the matching document of the expected one, as JSONContains
+// does.
+//
+//  assert.YAMLContains("name: Mat\n", "id: 1\nname: Mat\n")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) YAMLContains(expected string, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return YAMLContains(a.t, expected, actual, msgAndArgs...)
+}
+
+// FileExists asserts that the specified path exists and is not a
+// directory.
+//
+//  assert.FileExists("testdata/config.yaml")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) FileExists(path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return FileExists(a.t, path, msgAndArgs...)
+}
+
+// NoFileExists asserts that no file exists at the specified path.  A
+// directory at the path fails the assertion too.
+//
+//  assert.NoFileExists("output/tmp.lock")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) NoFileExists(path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return NoFileExists(a.t, path, msgAndArgs...)
+}
+
+// DirExists asserts that the specified path exists and is a directory.
+//
+//  assert.DirExists("output")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) DirExists(path string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DirExists(a.t, path, msgAndArgs...)
+}
+
+// FileContains asserts that the content of the file at the specified path
+// contains the specified substring.
+//
+//  assert.FileContains("output/log.txt", "server started")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) FileContains(path string, contains string, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return FileContains(a.t, path, contains, msgAndArgs...)
+}
+
+// FileEqual asserts that the content of the file at the specified path is
+// equal to expected, which is either a string, compared as text, or a
+// []byte, compared byte by byte.  Text differences are reported as a
+// unified diff.
+//
+//  assert.FileEqual("output/greeting.txt", "Hello\n")
+//  assert.FileEqual("output/image.png", pngBytes)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) FileEqual(path string, expected interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return FileEqual(a.t, path, expected, msgAndArgs...)
+}
+
+// FileMode asserts that the file at the specified path has the specified
+// permissions.  If mode has type bits too, such as os.ModeDir, they must
+// match as well.
+//
+//  assert.FileMode("bin/run.sh", 0755)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) FileMode(path string, mode os.FileMode, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return FileMode(a.t, path, mode, msgAndArgs...)
+}
+
+// DirMatches asserts that the directory holds exactly the files and
+// directories of the expected tree, with the same contents.  The expected
+// tree is either a Tree or the path of a directory, such as
+// "testdata/expected".  Failures show the tree of both directories, with
+// the missing (-), extra (+) and different (~) entries marked, followed
+// by the diffs of the different files.
+//
+//  assert.DirMatches(outputDir, assert.Tree{"a.txt": "A", "b/c.txt": "C"})
+//  assert.DirMatches(outputDir, "testdata/golden")
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) DirMatches(dir string, expectedTree interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return DirMatches(a.t, dir, expectedTree, msgAndArgs...)
+}
+
+// NoGoroutineLeaks asserts that the specified function does not leave
+// goroutines running once it returns.  The goroutines started by the
+// function are given one second to stop, and those still running then
+// are reported with their stacks, which end with the function that
+// created them.  Running the tests with GODEBUG=tracebackancestors=10
+// adds the stacks of the goroutines that created them.
+//
+//  assert.NoGoroutineLeaks(func() {
+//    server := NewServer()
+//    server.Close()
+//  })
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) NoGoroutineLeaks(f func(), msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return NoGoroutineLeaks(a.t, f, msgAndArgs...)
+}
+
+// NoGoroutineLeaksWith asserts that the specified function does not leave
+// goroutines running once it returns, as NoGoroutineLeaks does, with the
+// specified options.
+//
+//  assert.NoGoroutineLeaksWith(assert.GoroutineLeakOptions{
+//    Ignore: []*regexp.Regexp{regexp.MustCompile(`pool\.\(\*Pool\)\.reap`)},
+//  }, func() {
+//    ...
+//  })
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) NoGoroutineLeaksWith(options GoroutineLeakOptions, f func(), msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return NoGoroutineLeaksWith(a.t, options, f, msgAndArgs...)
+}
+
+// Eventually asserts that the condition becomes true within waitFor,
+// checking it every tick.
+//
+//  assert.Eventually(func() bool { return server.Ready() }, time.Second, 10*time.Millisecond)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) Eventually(condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return Eventually(a.t, condition, waitFor, tick, msgAndArgs...)
+}
+
+// EventuallyWith asserts that the condition becomes true within waitFor,
+// checking it every tick, as Eventually does, with the time measured by
+// the specified clock.  With a fake clock, the test decides when the
+// condition is checked by advancing the time.
+//
+//  fake := clock.NewFake(time.Now())
+//  go func() {
+//    fake.BlockUntil(2) // The timer and the ticker of EventuallyWith.
+//    fake.Advance(time.Second)
+//  }()
+//  assert.EventuallyWith(fake, cache.Expired, time.Minute, time.Second)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) EventuallyWith(c clock.Clock, condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return EventuallyWith(a.t, c, condition, waitFor, tick, msgAndArgs...)
+}
+
+// Never asserts that the condition stays false for waitFor, checking it
+// every tick.
+//
+//  assert.Never(func() bool { return queue.Len() > 0 }, time.Second, 10*time.Millisecond)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) Never(condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return Never(a.t, condition, waitFor, tick, msgAndArgs...)
+}
+
+// NeverWith asserts that the condition stays false for waitFor, checking
+// it every tick, as Never does, with the time measured by the specified
+// clock.
+//
+//  assert.NeverWith(fake, func() bool { return queue.Len() > 0 }, time.Minute, time.Second)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) NeverWith(c clock.Clock, condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return NeverWith(a.t, c, condition, waitFor, tick, msgAndArgs...)
+}
+
+// Collect calls f with a Collector, and reports the failures of the
+// assertions made through it as a single failure once f returns, as
+// Collector.Report does.
+//
+//  assert.Collect(func(c *assert.Collector) {
+//    c.Equal("Mat", user.Name)
+//    assert.Equal(c, 42, user.Age)
+//  })
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) Collect(f func(c *Collector), msgAndArgs ...interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return Collect(a.t, f, msgAndArgs...)
+}
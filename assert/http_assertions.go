@@ -5,19 +5,92 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"runtime/debug"
 	"strings"
 )
 
-// httpCode is a helper that returns HTTP code of the response. It returns -1
-// if building a new request fails.
-func httpCode(handler http.HandlerFunc, method, url string, values url.Values) int {
-	w := httptest.NewRecorder()
-	req, err := http.NewRequest(method, url+"?"+values.Encode(), nil)
+// serveHTTP serves a request built from method, url and values with the
+// handler.  It returns an error if building the request fails or if the
+// handler panics, as serveRequest does.
+func serveHTTP(handler http.HandlerFunc, method, url string, values url.Values) (*httptest.ResponseRecorder, error) {
+	target := url + "?" + values.Encode()
+	req, err := http.NewRequest(method, target, nil)
 	if err != nil {
-		return -1
+		return nil, fmt.Errorf("Failed to build request %s %s: %s", method, target, err)
 	}
-	handler(w, req)
-	return w.Code
+	return serveRequest(handler, req)
+}
+
+// serveRequest serves the request with the handler.  It returns an error
+// if the handler panics, which holds the panic value and the stack of the
+// handler.
+func serveRequest(handler http.Handler, req *http.Request) (w *httptest.ResponseRecorder, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			w = nil
+			err = fmt.Errorf("Handler panicked while serving %s %s: %v\n\n%s", req.Method, req.URL, r, debug.Stack())
+		}
+	}()
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w, nil
+}
+
+// httpCode is a helper that returns HTTP code of the response. It returns
+// an error if building a new request fails or if the handler panics.
+func httpCode(handler http.HandlerFunc, method, url string, values url.Values) (int, error) {
+	w, err := serveHTTP(handler, method, url, values)
+	if err != nil {
+		return -1, err
+	}
+	return w.Code, nil
+}
+
+// httpStatus asserts that a specified handler returns a status code
+// accepted by ok, described by description in the failure message.
+func httpStatus(t TestingT, handler http.HandlerFunc, method, url string, values url.Values, description string, ok func(code int) bool) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	code, err := httpCode(handler, method, url, values)
+	if err != nil {
+		return Fail(t, err.Error())
+	}
+	if !ok(code) {
+		return Fail(t, fmt.Sprintf("Expected %s status code for %s %q but received %d", description, method, url+"?"+values.Encode(), code))
+	}
+	return true
+}
+
+// HTTPStatusCode asserts that a specified handler returns the specified
+// status code.
+//
+//  assert.HTTPStatusCode(t, myHandler, "GET", "/notImplemented", nil, 501)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func HTTPStatusCode(t TestingT, handler http.HandlerFunc, method, url string, values url.Values, statuscode int) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return httpStatus(t, handler, method, url, values, fmt.Sprint(statuscode), func(code int) bool {
+		return code == statuscode
+	})
+}
+
+// HTTPInformational asserts that a specified handler returns an
+// informational (1xx) status code.
+//
+//  assert.HTTPInformational(t, myHandler, "GET", "/a/b/c", nil)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func HTTPInformational(t TestingT, handler http.HandlerFunc, method, url string, values url.Values) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return httpStatus(t, handler, method, url, values, "an informational (1xx)", func(code int) bool {
+		return code >= 100 && code < http.StatusOK
+	})
 }
 
 // HTTPSuccess asserts that a specified handler returns a success status code.
@@ -26,11 +99,12 @@ func httpCode(handler http.HandlerFunc, method, url string, values url.Values) i
 //
 // Returns whether the assertion was successful (true) or not (false).
 func HTTPSuccess(t TestingT, handler http.HandlerFunc, method, url string, values url.Values) bool {
-	code := httpCode(handler, method, url, values)
-	if code == -1 {
-		return false
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
 	}
-	return code >= http.StatusOK && code <= http.StatusPartialContent
+	return httpStatus(t, handler, method, url, values, "a success (200-206)", func(code int) bool {
+		return code >= http.StatusOK && code <= http.StatusPartialContent
+	})
 }
 
 // HTTPRedirect asserts that a specified handler returns a redirect status code.
@@ -39,11 +113,12 @@ func HTTPSuccess(t TestingT, handler http.HandlerFunc, method, url string, value
 //
 // Returns whether the assertion was successful (true) or not (false).
 func HTTPRedirect(t TestingT, handler http.HandlerFunc, method, url string, values url.Values) bool {
-	code := httpCode(handler, method, url, values)
-	if code == -1 {
-		return false
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
 	}
-	return code >= http.StatusMultipleChoices && code <= http.StatusTemporaryRedirect
+	return httpStatus(t, handler, method, url, values, "a redirect (300-307)", func(code int) bool {
+		return code >= http.StatusMultipleChoices && code <= http.StatusTemporaryRedirect
+	})
 }
 
 // HTTPError asserts that a specified handler returns an error status code.
@@ -52,22 +127,53 @@ func HTTPRedirect(t TestingT, handler http.HandlerFunc, method, url string, valu
 //
 // Returns whether the assertion was successful (true) or not (false).
 func HTTPError(t TestingT, handler http.HandlerFunc, method, url string, values url.Values) bool {
-	code := httpCode(handler, method, url, values)
-	if code == -1 {
-		return false
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return httpStatus(t, handler, method, url, values, "an error (4xx or 5xx)", func(code int) bool {
+		return code >= http.StatusBadRequest
+	})
+}
+
+// HTTPClientError asserts that a specified handler returns a client error
+// (4xx) status code.
+//
+//  assert.HTTPClientError(t, myHandler, "POST", "/a/b/c", url.Values{"a": []string{"b", "c"}}
+//
+// Returns whether the assertion was successful (true) or not (false).
+func HTTPClientError(t TestingT, handler http.HandlerFunc, method, url string, values url.Values) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	return httpStatus(t, handler, method, url, values, "a client error (4xx)", func(code int) bool {
+		return code >= http.StatusBadRequest && code < http.StatusInternalServerError
+	})
+}
+
+// HTTPServerError asserts that a specified handler returns a server error
+// (5xx) status code.
+//
+//  assert.HTTPServerError(t, myHandler, "POST", "/a/b/c", url.Values{"a": []string{"b", "c"}}
+//
+// Returns whether the assertion was successful (true) or not (false).
+func HTTPServerError(t TestingT, handler http.HandlerFunc, method, url string, values url.Values) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
 	}
-	return code >= http.StatusBadRequest
+	return httpStatus(t, handler, method, url, values, "a server error (5xx)", func(code int) bool {
+		return code >= http.StatusInternalServerError
+	})
 }
 
 // HTTPBody is a helper that returns HTTP body of the response. It returns
-// empty string if building a new request fails.
+// empty string if building a new request fails or if the handler panics;
+// use HTTPBodyContains or HTTPBodyNotContains to have these reported as
+// failures.
 func HTTPBody(handler http.HandlerFunc, method, url string, values url.Values) string {
-	w := httptest.NewRecorder()
-	req, err := http.NewRequest(method, url+"?"+values.Encode(), nil)
+	w, err := serveHTTP(handler, method, url, values)
 	if err != nil {
 		return ""
 	}
-	handler(w, req)
 	return w.Body.String()
 }
 
@@ -78,7 +184,14 @@ func HTTPBody(handler http.HandlerFunc, method, url string, values url.Values) s
 //
 // Returns whether the assertion was successful (true) or not (false).
 func HTTPBodyContains(t TestingT, handler http.HandlerFunc, method, url string, values url.Values, str interface{}) bool {
-	body := HTTPBody(handler, method, url, values)
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	w, err := serveHTTP(handler, method, url, values)
+	if err != nil {
+		return Fail(t, err.Error())
+	}
+	body := w.Body.String()
 
 	contains := strings.Contains(body, fmt.Sprint(str))
 	if !contains {
@@ -95,11 +208,18 @@ func HTTPBodyContains(t TestingT, handler http.HandlerFunc, method, url string,
 //
 // Returns whether the assertion was successful (true) or not (false).
 func HTTPBodyNotContains(t TestingT, handler http.HandlerFunc, method, url string, values url.Values, str interface{}) bool {
-	body := HTTPBody(handler, method, url, values)
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	w, err := serveHTTP(handler, method, url, values)
+	if err != nil {
+		return Fail(t, err.Error())
+	}
+	body := w.Body.String()
 
 	contains := strings.Contains(body, fmt.Sprint(str))
 	if contains {
-		Fail(t, "Expected response body for %s to NOT contain \"%s\" but found \"%s\"", url+"?"+values.Encode(), str, body)
+		Fail(t, fmt.Sprintf("Expected response body for \"%s\" to NOT contain \"%s\" but found \"%s\"", url+"?"+values.Encode(), str, body))
 	}
 
 	return !contains
@@ -109,12 +229,41 @@ func HTTPBodyNotContains(t TestingT, handler http.HandlerFunc, method, url strin
 // Assertions Wrappers
 //
 
+// HTTPStatusCode asserts that a specified handler returns the specified
+// status code.
+//
+//  assert.HTTPStatusCode(myHandler, "GET", "/notImplemented", nil, 501)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) HTTPStatusCode(handler http.HandlerFunc, method, url string, values url.Values, statuscode int) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HTTPStatusCode(a.t, handler, method, url, values, statuscode)
+}
+
+// HTTPInformational asserts that a specified handler returns an
+// informational (1xx) status code.
+//
+//  assert.HTTPInformational(myHandler, "GET", "/a/b/c", nil)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) HTTPInformational(handler http.HandlerFunc, method, url string, values url.Values) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HTTPInformational(a.t, handler, method, url, values)
+}
+
 // HTTPSuccess asserts that a specified handler returns a success status code.
 //
 //  assert.HTTPSuccess(myHandler, "POST", "http://www.google.com", nil)
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) HTTPSuccess(handler http.HandlerFunc, method, url string, values url.Values) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return HTTPSuccess(a.t, handler, method, url, values)
 }
 
@@ -124,6 +273,9 @@ func (a *Assertions) HTTPSuccess(handler http.HandlerFunc, method, url string, v
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) HTTPRedirect(handler http.HandlerFunc, method, url string, values url.Values) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return HTTPRedirect(a.t, handler, method, url, values)
 }
 
@@ -133,9 +285,38 @@ func (a *Assertions) HTTPRedirect(handler http.HandlerFunc, method, url string,
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) HTTPError(handler http.HandlerFunc, method, url string, values url.Values) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return HTTPError(a.t, handler, method, url, values)
 }
 
+// HTTPClientError asserts that a specified handler returns a client error
+// (4xx) status code.
+//
+//  assert.HTTPClientError(myHandler, "POST", "/a/b/c", url.Values{"a": []string{"b", "c"}}
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) HTTPClientError(handler http.HandlerFunc, method, url string, values url.Values) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HTTPClientError(a.t, handler, method, url, values)
+}
+
+// HTTPServerError asserts that a specified handler returns a server error
+// (5xx) status code.
+//
+//  assert.HTTPServerError(myHandler, "POST", "/a/b/c", url.Values{"a": []string{"b", "c"}}
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) HTTPServerError(handler http.HandlerFunc, method, url string, values url.Values) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HTTPServerError(a.t, handler, method, url, values)
+}
+
 // HTTPBodyContains asserts that a specified handler returns a
 // body that contains a string.
 //
@@ -143,6 +324,9 @@ func (a *Assertions) HTTPError(handler http.HandlerFunc, method, url string, val
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) HTTPBodyContains(handler http.HandlerFunc, method, url string, values url.Values, str interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return HTTPBodyContains(a.t, handler, method, url, values, str)
 }
 
@@ -153,5 +337,8 @@ func (a *Assertions) HTTPBodyContains(handler http.HandlerFunc, method, url stri
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) HTTPBodyNotContains(handler http.HandlerFunc, method, url string, values url.Values, str interface{}) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	return HTTPBodyNotContains(a.t, handler, method, url, values, str)
 }
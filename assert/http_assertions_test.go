@@ -19,6 +19,12 @@ func httpError(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusInternalServerError)
 }
 
+func httpStatusHandler(code int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(code)
+	}
+}
+
 func TestHTTPStatuses(t *testing.T) {
 	assert := New(t)
 	mockT := new(testing.T)
@@ -84,3 +90,84 @@ func TestHttpBodyWrappers(t *testing.T) {
 	assert.True(mockAssert.HTTPBodyNotContains(httpHelloName, "GET", "/", url.Values{"name": []string{"World"}}, "world"))
 
 }
+
+func httpContinue(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusContinue)
+}
+
+func httpNotFound(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func httpPanic(w http.ResponseWriter, r *http.Request) {
+	panic("handler exploded")
+}
+
+func TestHTTPStatusCode(t *testing.T) {
+	assert := New(t)
+	mockT := new(testing.T)
+
+	assert.True(HTTPStatusCode(mockT, httpOK, "GET", "/", nil, http.StatusOK))
+	assert.False(HTTPStatusCode(mockT, httpOK, "GET", "/", nil, http.StatusCreated))
+	assert.True(HTTPStatusCode(mockT, httpNotFound, "GET", "/", nil, http.StatusNotFound))
+
+	mockAssert := New(new(testing.T))
+	assert.True(mockAssert.HTTPStatusCode(httpRedirect, "GET", "/", nil, http.StatusTemporaryRedirect))
+	assert.False(mockAssert.HTTPStatusCode(httpRedirect, "GET", "/", nil, http.StatusOK))
+}
+
+func TestHTTPStatusClasses(t *testing.T) {
+	assert := New(t)
+	mockT := new(testing.T)
+
+	assert.True(HTTPInformational(mockT, httpContinue, "GET", "/", nil))
+	assert.False(HTTPInformational(mockT, httpOK, "GET", "/", nil))
+
+	assert.True(HTTPClientError(mockT, httpNotFound, "GET", "/", nil))
+	assert.False(HTTPClientError(mockT, httpError, "GET", "/", nil))
+
+	assert.True(HTTPServerError(mockT, httpError, "GET", "/", nil))
+	assert.False(HTTPServerError(mockT, httpNotFound, "GET", "/", nil))
+
+	// Success and redirect only cover the status codes they always did.
+	assert.False(HTTPSuccess(mockT, httpStatusHandler(http.StatusAlreadyReported), "GET", "/", nil))
+	assert.True(HTTPSuccess(mockT, httpStatusHandler(http.StatusPartialContent), "GET", "/", nil))
+	assert.False(HTTPRedirect(mockT, httpStatusHandler(http.StatusPermanentRedirect), "GET", "/", nil))
+	assert.True(HTTPRedirect(mockT, httpStatusHandler(http.StatusMultipleChoices), "GET", "/", nil))
+
+	mockAssert := New(new(testing.T))
+	assert.True(mockAssert.HTTPInformational(httpContinue, "GET", "/", nil))
+	assert.True(mockAssert.HTTPClientError(httpNotFound, "GET", "/", nil))
+	assert.True(mockAssert.HTTPServerError(httpError, "GET", "/", nil))
+}
+
+func TestHTTPStatusFailures(t *testing.T) {
+	assert := New(t)
+
+	mockT := new(bufferT)
+	assert.False(HTTPSuccess(mockT, httpError, "GET", "/", url.Values{"a": []string{"b"}}))
+	if assert.Len(mockT.messages, 1) {
+		assert.Contains(mockT.messages[0], `Expected a success (200-206) status code for GET "/?a=b" but received 500`)
+	}
+
+	mockT = new(bufferT)
+	assert.False(HTTPSuccess(mockT, httpOK, "BAD METHOD", "/", nil))
+	if assert.Len(mockT.messages, 1) {
+		assert.Contains(mockT.messages[0], "Failed to build request BAD METHOD /?")
+	}
+
+	mockT = new(bufferT)
+	assert.NotPanics(func() {
+		assert.False(HTTPStatusCode(mockT, httpPanic, "GET", "/", nil, http.StatusOK))
+	})
+	if assert.Len(mockT.messages, 1) {
+		assert.Contains(mockT.messages[0], "Handler panicked while serving GET /?: handler exploded")
+		assert.Contains(mockT.messages[0], "httpPanic")
+	}
+
+	mockT = new(bufferT)
+	assert.False(HTTPBodyContains(mockT, httpPanic, "GET", "/", nil, "anything"))
+	assert.False(HTTPBodyNotContains(mockT, httpPanic, "GET", "/", nil, "anything"))
+	assert.Len(mockT.messages, 2)
+	assert.Equal("", HTTPBody(httpPanic, "GET", "/", nil))
+}
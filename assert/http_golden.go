@@ -0,0 +1,200 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+var updateGolden = flag.Bool("testify.update", os.Getenv("TESTIFY_UPDATE") != "", "rewrite the golden files of HTTPGolden instead of comparing responses to them (defaults to whether $TESTIFY_UPDATE is set)")
+
+// goldenRedacted replaces the redacted parts of a response.
+const goldenRedacted = "[redacted]"
+
+// HTTPGoldenOptions configures how a response is compared to a golden
+// file.  The zero value compares the status and the body only.
+type HTTPGoldenOptions struct {
+	// Headers are the names of the headers included in the golden file.
+	Headers []string
+
+	// RedactKeys are the keys of the JSON objects whose values are
+	// replaced with "[redacted]", such as the keys of timestamps or
+	// generated IDs.
+	RedactKeys []string
+
+	// Redact holds regular expressions whose matches are replaced with
+	// "[redacted]" in the headers and the body.
+	Redact []*regexp.Regexp
+}
+
+// HTTPGolden asserts that the response of a handler matches the golden
+// file testdata/<golden>.golden.  The golden file holds the status, the
+// headers listed in options and the body, pretty-printed if it is JSON.
+// Running the tests with -testify.update, or with $TESTIFY_UPDATE set,
+// rewrites the golden files instead.
+//
+//  assert.HTTPGolden(t, myHandler, "GET", "/users/1", nil, "user", &assert.HTTPGoldenOptions{
+//    Headers:    []string{"Content-Type"},
+//    RedactKeys: []string{"createdAt"},
+//  })
+//
+// Returns whether the assertion was successful (true) or not (false).
+func HTTPGolden(t TestingT, handler http.HandlerFunc, method, url string, values url.Values, golden string, options *HTTPGoldenOptions) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	w, err := serveHTTP(handler, method, url, values)
+	if err != nil {
+		return Fail(t, err.Error())
+	}
+	if err := matchGolden(w.Result(), w.Body.Bytes(), golden, options); err != nil {
+		return Fail(t, fmt.Sprintf("%s\nin response to %s %s", err, method, url+"?"+values.Encode()))
+	}
+	return true
+}
+
+// Golden asserts that the response matches the golden file
+// testdata/<golden>.golden, as HTTPGolden does.
+func (c *HTTPResponseChecker) Golden(golden string, options *HTTPGoldenOptions) *HTTPResponseChecker {
+	if c.Response == nil {
+		return c
+	}
+	if err := matchGolden(c.Response, c.Body, golden, options); err != nil {
+		c.failed = true
+		Fail(c.t, fmt.Sprintf("%s\nin response to %s %s", err, c.method, c.url))
+	}
+	return c
+}
+
+// matchGolden compares the response to the golden file, or rewrites the
+// golden file if -testify.update is set.
+func matchGolden(resp *http.Response, body []byte, golden string, options *HTTPGoldenOptions) error {
+	if options == nil {
+		options = new(HTTPGoldenOptions)
+	}
+	path := filepath.Join("testdata", golden+".golden")
+	actual := renderGolden(resp, body, options)
+
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("Cannot create the directory of golden file %s: %s", path, err)
+		}
+		if err := ioutil.WriteFile(path, []byte(actual), 0644); err != nil {
+			return fmt.Errorf("Cannot write golden file %s: %s", path, err)
+		}
+		return nil
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("Golden file %s does not exist, run the tests with -testify.update to create it", path)
+	}
+	if err != nil {
+		return fmt.Errorf("Cannot read golden file %s: %s", path, err)
+	}
+	if string(expected) == actual {
+		return nil
+	}
+
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(expected)),
+		B:        difflib.SplitLines(actual),
+		FromFile: path,
+		ToFile:   "Actual",
+		Context:  3,
+	})
+	return fmt.Errorf("Response does not match golden file %s, run the tests with -testify.update to update it.\n\nDiff:\n%s", path, diff)
+}
+
+// renderGolden renders the status, the selected headers and the
+// normalized body of the response as they are stored in golden files.
+func renderGolden(resp *http.Response, body []byte, options *HTTPGoldenOptions) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%d %s\n", resp.StatusCode, http.StatusText(resp.StatusCode))
+	for _, name := range options.Headers {
+		for _, value := range resp.Header[http.CanonicalHeaderKey(name)] {
+			fmt.Fprintf(&b, "%s: %s\n", http.CanonicalHeaderKey(name), value)
+		}
+	}
+	b.WriteString("\n")
+	b.Write(normalizeGoldenBody(body, options.RedactKeys))
+
+	rendered := b.String()
+	for _, re := range options.Redact {
+		rendered = re.ReplaceAllLiteralString(rendered, goldenRedacted)
+	}
+	return rendered
+}
+
+// normalizeGoldenBody pretty-prints body with sorted keys and the values
+// of redactKeys redacted if it is JSON, and returns it unchanged
+// otherwise.
+func normalizeGoldenBody(body []byte, redactKeys []string) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return body
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return body
+	}
+
+	redacted := make(map[string]bool, len(redactKeys))
+	for _, key := range redactKeys {
+		redacted[key] = true
+	}
+	v = redactJSON(v, redacted)
+
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return body
+	}
+	return b.Bytes()
+}
+
+// redactJSON replaces the values of the redacted keys of the objects
+// found in v.
+func redactJSON(v interface{}, redacted map[string]bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if redacted[key] {
+				v[key] = goldenRedacted
+			} else {
+				v[key] = redactJSON(value, redacted)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactJSON(value, redacted)
+		}
+	}
+	return v
+}
+
+// HTTPGolden asserts that the response of a handler matches the golden
+// file testdata/<golden>.golden.
+//
+//  assert.HTTPGolden(myHandler, "GET", "/users/1", nil, "user", nil)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func (a *Assertions) HTTPGolden(handler http.HandlerFunc, method, url string, values url.Values, golden string, options *HTTPGoldenOptions) bool {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return HTTPGolden(a.t, handler, method, url, values, golden, options)
+}
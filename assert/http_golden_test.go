@@ -0,0 +1,74 @@
+package assert
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func httpGoldenUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Request-Id", fmt.Sprintf("req-%d", time.Now().UnixNano()))
+	w.Header().Set("X-Ignored", "true")
+	name := r.URL.Query().Get("name")
+	fmt.Fprintf(w, `{"name": %q, "id": 1, "createdAt": %q, "tags": [{"name": "admin", "createdAt": %q}]}`,
+		name, time.Now(), time.Now())
+}
+
+var httpGoldenUserOptions = &HTTPGoldenOptions{
+	Headers:    []string{"content-type", "X-Request-Id"},
+	RedactKeys: []string{"createdAt"},
+	Redact:     []*regexp.Regexp{regexp.MustCompile(`req-\d+`)},
+}
+
+func TestHTTPGolden(t *testing.T) {
+	values := map[string][]string{"name": {"Mat <mat@example.com>"}}
+	True(t, HTTPGolden(t, httpGoldenUser, "GET", "/users/1", values, "http_golden_user", httpGoldenUserOptions))
+
+	HTTPRequest(t, http.HandlerFunc(httpGoldenUser), "GET", "/users/1").
+		WithQuery(values).
+		Expect().
+		Golden("http_golden_user", httpGoldenUserOptions)
+
+	mockT := new(bufferT)
+	values = map[string][]string{"name": {"Tyler"}}
+	False(t, HTTPGolden(mockT, httpGoldenUser, "GET", "/users/1", values, "http_golden_user", httpGoldenUserOptions))
+	if Len(t, mockT.messages, 1) {
+		Contains(t, mockT.messages[0], "Response does not match golden file testdata/http_golden_user.golden")
+		Contains(t, mockT.messages[0], "-  \"name\": \"Mat <mat@example.com>\",\n")
+		Contains(t, mockT.messages[0], "+  \"name\": \"Tyler\",\n")
+	}
+
+	mockT = new(bufferT)
+	False(t, New(mockT).HTTPGolden(httpGoldenUser, "GET", "/users/1", nil, "missing", nil))
+	if Len(t, mockT.messages, 1) {
+		Contains(t, mockT.messages[0], "Golden file testdata/missing.golden does not exist")
+	}
+}
+
+func TestHTTPGoldenUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testify")
+	if !NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(dir)
+
+	*updateGolden = true
+	defer func() { *updateGolden = false }()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("plain text"))
+	}
+	True(t, HTTPGolden(t, handler, "GET", "/", nil, "text", nil))
+	golden, err := ioutil.ReadFile(filepath.Join(dir, "testdata", "text.golden"))
+	NoError(t, err)
+	Equal(t, "200 OK\n\nplain text", string(golden))
+}
@@ -0,0 +1,391 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+)
+
+// HTTPFile is a file uploaded in a multipart request body.
+type HTTPFile struct {
+	// Field is the name of the form field holding the file.
+	Field string
+
+	// Name is the file name sent with the file.
+	Name string
+
+	// Content is the content of the file.
+	Content []byte
+}
+
+// HTTPRequestBuilder builds a request to be served by a handler under
+// test.  It is created by HTTPRequest, and the request is served by
+// calling Expect.
+type HTTPRequestBuilder struct {
+	t       TestingT
+	handler http.Handler
+	method  string
+	url     string
+	query   url.Values
+	header  http.Header
+	cookies []*http.Cookie
+	body    []byte
+	err     error
+}
+
+// HTTPRequest starts building a request to be served by handler, so that
+// assertions can be made about the response.
+//
+//  assert.HTTPRequest(t, myHandler, "POST", "/users").
+//    WithHeader("Authorization", "Bearer token").
+//    WithJSON(map[string]string{"name": "Mat"}).
+//    Expect().
+//    Status(http.StatusCreated).
+//    ContentType("application/json").
+//    JSONEq(`{"id": 1, "name": "Mat"}`)
+func HTTPRequest(t TestingT, handler http.Handler, method, url string) *HTTPRequestBuilder {
+	return &HTTPRequestBuilder{
+		t:       t,
+		handler: handler,
+		method:  method,
+		url:     url,
+		query:   make(map[string][]string),
+		header:  make(http.Header),
+	}
+}
+
+// WithQuery adds values to the query string of the request.
+func (b *HTTPRequestBuilder) WithQuery(values url.Values) *HTTPRequestBuilder {
+	for key, vals := range values {
+		for _, val := range vals {
+			b.query.Add(key, val)
+		}
+	}
+	return b
+}
+
+// WithHeader adds a header to the request.
+func (b *HTTPRequestBuilder) WithHeader(name, value string) *HTTPRequestBuilder {
+	b.header.Add(name, value)
+	return b
+}
+
+// WithCookie adds a cookie to the request.
+func (b *HTTPRequestBuilder) WithCookie(cookie *http.Cookie) *HTTPRequestBuilder {
+	b.cookies = append(b.cookies, cookie)
+	return b
+}
+
+// WithBody sets the body of the request, and its content type unless
+// contentType is empty.
+func (b *HTTPRequestBuilder) WithBody(contentType string, body []byte) *HTTPRequestBuilder {
+	b.body = body
+	if contentType != "" {
+		b.header.Set("Content-Type", contentType)
+	}
+	return b
+}
+
+// WithJSON sets the body of the request to the JSON encoding of v.
+func (b *HTTPRequestBuilder) WithJSON(v interface{}) *HTTPRequestBuilder {
+	body, err := json.Marshal(v)
+	if err != nil {
+		b.err = fmt.Errorf("cannot encode request body as JSON: %s", err)
+		return b
+	}
+	return b.WithBody("application/json", body)
+}
+
+// WithForm sets the body of the request to the URL encoding of values.
+func (b *HTTPRequestBuilder) WithForm(values url.Values) *HTTPRequestBuilder {
+	return b.WithBody("application/x-www-form-urlencoded", []byte(values.Encode()))
+}
+
+// WithMultipart sets the body of the request to a multipart form holding
+// the fields and the files.
+func (b *HTTPRequestBuilder) WithMultipart(fields url.Values, files ...HTTPFile) *HTTPRequestBuilder {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	for key, vals := range fields {
+		for _, val := range vals {
+			if err := writer.WriteField(key, val); err != nil {
+				b.err = fmt.Errorf("cannot write multipart field %q: %s", key, err)
+				return b
+			}
+		}
+	}
+	for _, file := range files {
+		part, err := writer.CreateFormFile(file.Field, file.Name)
+		if err == nil {
+			_, err = part.Write(file.Content)
+		}
+		if err != nil {
+			b.err = fmt.Errorf("cannot write multipart file %q: %s", file.Name, err)
+			return b
+		}
+	}
+	if err := writer.Close(); err != nil {
+		b.err = fmt.Errorf("cannot write multipart body: %s", err)
+		return b
+	}
+	return b.WithBody(writer.FormDataContentType(), body.Bytes())
+}
+
+// Request returns the request that is served by Expect.
+func (b *HTTPRequestBuilder) Request() (*http.Request, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	var body io.Reader
+	if b.body != nil {
+		body = bytes.NewReader(b.body)
+	}
+	req, err := http.NewRequest(b.method, b.url, body)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.query) > 0 {
+		query := req.URL.Query()
+		for key, vals := range b.query {
+			for _, val := range vals {
+				query.Add(key, val)
+			}
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+	for name, vals := range b.header {
+		for _, val := range vals {
+			req.Header.Add(name, val)
+		}
+	}
+	for _, cookie := range b.cookies {
+		req.AddCookie(cookie)
+	}
+	return req, nil
+}
+
+// Expect serves the request with the handler, and returns a checker to
+// make assertions about the response.  If the request cannot be built, or
+// if the handler panics, the failure is reported and all the assertions
+// of the checker fail.
+func (b *HTTPRequestBuilder) Expect() *HTTPResponseChecker {
+	if h, ok := b.t.(tHelper); ok {
+		h.Helper()
+	}
+	c := &HTTPResponseChecker{t: b.t, method: b.method, url: b.url}
+
+	req, err := b.Request()
+	if err != nil {
+		c.failed = true
+		Fail(b.t, fmt.Sprintf("Cannot build request %s %s: %s", b.method, b.url, err))
+		return c
+	}
+	c.url = req.URL.String()
+
+	w, err := serveRequest(b.handler, req)
+	if err != nil {
+		c.failed = true
+		Fail(b.t, err.Error())
+		return c
+	}
+	c.Response = w.Result()
+	c.Body, _ = ioutil.ReadAll(c.Response.Body)
+	c.Response.Body = ioutil.NopCloser(bytes.NewReader(c.Body))
+	return c
+}
+
+// HTTPResponseChecker makes assertions about the response of a handler
+// to a request built by HTTPRequest.  Its assertions can be chained, and
+// their failures include the raw response.
+type HTTPResponseChecker struct {
+	// Response is the response of the handler, whose body can be read
+	// again from Body.
+	Response *http.Response
+
+	// Body is the body of the response.
+	Body []byte
+
+	t      TestingT
+	method string
+	url    string
+	failed bool
+}
+
+// Passed returns whether the request could be served and all the
+// assertions made so far were successful.
+func (c *HTTPResponseChecker) Passed() bool {
+	return !c.failed
+}
+
+// raw returns the response as it would be sent on the wire.
+func (c *HTTPResponseChecker) raw() string {
+	c.Response.Body = ioutil.NopCloser(bytes.NewReader(c.Body))
+	dump, err := httputil.DumpResponse(c.Response, true)
+	c.Response.Body = ioutil.NopCloser(bytes.NewReader(c.Body))
+	if err != nil {
+		return fmt.Sprintf("(cannot dump response: %s)", err)
+	}
+	return string(dump)
+}
+
+// fail reports a failed assertion about the response, along with the
+// raw response.
+func (c *HTTPResponseChecker) fail(failureMessage string) *HTTPResponseChecker {
+	if h, ok := c.t.(tHelper); ok {
+		h.Helper()
+	}
+	c.failed = true
+	Fail(c.t, fmt.Sprintf("%s\nin response to %s %s:\n\n%s", failureMessage, c.method, c.url, c.raw()))
+	return c
+}
+
+// Status asserts that the response has the status code.
+func (c *HTTPResponseChecker) Status(code int) *HTTPResponseChecker {
+	if h, ok := c.t.(tHelper); ok {
+		h.Helper()
+	}
+	if c.Response == nil {
+		return c
+	}
+	if c.Response.StatusCode != code {
+		return c.fail(fmt.Sprintf("Expected status code %d but got %d", code, c.Response.StatusCode))
+	}
+	return c
+}
+
+// Header asserts that the response has the header with the value.
+func (c *HTTPResponseChecker) Header(name, value string) *HTTPResponseChecker {
+	if h, ok := c.t.(tHelper); ok {
+		h.Helper()
+	}
+	if c.Response == nil {
+		return c
+	}
+	values, ok := c.Response.Header[http.CanonicalHeaderKey(name)]
+	if !ok {
+		return c.fail(fmt.Sprintf("Expected header %q to be %q but it is not set", name, value))
+	}
+	for _, v := range values {
+		if v == value {
+			return c
+		}
+	}
+	return c.fail(fmt.Sprintf("Expected header %q to be %q but got %q", name, value, strings.Join(values, ", ")))
+}
+
+// ContentType asserts that the media type of the response is mediaType,
+// ignoring any parameters such as the charset.
+func (c *HTTPResponseChecker) ContentType(mediaType string) *HTTPResponseChecker {
+	if h, ok := c.t.(tHelper); ok {
+		h.Helper()
+	}
+	if c.Response == nil {
+		return c
+	}
+	contentType := c.Response.Header.Get("Content-Type")
+	actual, _, err := mime.ParseMediaType(contentType)
+	if err != nil || actual != mediaType {
+		return c.fail(fmt.Sprintf("Expected content type %q but got %q", mediaType, contentType))
+	}
+	return c
+}
+
+// Redirect asserts that the response is a redirect to location.
+func (c *HTTPResponseChecker) Redirect(location string) *HTTPResponseChecker {
+	if h, ok := c.t.(tHelper); ok {
+		h.Helper()
+	}
+	if c.Response == nil {
+		return c
+	}
+	code := c.Response.StatusCode
+	if code < http.StatusMultipleChoices || code >= http.StatusBadRequest {
+		return c.fail(fmt.Sprintf("Expected a redirect to %q but got status code %d", location, code))
+	}
+	if actual := c.Response.Header.Get("Location"); actual != location {
+		return c.fail(fmt.Sprintf("Expected a redirect to %q but got a redirect to %q", location, actual))
+	}
+	return c
+}
+
+// Cookie asserts that the response sets the cookie to value.
+func (c *HTTPResponseChecker) Cookie(name, value string) *HTTPResponseChecker {
+	if h, ok := c.t.(tHelper); ok {
+		h.Helper()
+	}
+	if c.Response == nil {
+		return c
+	}
+	for _, cookie := range c.Response.Cookies() {
+		if cookie.Name == name {
+			if cookie.Value != value {
+				return c.fail(fmt.Sprintf("Expected cookie %q to be %q but got %q", name, value, cookie.Value))
+			}
+			return c
+		}
+	}
+	return c.fail(fmt.Sprintf("Expected cookie %q to be %q but it is not set", name, value))
+}
+
+// BodyEqual asserts that the body of the response is body.
+func (c *HTTPResponseChecker) BodyEqual(body string) *HTTPResponseChecker {
+	if h, ok := c.t.(tHelper); ok {
+		h.Helper()
+	}
+	if c.Response == nil {
+		return c
+	}
+	if string(c.Body) != body {
+		return c.fail(fmt.Sprintf("Expected body %q but got %q", body, c.Body))
+	}
+	return c
+}
+
+// BodyContains asserts that the body of the response contains str.
+func (c *HTTPResponseChecker) BodyContains(str string) *HTTPResponseChecker {
+	if h, ok := c.t.(tHelper); ok {
+		h.Helper()
+	}
+	if c.Response == nil {
+		return c
+	}
+	if !strings.Contains(string(c.Body), str) {
+		return c.fail(fmt.Sprintf("Expected body to contain %q", str))
+	}
+	return c
+}
+
+// JSONEq asserts that the body of the response is JSON equivalent to
+// expected.
+func (c *HTTPResponseChecker) JSONEq(expected string) *HTTPResponseChecker {
+	if h, ok := c.t.(tHelper); ok {
+		h.Helper()
+	}
+	if c.Response == nil {
+		return c
+	}
+	if !JSONEq(c.t, expected, string(c.Body), "in response to %s %s:\n\n%s", c.method, c.url, c.raw()) {
+		c.failed = true
+	}
+	return c
+}
+
+// HTTPRequest starts building a request to be served by handler, so that
+// assertions can be made about the response.
+//
+//  assert.HTTPRequest(myHandler, "GET", "/users/1").
+//    Expect().
+//    Status(http.StatusOK).
+//    JSONEq(`{"id": 1, "name": "Mat"}`)
+func (a *Assertions) HTTPRequest(handler http.Handler, method, url string) *HTTPRequestBuilder {
+	return HTTPRequest(a.t, handler, method, url)
+}
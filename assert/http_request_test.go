@@ -0,0 +1,132 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// bufferT is a TestingT recording the messages of failed assertions.
+type bufferT struct {
+	messages []string
+}
+
+func (t *bufferT) Errorf(format string, args ...interface{}) {
+	t.messages = append(t.messages, fmt.Sprintf(format, args...))
+}
+
+func httpUsers(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		http.SetCookie(w, &http.Cookie{Name: "seen", Value: r.URL.Query().Get("id")})
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(`{"id": 1, "name": "Mat"}`))
+	case "POST":
+		if r.Header.Get("Authorization") != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var user map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Redirect(w, r, "/users/2", http.StatusSeeOther)
+	case "PUT":
+		file, header, err := r.FormFile("avatar")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		content, _ := ioutil.ReadAll(file)
+		session, _ := r.Cookie("session")
+		fmt.Fprintf(w, "%s %s %s %s", r.FormValue("name"), header.Filename, content, session.Value)
+	}
+}
+
+func TestHTTPRequest(t *testing.T) {
+	mockT := new(bufferT)
+	handler := http.HandlerFunc(httpUsers)
+
+	c := HTTPRequest(mockT, handler, "GET", "/users").
+		WithQuery(url.Values{"id": []string{"1"}}).
+		Expect().
+		Status(http.StatusOK).
+		ContentType("application/json").
+		Header("Content-Type", "application/json; charset=utf-8").
+		Cookie("seen", "1").
+		JSONEq(`{"name": "Mat", "id": 1}`).
+		BodyContains(`"Mat"`)
+	True(t, c.Passed())
+
+	c = HTTPRequest(mockT, handler, "POST", "/users").
+		WithHeader("Authorization", "secret").
+		WithJSON(map[string]string{"name": "Tyler"}).
+		Expect().
+		Status(http.StatusSeeOther).
+		Redirect("/users/2")
+	True(t, c.Passed())
+
+	c = HTTPRequest(mockT, handler, "PUT", "/users/1").
+		WithCookie(&http.Cookie{Name: "session", Value: "abc"}).
+		WithMultipart(url.Values{"name": []string{"Mat"}}, HTTPFile{Field: "avatar", Name: "mat.png", Content: []byte("PNG")}).
+		Expect().
+		BodyEqual("Mat mat.png PNG abc")
+	True(t, c.Passed())
+
+	Empty(t, mockT.messages)
+}
+
+func TestHTTPRequestFailures(t *testing.T) {
+	handler := http.HandlerFunc(httpUsers)
+
+	mockT := new(bufferT)
+	c := HTTPRequest(mockT, handler, "POST", "/users").
+		WithJSON(map[string]string{"name": "Tyler"}).
+		Expect().
+		Status(http.StatusSeeOther)
+	False(t, c.Passed())
+	if Len(t, mockT.messages, 1) {
+		Contains(t, mockT.messages[0], "Expected status code 303 but got 401")
+		Contains(t, mockT.messages[0], "in response to POST /users")
+		Contains(t, mockT.messages[0], "HTTP/1.1 401 Unauthorized")
+	}
+
+	mockT = new(bufferT)
+	HTTPRequest(mockT, handler, "GET", "/users").
+		Expect().
+		ContentType("text/html").
+		Header("X-Missing", "value").
+		Cookie("seen", "2").
+		JSONEq(`{"id": 2}`).
+		Redirect("/elsewhere")
+	Len(t, mockT.messages, 5)
+
+	mockT = new(bufferT)
+	c = HTTPRequest(mockT, handler, "GET", ":not a url").Expect().Status(http.StatusOK)
+	False(t, c.Passed())
+	if Len(t, mockT.messages, 1) {
+		Contains(t, mockT.messages[0], "Cannot build request GET :not a url")
+	}
+
+	mockT = new(bufferT)
+	panicking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("handler exploded")
+	})
+	c = HTTPRequest(mockT, panicking, "GET", "/users").WithQuery(url.Values{"id": {"1"}}).Expect().Status(http.StatusOK).BodyContains("x")
+	False(t, c.Passed())
+	if Len(t, mockT.messages, 1) {
+		Contains(t, mockT.messages[0], "Handler panicked while serving GET /users?id=1: handler exploded")
+	}
+}
+
+func TestHTTPRequestWrapper(t *testing.T) {
+	assert := New(t)
+	mockAssert := New(new(bufferT))
+
+	assert.True(mockAssert.HTTPRequest(http.HandlerFunc(httpUsers), "GET", "/users").Expect().Status(http.StatusOK).Passed())
+	assert.False(mockAssert.HTTPRequest(http.HandlerFunc(httpUsers), "GET", "/users").Expect().Status(http.StatusNotFound).Passed())
+}
@@ -0,0 +1,573 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// jsonSchema validates JSON documents against a draft-07 JSON Schema.
+// Only local references, such as "#/definitions/item", are supported, and
+// formats are not validated, as the draft allows.
+type jsonSchema struct {
+	root interface{}
+
+	// invalid holds the problems found in the schema itself.
+	invalid []string
+
+	// resolving holds the $ref being resolved at each instance path, to
+	// detect the references looping without consuming the instance.
+	resolving map[string]bool
+}
+
+// validate returns the reasons why instance, at the JSON pointer path of
+// the document, does not match schema.
+func (s *jsonSchema) validate(schema, instance interface{}, path string) []string {
+	switch schema := schema.(type) {
+	case bool:
+		if !schema {
+			return []string{fmt.Sprintf("%s: no value is allowed here, got %s", displayPointer(path), jsonString(instance))}
+		}
+		return nil
+	case map[string]interface{}:
+		if ref, ok := schema["$ref"]; ok {
+			resolved, err := s.resolve(ref)
+			if err != nil {
+				s.invalid = append(s.invalid, err.Error())
+				return nil
+			}
+			key := fmt.Sprintf("%s %v", path, ref)
+			if s.resolving[key] {
+				s.invalid = append(s.invalid, fmt.Sprintf("$ref %s loops at %s without validating any value", jsonString(ref), displayPointer(path)))
+				return nil
+			}
+			if s.resolving == nil {
+				s.resolving = make(map[string]bool)
+			}
+			s.resolving[key] = true
+			defer delete(s.resolving, key)
+			return s.validate(resolved, instance, path)
+		}
+		errors := []string{}
+		for _, keyword := range sortedKeys(schema) {
+			errors = append(errors, s.validateKeyword(schema, keyword, instance, path)...)
+		}
+		return errors
+	}
+	s.invalid = append(s.invalid, fmt.Sprintf("schema must be an object or a boolean, not %s", jsonString(schema)))
+	return nil
+}
+
+// matches returns whether instance matches schema.
+func (s *jsonSchema) matches(schema, instance interface{}, path string) bool {
+	return len(s.validate(schema, instance, path)) == 0
+}
+
+// resolve returns the schema a local $ref points to.
+func (s *jsonSchema) resolve(ref interface{}) (interface{}, error) {
+	pointer, ok := ref.(string)
+	if !ok || !strings.HasPrefix(pointer, "#") {
+		return nil, fmt.Errorf("unsupported $ref %s, only local references are supported", jsonString(ref))
+	}
+	pointer, err := url.PathUnescape(pointer[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid $ref %q: %s", ref, err)
+	}
+	value := s.root
+	if pointer == "" {
+		return value, nil
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value, ok = v[token]
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			ok = err == nil && index >= 0 && index < len(v)
+			if ok {
+				value = v[index]
+			}
+		default:
+			ok = false
+		}
+		if !ok {
+			return nil, fmt.Errorf("$ref %q does not point to a schema", ref)
+		}
+	}
+	return value, nil
+}
+
+// validateKeyword returns the reasons why instance does not match the
+// keyword of schema.  Keywords that do not apply to the type of instance,
+// or that only annotate others, are ignored.
+func (s *jsonSchema) validateKeyword(schema map[string]interface{}, keyword string, instance interface{}, path string) []string {
+	value := schema[keyword]
+	fail := func(format string, args ...interface{}) []string {
+		return []string{displayPointer(path) + ": " + fmt.Sprintf(format, args...)}
+	}
+	invalid := func() []string {
+		s.invalid = append(s.invalid, fmt.Sprintf("invalid %q keyword: %s", keyword, jsonString(value)))
+		return nil
+	}
+
+	switch keyword {
+	case "type":
+		types := []string{}
+		switch v := value.(type) {
+		case string:
+			types = append(types, v)
+		case []interface{}:
+			for _, t := range v {
+				name, ok := t.(string)
+				if !ok {
+					return invalid()
+				}
+				types = append(types, name)
+			}
+		default:
+			return invalid()
+		}
+		for _, t := range types {
+			if hasJSONType(instance, t) {
+				return nil
+			}
+		}
+		return fail("expected %s but got %s %s", strings.Join(types, " or "), jsonType(instance), jsonString(instance))
+
+	case "enum":
+		values, ok := value.([]interface{})
+		if !ok {
+			return invalid()
+		}
+		for _, v := range values {
+			if jsonEqual(v, instance) {
+				return nil
+			}
+		}
+		return fail("expected one of %s but got %s", jsonString(values), jsonString(instance))
+
+	case "const":
+		if !jsonEqual(value, instance) {
+			return fail("expected %s but got %s", jsonString(value), jsonString(instance))
+		}
+
+	case "allOf", "anyOf", "oneOf":
+		schemas, ok := value.([]interface{})
+		if !ok || len(schemas) == 0 {
+			return invalid()
+		}
+		errors, matched := []string{}, 0
+		for _, sub := range schemas {
+			subErrors := s.validate(sub, instance, path)
+			if len(subErrors) == 0 {
+				matched++
+			}
+			errors = append(errors, subErrors...)
+		}
+		switch {
+		case keyword == "allOf" && matched < len(schemas):
+			return errors
+		case keyword == "anyOf" && matched == 0:
+			return fail("expected a value matching any of the anyOf schemas:\n  %s", strings.Join(errors, "\n  "))
+		case keyword == "oneOf" && matched == 0:
+			return fail("expected a value matching one of the oneOf schemas:\n  %s", strings.Join(errors, "\n  "))
+		case keyword == "oneOf" && matched > 1:
+			return fail("expected a value matching exactly one of the oneOf schemas but %d match", matched)
+		}
+
+	case "not":
+		if s.matches(value, instance, path) {
+			return fail("expected a value not matching %s but got %s", jsonString(value), jsonString(instance))
+		}
+
+	case "if":
+		if s.matches(value, instance, path) {
+			if then, ok := schema["then"]; ok {
+				return s.validate(then, instance, path)
+			}
+		} else if otherwise, ok := schema["else"]; ok {
+			return s.validate(otherwise, instance, path)
+		}
+
+	case "multipleOf", "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum":
+		n, ok := instance.(json.Number)
+		if !ok {
+			return nil
+		}
+		limit, ok := value.(json.Number)
+		if !ok {
+			return invalid()
+		}
+		x, okX := jsonNumberRat(n)
+		l, okL := jsonNumberRat(limit)
+		if !okX || !okL {
+			return invalid()
+		}
+		switch keyword {
+		case "multipleOf":
+			if l.Sign() <= 0 {
+				return invalid()
+			}
+			if !new(big.Rat).Quo(x, l).IsInt() {
+				return fail("expected a multiple of %s but got %s", limit, n)
+			}
+		case "minimum":
+			if x.Cmp(l) < 0 {
+				return fail("expected a number greater than or equal to %s but got %s", limit, n)
+			}
+		case "maximum":
+			if x.Cmp(l) > 0 {
+				return fail("expected a number less than or equal to %s but got %s", limit, n)
+			}
+		case "exclusiveMinimum":
+			if x.Cmp(l) <= 0 {
+				return fail("expected a number greater than %s but got %s", limit, n)
+			}
+		case "exclusiveMaximum":
+			if x.Cmp(l) >= 0 {
+				return fail("expected a number less than %s but got %s", limit, n)
+			}
+		}
+
+	case "minLength", "maxLength":
+		str, ok := instance.(string)
+		if !ok {
+			return nil
+		}
+		limit, ok := jsonCount(value)
+		if !ok {
+			return invalid()
+		}
+		length := utf8.RuneCountInString(str)
+		if keyword == "minLength" && length < limit {
+			return fail("expected a string of at least %d character(s) but got %s", limit, jsonString(str))
+		}
+		if keyword == "maxLength" && length > limit {
+			return fail("expected a string of at most %d character(s) but got %s", limit, jsonString(str))
+		}
+
+	case "pattern":
+		str, ok := instance.(string)
+		if !ok {
+			return nil
+		}
+		pattern, ok := value.(string)
+		if !ok {
+			return invalid()
+		}
+		rx, err := regexp.Compile(pattern)
+		if err != nil {
+			return invalid()
+		}
+		if !rx.MatchString(str) {
+			return fail("expected a string matching %q but got %s", pattern, jsonString(str))
+		}
+
+	case "items":
+		array, ok := instance.([]interface{})
+		if !ok {
+			return nil
+		}
+		errors := []string{}
+		if schemas, ok := value.([]interface{}); ok {
+			for i := 0; i < len(schemas) && i < len(array); i++ {
+				errors = append(errors, s.validate(schemas[i], array[i], jsonPointer(path, strconv.Itoa(i)))...)
+			}
+			if additional, ok := schema["additionalItems"]; ok {
+				for i := len(schemas); i < len(array); i++ {
+					errors = append(errors, s.validate(additional, array[i], jsonPointer(path, strconv.Itoa(i)))...)
+				}
+			}
+			return errors
+		}
+		for i, item := range array {
+			errors = append(errors, s.validate(value, item, jsonPointer(path, strconv.Itoa(i)))...)
+		}
+		return errors
+
+	case "minItems", "maxItems":
+		array, ok := instance.([]interface{})
+		if !ok {
+			return nil
+		}
+		limit, ok := jsonCount(value)
+		if !ok {
+			return invalid()
+		}
+		if keyword == "minItems" && len(array) < limit {
+			return fail("expected an array of at least %d element(s) but got %d", limit, len(array))
+		}
+		if keyword == "maxItems" && len(array) > limit {
+			return fail("expected an array of at most %d element(s) but got %d", limit, len(array))
+		}
+
+	case "uniqueItems":
+		array, ok := instance.([]interface{})
+		if !ok || value != true {
+			return nil
+		}
+		for i := range array {
+			for j := i + 1; j < len(array); j++ {
+				if jsonEqual(array[i], array[j]) {
+					return fail("expected unique elements but elements %d and %d are both %s", i, j, jsonString(array[i]))
+				}
+			}
+		}
+
+	case "contains":
+		array, ok := instance.([]interface{})
+		if !ok {
+			return nil
+		}
+		for i, item := range array {
+			if s.matches(value, item, jsonPointer(path, strconv.Itoa(i))) {
+				return nil
+			}
+		}
+		return fail("expected an array containing an element matching %s", jsonString(value))
+
+	case "properties", "patternProperties", "additionalProperties":
+		object, ok := instance.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		return s.validateProperties(schema, keyword, object, path, invalid)
+
+	case "required":
+		object, ok := instance.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		names, ok := value.([]interface{})
+		if !ok {
+			return invalid()
+		}
+		errors := []string{}
+		for _, name := range names {
+			key, ok := name.(string)
+			if !ok {
+				return invalid()
+			}
+			if _, ok := object[key]; !ok {
+				errors = append(errors, fmt.Sprintf("%s: required but missing", jsonPointer(path, key)))
+			}
+		}
+		return errors
+
+	case "minProperties", "maxProperties":
+		object, ok := instance.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		limit, ok := jsonCount(value)
+		if !ok {
+			return invalid()
+		}
+		if keyword == "minProperties" && len(object) < limit {
+			return fail("expected an object of at least %d property(ies) but got %d", limit, len(object))
+		}
+		if keyword == "maxProperties" && len(object) > limit {
+			return fail("expected an object of at most %d property(ies) but got %d", limit, len(object))
+		}
+
+	case "propertyNames":
+		object, ok := instance.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		errors := []string{}
+		for _, key := range sortedKeys(object) {
+			for _, err := range s.validate(value, key, jsonPointer(path, key)) {
+				errors = append(errors, "property name "+err)
+			}
+		}
+		return errors
+
+	case "dependencies":
+		object, ok := instance.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		dependencies, ok := value.(map[string]interface{})
+		if !ok {
+			return invalid()
+		}
+		errors := []string{}
+		for _, key := range sortedKeys(dependencies) {
+			if _, ok := object[key]; !ok {
+				continue
+			}
+			names, ok := dependencies[key].([]interface{})
+			if !ok {
+				errors = append(errors, s.validate(dependencies[key], instance, path)...)
+				continue
+			}
+			for _, name := range names {
+				dependency, _ := name.(string)
+				if _, ok := object[dependency]; !ok {
+					errors = append(errors, fmt.Sprintf("%s: required by %q but missing", jsonPointer(path, dependency), key))
+				}
+			}
+		}
+		return errors
+	}
+	return nil
+}
+
+// validateProperties validates the members of object against the
+// properties, patternProperties or additionalProperties keyword of
+// schema.
+func (s *jsonSchema) validateProperties(schema map[string]interface{}, keyword string, object map[string]interface{}, path string, invalid func() []string) []string {
+	properties, _ := schema["properties"].(map[string]interface{})
+	patternProperties, _ := schema["patternProperties"].(map[string]interface{})
+	patterns := []*regexp.Regexp{}
+	for _, pattern := range sortedKeys(patternProperties) {
+		rx, err := regexp.Compile(pattern)
+		if err != nil {
+			return invalid()
+		}
+		patterns = append(patterns, rx)
+	}
+
+	errors := []string{}
+	for _, key := range sortedKeys(object) {
+		member, memberPath := object[key], jsonPointer(path, key)
+		switch keyword {
+		case "properties":
+			if sub, ok := properties[key]; ok {
+				errors = append(errors, s.validate(sub, member, memberPath)...)
+			}
+		case "patternProperties":
+			for _, rx := range patterns {
+				if rx.MatchString(key) {
+					errors = append(errors, s.validate(patternProperties[rx.String()], member, memberPath)...)
+				}
+			}
+		case "additionalProperties":
+			if _, ok := properties[key]; ok {
+				continue
+			}
+			matched := false
+			for _, rx := range patterns {
+				matched = matched || rx.MatchString(key)
+			}
+			if matched {
+				continue
+			}
+			if schema["additionalProperties"] == false {
+				errors = append(errors, fmt.Sprintf("%s: unexpected property %s", memberPath, jsonString(member)))
+				continue
+			}
+			errors = append(errors, s.validate(schema["additionalProperties"], member, memberPath)...)
+		}
+	}
+	return errors
+}
+
+// hasJSONType returns whether v, as returned by parseJSON, is of the JSON
+// Schema type t.
+func hasJSONType(v interface{}, t string) bool {
+	if t == "integer" {
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		r, ok := jsonNumberRat(n)
+		return ok && r.IsInt()
+	}
+	return jsonType(v) == t
+}
+
+// jsonEqual returns whether two values returned by parseJSON are equal.
+func jsonEqual(a, b interface{}) bool {
+	return len(jsonDiff(a, b, "", false)) == 0
+}
+
+// jsonCount returns the value of a keyword holding a non-negative integer.
+func jsonCount(v interface{}) (int, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	count, err := strconv.Atoi(string(n))
+	if err != nil {
+		f, err := n.Float64()
+		if err != nil || f != float64(int(f)) {
+			return 0, false
+		}
+		count = int(f)
+	}
+	return count, count >= 0
+}
+
+// sortedKeys returns the keys of an object in order, so that errors are
+// reported deterministically.
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// JSONMatchesSchema asserts that the JSON document is valid against the
+// draft-07 JSON Schema.  Only local references, such as
+// "#/definitions/item", are supported, and formats are not validated.
+//
+//  assert.JSONMatchesSchema(t, `{"type": "object", "required": ["id"]}`, `{"id": 1}`)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func JSONMatchesSchema(t TestingT, schema string, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	schemaJSON, err := parseJSON(schema)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Schema ('%s') is not valid json.\nJSON parsing error: '%s'", schema, err.Error()), msgAndArgs...)
+	}
+	actualJSON, err := parseJSON(actual)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Input ('%s') needs to be valid json.\nJSON parsing error: '%s'", actual, err.Error()), msgAndArgs...)
+	}
+
+	s := &jsonSchema{root: schemaJSON}
+	errors := s.validate(schemaJSON, actualJSON, "")
+	if len(s.invalid) > 0 {
+		return Fail(t, fmt.Sprintf("Schema is not valid:\n%s", strings.Join(s.invalid, "\n")), msgAndArgs...)
+	}
+	if len(errors) > 0 {
+		return failWith(t, Failure{
+			Message:  fmt.Sprintf("JSON document does not match the schema:\n%s", strings.Join(errors, "\n")),
+			Expected: schema,
+			Actual:   actual,
+			Diff:     strings.Join(errors, "\n"),
+		}, msgAndArgs...)
+	}
+	return true
+}
+
+// JSONMatchesSchemaFile asserts that the JSON document is valid against
+// the draft-07 JSON Schema stored in the file at path.
+//
+//  assert.JSONMatchesSchemaFile(t, "testdata/user.schema.json", body)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func JSONMatchesSchemaFile(t TestingT, path string, actual string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	schema, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Cannot read schema file %s: %s", path, err), msgAndArgs...)
+	}
+	return JSONMatchesSchema(t, string(schema), actual, msgAndArgs...)
+}
@@ -0,0 +1,231 @@
+package assert
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Failure describes a failed assertion, as reported by a Reporter.  The
+// fields that do not apply to the assertion are empty.
+type Failure struct {
+	// Assertion is the name of the assertion that failed, such as "Equal".
+	Assertion string `json:"assertion,omitempty"`
+
+	// Message describes the failure.
+	Message string `json:"message"`
+
+	// Expected and Actual render the values compared by the assertion.
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+
+	// Diff describes the differences between the expected and actual
+	// values, as a unified diff or one difference per line.
+	Diff string `json:"diff,omitempty"`
+
+	// Messages is the message passed to the assertion in msgAndArgs.
+	Messages string `json:"messages,omitempty"`
+
+	// Callers are the file and line numbers of the calls leading to the
+	// assertion, as returned by CallerInfo.
+	Callers []string `json:"callers,omitempty"`
+}
+
+// Reporter formats a failure as the message of the failed test.
+type Reporter func(failure Failure) string
+
+// TextReporter formats failures as blocks of tab-aligned fields, with
+// carriage returns and padding hiding the file and line number that go
+// test prints before them.  It is the default Reporter.
+func TextReporter(failure Failure) string {
+	errorTrace := strings.Join(failure.Callers, "\n\r\t\t\t")
+	if len(failure.Messages) > 0 {
+		return fmt.Sprintf("\r%s\r\tError Trace:\t%s\n"+
+			"\r\tError:%s\n"+
+			"\r\tMessages:\t%s\n\r",
+			getWhitespaceString(),
+			errorTrace,
+			indentMessageLines(failure.Message, 2),
+			failure.Messages)
+	}
+	return fmt.Sprintf("\r%s\r\tError Trace:\t%s\n"+
+		"\r\tError:%s\n\r",
+		getWhitespaceString(),
+		errorTrace,
+		indentMessageLines(failure.Message, 2))
+}
+
+// PlainReporter formats failures as TextReporter does, without the
+// carriage returns and padding that garble the logs of continuous
+// integration systems.
+func PlainReporter(failure Failure) string {
+	text := fmt.Sprintf("\n\tError Trace:\t%s\n\tError:%s",
+		strings.Join(failure.Callers, "\n\t\t\t"),
+		indentMessageLines(failure.Message, 2))
+	if len(failure.Messages) > 0 {
+		text += fmt.Sprintf("\n\tMessages:\t%s", failure.Messages)
+	}
+	return text
+}
+
+// JSONReporter formats failures as a JSON object on a single line, with
+// the fields of Failure.
+func JSONReporter(failure Failure) string {
+	data, err := json.Marshal(failure)
+	if err != nil {
+		return fmt.Sprintf("{\"message\":%q}", err.Error())
+	}
+	return string(data)
+}
+
+// junitProperty is a property of a JUnit test case.
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// JUnitReporter formats failures as the properties element of a JUnit
+// test case, with a property for each non-empty field of Failure, named
+// as in JSONReporter.
+func JUnitReporter(failure Failure) string {
+	properties := struct {
+		XMLName    xml.Name        `xml:"properties"`
+		Properties []junitProperty `xml:"property"`
+	}{}
+	for _, field := range []junitProperty{
+		{"assertion", failure.Assertion},
+		{"message", failure.Message},
+		{"expected", failure.Expected},
+		{"actual", failure.Actual},
+		{"diff", failure.Diff},
+		{"messages", failure.Messages},
+		{"callers", strings.Join(failure.Callers, "\n")},
+	} {
+		if field.Value != "" {
+			properties.Properties = append(properties.Properties, field)
+		}
+	}
+	data, err := xml.MarshalIndent(properties, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+// reporters are the Reporters selected by -testify.reporter.
+var reporters = map[string]Reporter{
+	"text":  TextReporter,
+	"plain": PlainReporter,
+	"json":  JSONReporter,
+	"junit": JUnitReporter,
+}
+
+var reporterName = flag.String("testify.reporter", os.Getenv("TESTIFY_REPORTER"), "format of assertion failures: text, plain, json or junit (defaults to $TESTIFY_REPORTER or text)")
+
+var (
+	// reporterMutex protects reporter.
+	reporterMutex sync.Mutex
+
+	// reporter is the Reporter set by SetReporter.
+	reporter Reporter
+)
+
+// SetReporter sets the Reporter formatting the failures of assertions, and
+// returns the previous one.  A nil Reporter restores the one selected by
+// the -testify.reporter flag, or by $TESTIFY_REPORTER, which is one of
+// text, plain, json and junit and defaults to text.
+//
+//  func TestMain(m *testing.M) {
+//    assert.SetReporter(assert.JSONReporter)
+//    os.Exit(m.Run())
+//  }
+func SetReporter(r Reporter) Reporter {
+	reporterMutex.Lock()
+	defer reporterMutex.Unlock()
+	previous := reporter
+	reporter = r
+	return previous
+}
+
+// currentReporter returns the Reporter set by SetReporter, or the one
+// selected by -testify.reporter.
+func currentReporter() Reporter {
+	reporterMutex.Lock()
+	defer reporterMutex.Unlock()
+	if reporter != nil {
+		return reporter
+	}
+	if r, ok := reporters[strings.ToLower(*reporterName)]; ok {
+		return r
+	}
+	return TextReporter
+}
+
+// tHelper is implemented by *testing.T, which skips the functions calling
+// Helper when printing the file and line of a failure.
+type tHelper interface {
+	Helper()
+}
+
+// failWith reports a failure with the Reporter, filling in its messages,
+// callers and, unless set, assertion name.
+func failWith(t TestingT, failure Failure, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	failure.Messages = messageFromMsgAndArgs(msgAndArgs...)
+	failure.Callers = CallerInfo()
+	if failure.Assertion == "" {
+		failure.Assertion = assertionName()
+	}
+
+	if c, ok := t.(*Collector); ok {
+		c.collect(failure)
+		return false
+	}
+
+	t.Errorf("%s", currentReporter()(failure))
+	return false
+}
+
+// assertionName returns the name of the outermost exported function of
+// this package in the calls leading to the failure, which is the
+// assertion called by the test.
+func assertionName() string {
+	prefix := reflect.TypeOf(Failure{}).PkgPath() + "."
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	name := ""
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, prefix) {
+			break
+		}
+		function := strings.TrimPrefix(frame.Function, prefix)
+		if strings.HasPrefix(function, "(") {
+			// A method, such as (*Assertions).Equal.
+			function = function[strings.Index(function, ").")+2:]
+		}
+		function = strings.Split(function, ".")[0]
+		if isTest(function, "Test") {
+			// The tests of this package.
+			break
+		}
+		if r, _ := utf8.DecodeRuneInString(function); unicode.IsUpper(r) {
+			name = function
+		}
+		if !more {
+			break
+		}
+	}
+	return name
+}
@@ -0,0 +1,224 @@
+package assert
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// recordFailure returns the failure reported by the assertion, as given
+// to the Reporter.
+func recordFailure(assertion func(t TestingT)) Failure {
+	var failure Failure
+	previous := SetReporter(func(f Failure) string {
+		failure = f
+		return "failed"
+	})
+	defer SetReporter(previous)
+
+	mockT := new(bufferT)
+	assertion(mockT)
+	return failure
+}
+
+func TestFailureFields(t *testing.T) {
+	failure := recordFailure(func(t TestingT) {
+		Equal(t, []int{1, 2}, []int{1, 3}, "slices of %s", "ints")
+	})
+	Equal(t, "Equal", failure.Assertion)
+	Contains(t, failure.Message, "Not equal: []int{1, 2} (expected)")
+	Equal(t, "[]int{1, 2}", failure.Expected)
+	Equal(t, "[]int{1, 3}", failure.Actual)
+	True(t, strings.HasPrefix(failure.Diff, "--- Expected\n+++ Actual\n"), failure.Diff)
+	Equal(t, "slices of ints", failure.Messages)
+
+	failure = recordFailure(func(t TestingT) {
+		New(t).Error(nil)
+	})
+	Equal(t, "Error", failure.Assertion, "the outermost assertion is named")
+	Empty(t, failure.Expected)
+
+	failure = recordFailure(func(t TestingT) {
+		JSONEq(t, `{"a": 1}`, `{"a": 2}`)
+	})
+	Equal(t, "JSONEq", failure.Assertion)
+	Equal(t, `{"a": 1}`, failure.Expected)
+	Equal(t, "/a: expected 1 but got 2", failure.Diff)
+
+	failure = recordFailure(func(t TestingT) {
+		NotEqual(t, 1, 1)
+	})
+	Equal(t, "NotEqual", failure.Assertion)
+	Equal(t, "1", failure.Expected)
+	Equal(t, "1", failure.Actual)
+
+	failure = recordFailure(func(t TestingT) {
+		Greater(t, 1, 2)
+	})
+	Equal(t, "Greater", failure.Assertion)
+	Equal(t, `"1" is not greater than "2"`, failure.Message)
+	Equal(t, "greater than 2", failure.Expected)
+	Equal(t, "1", failure.Actual)
+
+	failure = recordFailure(func(t TestingT) {
+		Positive(t, -1)
+	})
+	Equal(t, "positive", failure.Expected)
+	Equal(t, "-1", failure.Actual)
+
+	failure = recordFailure(func(t TestingT) {
+		InRange(t, 11, 1, 10)
+	})
+	Equal(t, "in range [1, 10]", failure.Expected)
+	Equal(t, "11", failure.Actual)
+
+	failure = recordFailure(func(t TestingT) {
+		IsIncreasing(t, []int{1, 3, 2})
+	})
+	Equal(t, "IsIncreasing", failure.Assertion)
+	Equal(t, "increasing", failure.Expected)
+	Equal(t, "[1 3 2]", failure.Actual)
+	Equal(t, "[1]: 3\n[2]: 2", failure.Diff)
+
+	failure = recordFailure(func(t TestingT) {
+		JSONContains(t, `{"a": 1}`, `{"a": 2, "b": 3}`)
+	})
+	Equal(t, "JSONContains", failure.Assertion)
+	Equal(t, `{"a": 1}`, failure.Expected)
+	Equal(t, `{"a": 2, "b": 3}`, failure.Actual)
+	Equal(t, "/a: expected 1 but got 2", failure.Diff)
+
+	failure = recordFailure(func(t TestingT) {
+		JSONPathEq(t, `{"items": [{"id": 41}]}`, "$.items[0]", map[string]int{"id": 42})
+	})
+	Equal(t, "JSONPathEq", failure.Assertion)
+	Equal(t, `{"id":42}`, failure.Expected)
+	Equal(t, `{"id":41}`, failure.Actual)
+	Equal(t, "/items/0/id: expected 42 but got 41", failure.Diff)
+
+	failure = recordFailure(func(t TestingT) {
+		JSONMatchesSchema(t, `{"required": ["id"]}`, `{}`)
+	})
+	Equal(t, "JSONMatchesSchema", failure.Assertion)
+	Equal(t, `{}`, failure.Actual)
+	NotEmpty(t, failure.Diff)
+
+	failure = recordFailure(func(t TestingT) {
+		YAMLEq(t, "a: 1\n", "a: 2\n")
+	})
+	Equal(t, "YAMLEq", failure.Assertion)
+	Equal(t, "a: 1\n", failure.Expected)
+	Equal(t, "a: 2\n", failure.Actual)
+	Equal(t, "/a: expected 1 but got 2", failure.Diff)
+
+	failure = recordFailure(func(t TestingT) {
+		YAMLContains(t, "a: 1\n", "a: 2\n")
+	})
+	Equal(t, "YAMLContains", failure.Assertion)
+	Equal(t, "/a: expected 1 but got 2", failure.Diff)
+
+	failure = recordFailure(func(t TestingT) {
+		Fail(t, "failed")
+	})
+	Equal(t, "Fail", failure.Assertion)
+}
+
+func TestReporterOutput(t *testing.T) {
+	mockT := new(bufferT)
+	previous := SetReporter(func(f Failure) string { return "custom " + f.Assertion })
+	True(t, previous == nil, "no Reporter is set by default")
+	Exactly(mockT, int32(1), int64(1))
+	SetReporter(nil)
+	Equal(t, []string{"custom Exactly"}, mockT.messages)
+}
+
+func TestReporters(t *testing.T) {
+	failure := Failure{
+		Assertion: "Equal",
+		Message:   "Not equal: 1 (expected)\n        != 2 (actual)",
+		Expected:  "1",
+		Actual:    "2",
+		Messages:  "answer",
+		Callers:   []string{"a_test.go:12", "a_test.go:30"},
+	}
+
+	text := TextReporter(failure)
+	Contains(t, text, "\r\tError Trace:\ta_test.go:12\n\r\t\t\ta_test.go:30\n")
+	Contains(t, text, "\r\tMessages:\tanswer\n\r")
+
+	Equal(t, "\n\tError Trace:\ta_test.go:12\n\t\t\ta_test.go:30\n\tError:\t\tNot equal: 1 (expected)\n\t        != 2 (actual)\n\tMessages:\tanswer", PlainReporter(failure))
+	NotContains(t, PlainReporter(Failure{Message: "failed"}), "Messages")
+
+	line := JSONReporter(failure)
+	NotContains(t, line, "\n")
+	var decoded Failure
+	if NoError(t, json.Unmarshal([]byte(line), &decoded)) {
+		Equal(t, failure, decoded)
+	}
+	NotContains(t, line, "diff", "empty fields are omitted")
+
+	junit := JUnitReporter(failure)
+	var properties struct {
+		Properties []junitProperty `xml:"property"`
+	}
+	if NoError(t, xml.Unmarshal([]byte(junit), &properties)) {
+		Equal(t, []junitProperty{
+			{"assertion", "Equal"},
+			{"message", failure.Message},
+			{"expected", "1"},
+			{"actual", "2"},
+			{"messages", "answer"},
+			{"callers", "a_test.go:12\na_test.go:30"},
+		}, properties.Properties)
+	}
+}
+
+func TestReporterFlag(t *testing.T) {
+	defer flag.Set("testify.reporter", *reporterName)
+
+	for name, want := range map[string]string{
+		"plain": "\n\tError Trace:",
+		"JSON":  `{"assertion":"True"`,
+		"junit": "<properties>",
+		"":      "\r",
+		"other": "\r",
+	} {
+		if NoError(t, flag.Set("testify.reporter", name)) {
+			mockT := new(bufferT)
+			True(mockT, false)
+			if Len(t, mockT.messages, 1) {
+				True(t, strings.HasPrefix(mockT.messages[0], want), "%s: %q", name, mockT.messages[0])
+			}
+		}
+	}
+}
+
+// TestJSONReporterOutput runs a failing assertion with a *testing.T in a
+// new test process, and checks that go test prints the JSON failure after
+// the file and line of the assertion in the test.
+func TestJSONReporterOutput(t *testing.T) {
+	if os.Getenv("TESTIFY_JSON_OUTPUT") != "" {
+		Equal(t, 1, 2, "answer")
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestJSONReporterOutput$", "-testify.reporter=json")
+	cmd.Env = append(os.Environ(), "TESTIFY_JSON_OUTPUT=1")
+	output, err := cmd.CombinedOutput()
+	Error(t, err, "the assertion fails the test")
+
+	lines := regexp.MustCompile(`(?m)^\s*reporter_test\.go:\d+: (.*)$`).FindAllStringSubmatch(string(output), -1)
+	if Len(t, lines, 1, "%s", output) {
+		var failure Failure
+		if NoError(t, json.Unmarshal([]byte(lines[0][1]), &failure)) {
+			Equal(t, "Equal", failure.Assertion)
+			Equal(t, "answer", failure.Messages)
+		}
+	}
+	NotContains(t, string(output), "reporter.go:")
+}
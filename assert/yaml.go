@@ -0,0 +1,835 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// The YAML parser below reads the subset of YAML 1.2 used by configuration
+// files: block and flow collections, plain, quoted and block scalars,
+// anchors and aliases, merge keys and multi-document streams.  The rest of
+// YAML, such as explicit keys, %TAG directives and tags other than the
+// standard ones, is rejected with an error, as YAMLEq documents.  It returns
+// the same values as parseJSON, so that documents can be compared with
+// jsonDiff: mappings are map[string]interface{}, with their keys
+// rendered as strings, and numbers are json.Number.
+
+// yamlLine is a line of a YAML document, split into its indentation and
+// its text.
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+// yamlParser parses the lines of a YAML document.
+type yamlParser struct {
+	lines   []yamlLine
+	pos     int
+	anchors map[string]interface{}
+}
+
+// parseYAML parses a YAML stream, and returns its documents.
+func parseYAML(s string) ([]interface{}, error) {
+	documents := []interface{}{}
+	var lines []yamlLine
+	started := false
+	flush := func() error {
+		if !started {
+			return nil
+		}
+		p := &yamlParser{lines: lines, anchors: make(map[string]interface{})}
+		document, err := p.parseDocument()
+		if err != nil {
+			return err
+		}
+		documents = append(documents, document)
+		lines, started = nil, false
+		return nil
+	}
+
+	for i, raw := range strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n") {
+		switch {
+		case !started && strings.HasPrefix(raw, "%"):
+			if directive := strings.Fields(stripYAMLComment(raw)); len(directive) != 2 || directive[0] != "%YAML" || directive[1] != "1.2" {
+				return nil, fmt.Errorf("line %d: unsupported directive %q, only %%YAML 1.2 is supported", i+1, stripYAMLComment(raw))
+			}
+			continue
+		case raw == "---" || strings.HasPrefix(raw, "--- ") || strings.HasPrefix(raw, "---\t"):
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			started = true
+			if rest := strings.TrimSpace(raw[3:]); rest != "" {
+				lines = append(lines, yamlLine{number: i + 1, text: rest})
+			}
+			continue
+		case raw == "..." || strings.HasPrefix(raw, "... "):
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		text := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(text, "\t") && stripYAMLComment(text) != "" {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", i+1)
+		}
+		if stripYAMLComment(text) != "" {
+			started = true
+		}
+		lines = append(lines, yamlLine{number: i + 1, indent: len(raw) - len(text), text: text})
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+// parseDocument parses the lines of the parser as a single document.
+func (p *yamlParser) parseDocument() (interface{}, error) {
+	document, err := p.parseBlock(0)
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	if !p.done() {
+		line := p.current()
+		return nil, fmt.Errorf("line %d: unexpected %q", line.number, stripYAMLComment(line.text))
+	}
+	return document, nil
+}
+
+func (p *yamlParser) done() bool {
+	return p.pos >= len(p.lines)
+}
+
+func (p *yamlParser) current() yamlLine {
+	return p.lines[p.pos]
+}
+
+// skipBlank skips the blank lines and the comment lines.
+func (p *yamlParser) skipBlank() {
+	for !p.done() && stripYAMLComment(p.current().text) == "" {
+		p.pos++
+	}
+}
+
+// parseBlock parses the node starting at the next line, if it is indented
+// by at least minIndent, and returns nil otherwise.
+func (p *yamlParser) parseBlock(minIndent int) (interface{}, error) {
+	p.skipBlank()
+	if p.done() || p.current().indent < minIndent {
+		return nil, nil
+	}
+	line := p.current()
+	text := stripYAMLComment(line.text)
+	switch {
+	case isYAMLSequenceEntry(text):
+		return p.parseSequence(line.indent)
+	case yamlKeyEnd(text) >= 0:
+		return p.parseMapping(line.indent)
+	}
+	p.pos++
+	return p.parseValue(text, line, minIndent-1, false)
+}
+
+// parseSequence parses the block sequence whose entries are indented by
+// indent.
+func (p *yamlParser) parseSequence(indent int) (interface{}, error) {
+	sequence := []interface{}{}
+	for {
+		p.skipBlank()
+		if p.done() || p.current().indent != indent {
+			return sequence, nil
+		}
+		line := p.current()
+		text := stripYAMLComment(line.text)
+		if !isYAMLSequenceEntry(text) {
+			return sequence, nil
+		}
+
+		rest := strings.TrimLeft(text[1:], " \t")
+		var entry interface{}
+		var err error
+		if isYAMLSequenceEntry(rest) || yamlKeyEnd(rest) >= 0 {
+			// A compact collection, such as "- key: value", continues
+			// on the following lines at the indentation of its first
+			// entry.
+			p.lines[p.pos] = yamlLine{number: line.number, indent: indent + len(text) - len(rest), text: rest}
+			entry, err = p.parseBlock(indent + 1)
+		} else {
+			p.pos++
+			entry, err = p.parseValue(rest, line, indent, true)
+		}
+		if err != nil {
+			return nil, err
+		}
+		sequence = append(sequence, entry)
+	}
+}
+
+// parseMapping parses the block mapping whose keys are indented by
+// indent.
+func (p *yamlParser) parseMapping(indent int) (interface{}, error) {
+	mapping := map[string]interface{}{}
+	merged := []interface{}{}
+	for {
+		p.skipBlank()
+		if p.done() || p.current().indent != indent {
+			break
+		}
+		line := p.current()
+		text := stripYAMLComment(line.text)
+		end := yamlKeyEnd(text)
+		if end < 0 {
+			if isYAMLSequenceEntry(text) {
+				break
+			}
+			return nil, fmt.Errorf("line %d: expected a mapping key in %q", line.number, text)
+		}
+		keyText := strings.TrimSpace(text[:end])
+		key, err := parseYAMLFlow(keyText, line.number, p.anchors)
+		if err != nil {
+			return nil, err
+		}
+		p.pos++
+		value, err := p.parseValue(strings.TrimSpace(text[end+1:]), line, indent, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if keyText == "<<" {
+			merged = append(merged, value)
+			continue
+		}
+		name := yamlKeyString(key)
+		if _, ok := mapping[name]; ok {
+			return nil, fmt.Errorf("line %d: duplicate key %q", line.number, name)
+		}
+		mapping[name] = value
+	}
+
+	// Merged keys do not override the keys of the mapping, and the first
+	// merged mapping wins.
+	for _, value := range merged {
+		sources, ok := value.([]interface{})
+		if !ok {
+			sources = []interface{}{value}
+		}
+		for _, source := range sources {
+			m, ok := source.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("merge key << must refer to mappings, not %s", jsonString(source))
+			}
+			for key, v := range m {
+				if _, ok := mapping[key]; !ok {
+					mapping[key] = v
+				}
+			}
+		}
+	}
+	return mapping, nil
+}
+
+// parseValue parses the value following a key or a sequence entry on
+// line, whose parent node is indented by parentIndent.  The value is
+// either on the line itself, possibly continued on the following lines,
+// or a block node on the following lines.
+func (p *yamlParser) parseValue(text string, line yamlLine, parentIndent int, inSequence bool) (interface{}, error) {
+	anchor, tag, rest := yamlProperties(text)
+
+	var value interface{}
+	var err error
+	switch {
+	case rest == "":
+		p.skipBlank()
+		if !inSequence && !p.done() && p.current().indent == parentIndent && isYAMLSequenceEntry(stripYAMLComment(p.current().text)) {
+			// Sequences may be indented like the key they are the
+			// value of.
+			value, err = p.parseSequence(parentIndent)
+		} else {
+			value, err = p.parseBlock(parentIndent + 1)
+		}
+		if err == nil && tag != "" {
+			value, err = applyYAMLTag(tag, value, line.number)
+		}
+	case rest[0] == '|' || rest[0] == '>':
+		value, err = p.parseBlockScalar(rest, line, parentIndent)
+	default:
+		for yamlContinues(text) {
+			j := p.pos
+			for j < len(p.lines) && stripYAMLComment(p.lines[j].text) == "" {
+				j++
+			}
+			if j == len(p.lines) || p.lines[j].indent <= parentIndent {
+				break
+			}
+			if isYAMLPlain(rest) && yamlKeyEnd(stripYAMLComment(p.lines[j].text)) >= 0 {
+				// A plain scalar cannot be followed by a mapping.
+				break
+			}
+			text += " " + stripYAMLComment(p.lines[j].text)
+			p.pos = j + 1
+		}
+		return parseYAMLFlow(text, line.number, p.anchors)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if anchor != "" {
+		p.anchors[anchor] = value
+	}
+	return value, nil
+}
+
+// parseBlockScalar parses a literal (|) or folded (>) block scalar whose
+// header is on line, and whose content follows it.
+func (p *yamlParser) parseBlockScalar(header string, line yamlLine, parentIndent int) (interface{}, error) {
+	literal := header[0] == '|'
+	chomping, contentIndent := "clip", 0
+	for _, c := range strings.TrimSpace(header[1:]) {
+		switch {
+		case c == '-':
+			chomping = "strip"
+		case c == '+':
+			chomping = "keep"
+		case c >= '1' && c <= '9':
+			contentIndent = parentIndent + int(c-'0')
+			if parentIndent < 0 {
+				contentIndent = int(c - '0')
+			}
+		default:
+			return nil, fmt.Errorf("line %d: invalid block scalar header %q", line.number, header)
+		}
+	}
+
+	var lines []string
+	for ; !p.done(); p.pos++ {
+		l := p.current()
+		if strings.TrimSpace(l.text) == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if l.indent <= parentIndent || (contentIndent > 0 && l.indent < contentIndent) {
+			break
+		}
+		if contentIndent == 0 {
+			contentIndent = l.indent
+		}
+		lines = append(lines, strings.Repeat(" ", l.indent-contentIndent)+l.text)
+	}
+
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+	if len(lines) == 0 {
+		if chomping == "keep" {
+			return strings.Repeat("\n", trailing), nil
+		}
+		return "", nil
+	}
+
+	var text string
+	if literal {
+		text = strings.Join(lines, "\n")
+	} else {
+		text = foldYAMLLines(lines)
+	}
+	switch chomping {
+	case "clip":
+		text += "\n"
+	case "keep":
+		text += "\n" + strings.Repeat("\n", trailing)
+	}
+	return text, nil
+}
+
+// foldYAMLLines joins the lines of a folded block scalar: line breaks
+// between lines of text become spaces, and empty lines become line
+// breaks.  More indented lines are kept as they are.
+func foldYAMLLines(lines []string) string {
+	moreIndented := func(line string) bool {
+		return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+	}
+	var b bytes.Buffer
+	for i := 0; i < len(lines); {
+		b.WriteString(lines[i])
+		j := i + 1
+		for j < len(lines) && lines[j] == "" {
+			j++
+		}
+		if j == len(lines) {
+			break
+		}
+		breaks := j - i - 1
+		if moreIndented(lines[i]) || moreIndented(lines[j]) {
+			breaks++
+		}
+		if breaks == 0 {
+			b.WriteString(" ")
+		} else {
+			b.WriteString(strings.Repeat("\n", breaks))
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// isYAMLSequenceEntry returns whether text starts a block sequence entry.
+func isYAMLSequenceEntry(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ") || strings.HasPrefix(text, "-\t")
+}
+
+// yamlQuoteStarts returns whether a quote at index i of text starts a
+// quoted scalar, rather than being part of a plain one.
+func yamlQuoteStarts(text string, i int) bool {
+	return i == 0 || strings.ContainsRune(" \t[{,:", rune(text[i-1]))
+}
+
+// scanYAML calls visit with the index of each character of text that is
+// outside quoted scalars, along with the depth of flow collections at
+// that character, until visit returns false.
+func scanYAML(text string, visit func(i, depth int) bool) {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if (c == '"' || c == '\'') && yamlQuoteStarts(text, i) {
+			for i++; i < len(text); i++ {
+				if c == '"' && text[i] == '\\' {
+					i++
+				} else if text[i] == c {
+					if c == '\'' && i+1 < len(text) && text[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			continue
+		}
+		switch c {
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		}
+		if !visit(i, depth) {
+			return
+		}
+	}
+}
+
+// stripYAMLComment returns text without its comment and trailing spaces.
+func stripYAMLComment(text string) string {
+	end := len(text)
+	scanYAML(text, func(i, depth int) bool {
+		if text[i] == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t') {
+			end = i
+			return false
+		}
+		return true
+	})
+	return strings.TrimRight(text[:end], " \t")
+}
+
+// yamlKeyEnd returns the index of the colon ending the key of a block
+// mapping entry in text, or -1 if text is not a mapping entry.
+func yamlKeyEnd(text string) int {
+	end := -1
+	scanYAML(text, func(i, depth int) bool {
+		if text[i] == ':' && depth == 0 && (i+1 == len(text) || text[i+1] == ' ' || text[i+1] == '\t') {
+			end = i
+			return false
+		}
+		return true
+	})
+	return end
+}
+
+// yamlContinues returns whether the scalar or flow collection in text may
+// continue on the following lines: plain scalars may always, while quoted
+// scalars and flow collections do until they are closed.
+func yamlContinues(text string) bool {
+	_, _, rest := yamlProperties(text)
+	if rest == "" {
+		return false
+	}
+	switch rest[0] {
+	case '[', '{':
+		depth := 0
+		scanYAML(rest, func(i, d int) bool {
+			depth = d
+			return true
+		})
+		return depth > 0
+	case '"', '\'':
+		p := &yamlFlow{s: rest}
+		_, err := p.quoted()
+		return err != nil
+	case '*':
+		return false
+	}
+	return true
+}
+
+// isYAMLPlain returns whether text starts a plain scalar, rather than a
+// quoted scalar, a flow collection or an alias.
+func isYAMLPlain(text string) bool {
+	return text != "" && !strings.ContainsRune("\"'[{*", rune(text[0]))
+}
+
+// yamlProperties splits the anchor (&name) and the tag (!tag) off the
+// start of a node.
+func yamlProperties(text string) (anchor, tag, rest string) {
+	rest = text
+	for rest != "" && (rest[0] == '&' || rest[0] == '!') {
+		end := strings.IndexAny(rest, " \t")
+		if end < 0 {
+			end = len(rest)
+		}
+		if rest[0] == '&' {
+			anchor = rest[1:end]
+		} else {
+			tag = rest[:end]
+		}
+		rest = strings.TrimLeft(rest[end:], " \t")
+	}
+	return anchor, tag, rest
+}
+
+// yamlKeyString renders a key of a mapping as a string, as the keys of
+// JSON objects.
+func yamlKeyString(key interface{}) string {
+	switch key := key.(type) {
+	case string:
+		return key
+	case json.Number:
+		return string(key)
+	case nil:
+		return "null"
+	}
+	return jsonString(key)
+}
+
+// yamlFlow parses a scalar or a flow collection.
+type yamlFlow struct {
+	s       string
+	i       int
+	line    int
+	anchors map[string]interface{}
+}
+
+// parseYAMLFlow parses text as a single scalar or flow collection.
+func parseYAMLFlow(text string, line int, anchors map[string]interface{}) (interface{}, error) {
+	p := &yamlFlow{s: text, line: line, anchors: anchors}
+	value, err := p.value(false)
+	if err != nil {
+		return nil, err
+	}
+	p.space()
+	if p.i < len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.i:])
+	}
+	return value, nil
+}
+
+func (p *yamlFlow) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+func (p *yamlFlow) space() {
+	for p.i < len(p.s) && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+// value parses a node, which is inside a flow collection if inFlow is
+// true.
+func (p *yamlFlow) value(inFlow bool) (interface{}, error) {
+	p.space()
+	anchor, tag := "", ""
+	for p.i < len(p.s) && (p.s[p.i] == '&' || p.s[p.i] == '!') {
+		start := p.i
+		for p.i < len(p.s) && !strings.ContainsRune(" \t,[]{}", rune(p.s[p.i])) {
+			p.i++
+		}
+		if p.s[start] == '&' {
+			anchor = p.s[start+1 : p.i]
+		} else {
+			tag = p.s[start:p.i]
+		}
+		p.space()
+	}
+
+	var value interface{}
+	var err error
+	quoted := false
+	switch {
+	case p.i == len(p.s):
+		value = nil
+	case p.s[p.i] == '[':
+		value, err = p.sequence()
+	case p.s[p.i] == '{':
+		value, err = p.mapping()
+	case p.s[p.i] == '"' || p.s[p.i] == '\'':
+		value, err = p.quoted()
+		quoted = true
+	case p.s[p.i] == '*':
+		start := p.i + 1
+		for p.i++; p.i < len(p.s) && !strings.ContainsRune(" \t,[]{}", rune(p.s[p.i])); p.i++ {
+		}
+		name := p.s[start:p.i]
+		aliased, ok := p.anchors[name]
+		if !ok {
+			return nil, p.errorf("unknown alias *%s", name)
+		}
+		return aliased, nil
+	case strings.ContainsRune("@`%", rune(p.s[p.i])):
+		return nil, p.errorf("plain scalars cannot start with %q", p.s[p.i])
+	case p.s[p.i] == '?' && (p.i+1 == len(p.s) || p.s[p.i+1] == ' ' || p.s[p.i+1] == '\t'):
+		return nil, p.errorf("explicit keys (?) are not supported")
+	default:
+		plain := p.plain(inFlow)
+		if tag == "!!str" {
+			value = plain
+		} else {
+			value = resolveYAMLScalar(plain)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if tag != "" && tag != "!!str" {
+		if quoted {
+			value = resolveYAMLScalar(value.(string))
+		}
+		if value, err = applyYAMLTag(tag, value, p.line); err != nil {
+			return nil, err
+		}
+	}
+	if anchor != "" {
+		p.anchors[anchor] = value
+	}
+	return value, nil
+}
+
+// plain parses a plain scalar, which ends at the end of the text, at the
+// colon ending a key, or at the flow indicators inside flow collections.
+func (p *yamlFlow) plain(inFlow bool) string {
+	start := p.i
+	for ; p.i < len(p.s); p.i++ {
+		c := p.s[p.i]
+		if c == ':' && (p.i+1 == len(p.s) || strings.ContainsRune(" \t,[]{}", rune(p.s[p.i+1]))) && inFlow {
+			break
+		}
+		if inFlow && strings.ContainsRune(",[]{}", rune(c)) {
+			break
+		}
+	}
+	return strings.TrimSpace(p.s[start:p.i])
+}
+
+// quoted parses a single-quoted or double-quoted scalar.
+func (p *yamlFlow) quoted() (string, error) {
+	quote := p.s[p.i]
+	var b bytes.Buffer
+	for p.i++; p.i < len(p.s); p.i++ {
+		c := p.s[p.i]
+		switch {
+		case c == quote && quote == '\'' && p.i+1 < len(p.s) && p.s[p.i+1] == '\'':
+			b.WriteByte('\'')
+			p.i++
+		case c == quote:
+			p.i++
+			return b.String(), nil
+		case c == '\\' && quote == '"':
+			p.i++
+			if p.i == len(p.s) {
+				return "", p.errorf("unterminated escape sequence")
+			}
+			r, err := p.escape()
+			if err != nil {
+				return "", err
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated quoted scalar %s", p.s)
+}
+
+// yamlEscapes maps the single character escape sequences of double-quoted
+// scalars to the characters they stand for.
+var yamlEscapes = map[byte]rune{
+	'0': 0, 'a': '\a', 'b': '\b', 't': '\t', '\t': '\t', 'n': '\n', 'v': '\v',
+	'f': '\f', 'r': '\r', 'e': 0x1b, ' ': ' ', '"': '"', '/': '/', '\\': '\\',
+	'N': 0x85, '_': 0xa0, 'L': 0x2028, 'P': 0x2029,
+}
+
+// escape parses the escape sequence following a backslash.
+func (p *yamlFlow) escape() (rune, error) {
+	c := p.s[p.i]
+	if r, ok := yamlEscapes[c]; ok {
+		return r, nil
+	}
+	size := map[byte]int{'x': 2, 'u': 4, 'U': 8}[c]
+	if size == 0 || p.i+size >= len(p.s) {
+		return 0, p.errorf("invalid escape sequence \\%c", c)
+	}
+	code, err := strconv.ParseUint(p.s[p.i+1:p.i+1+size], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		return 0, p.errorf("invalid escape sequence \\%s", p.s[p.i:p.i+1+size])
+	}
+	p.i += size
+	return rune(code), nil
+}
+
+// sequence parses a flow sequence.
+func (p *yamlFlow) sequence() (interface{}, error) {
+	sequence := []interface{}{}
+	p.i++
+	for {
+		p.space()
+		if p.i < len(p.s) && p.s[p.i] == ']' {
+			p.i++
+			return sequence, nil
+		}
+		entry, err := p.value(true)
+		if err != nil {
+			return nil, err
+		}
+		sequence = append(sequence, entry)
+		p.space()
+		switch {
+		case p.i < len(p.s) && p.s[p.i] == ',':
+			p.i++
+		case p.i < len(p.s) && p.s[p.i] == ']':
+		default:
+			return nil, p.errorf("expected , or ] in flow sequence %s", p.s)
+		}
+	}
+}
+
+// mapping parses a flow mapping.
+func (p *yamlFlow) mapping() (interface{}, error) {
+	mapping := map[string]interface{}{}
+	p.i++
+	for {
+		p.space()
+		if p.i < len(p.s) && p.s[p.i] == '}' {
+			p.i++
+			return mapping, nil
+		}
+		start := p.i
+		key, err := p.value(true)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(p.s[start:p.i]) == "<<" {
+			return nil, p.errorf("merge keys (<<) are only supported in block mappings")
+		}
+		p.space()
+		var value interface{}
+		if p.i < len(p.s) && p.s[p.i] == ':' {
+			p.i++
+			p.space()
+			if p.i < len(p.s) && p.s[p.i] != ',' && p.s[p.i] != '}' {
+				if value, err = p.value(true); err != nil {
+					return nil, err
+				}
+			}
+		}
+		name := yamlKeyString(key)
+		if _, ok := mapping[name]; ok {
+			return nil, p.errorf("duplicate key %q", name)
+		}
+		mapping[name] = value
+		p.space()
+		switch {
+		case p.i < len(p.s) && p.s[p.i] == ',':
+			p.i++
+		case p.i < len(p.s) && p.s[p.i] == '}':
+		default:
+			return nil, p.errorf("expected , or } in flow mapping %s", p.s)
+		}
+	}
+}
+
+var (
+	yamlIntPattern   = regexp.MustCompile(`^[-+]?[0-9]+$`)
+	yamlFloatPattern = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+	jsonNumberRegexp = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+)
+
+// resolveYAMLScalar returns the value of a plain scalar, following the
+// core schema of YAML 1.2: null, booleans, integers and floats are
+// recognized, and numbers are normalized so that 0x10 and 16 are equal.
+func resolveYAMLScalar(s string) interface{} {
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return json.Number(".inf")
+	case "-.inf", "-.Inf", "-.INF":
+		return json.Number("-.inf")
+	case ".nan", ".NaN", ".NAN":
+		return json.Number(".nan")
+	}
+
+	base, digits := 10, strings.TrimPrefix(s, "+")
+	switch {
+	case strings.HasPrefix(s, "0x"):
+		base, digits = 16, s[2:]
+	case strings.HasPrefix(s, "0o"):
+		base, digits = 8, s[2:]
+	case yamlIntPattern.MatchString(s):
+	case yamlFloatPattern.MatchString(s):
+		if jsonNumberRegexp.MatchString(digits) {
+			return json.Number(digits)
+		}
+		r, _ := new(big.Rat).SetString(digits)
+		f, _ := r.Float64()
+		return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+	default:
+		return s
+	}
+	if n, ok := new(big.Int).SetString(digits, base); ok && (base == 10 || !strings.ContainsAny(digits[:1], "+-")) {
+		return json.Number(n.String())
+	}
+	return s
+}
+
+// applyYAMLTag checks that value is of the type required by the standard
+// tag.  Other tags are not supported.
+func applyYAMLTag(tag string, value interface{}, line int) (interface{}, error) {
+	want := map[string]string{
+		"!!str":   "string",
+		"!!null":  "null",
+		"!!bool":  "boolean",
+		"!!int":   "number",
+		"!!float": "number",
+		"!!seq":   "array",
+		"!!map":   "object",
+	}[tag]
+	if want == "" {
+		return nil, fmt.Errorf("line %d: unsupported tag %s, only !!str, !!null, !!bool, !!int, !!float, !!seq and !!map are supported", line, tag)
+	}
+	if jsonType(value) == want {
+		return value, nil
+	}
+	return nil, fmt.Errorf("line %d: %s is not a valid %s", line, jsonString(value), tag)
+}
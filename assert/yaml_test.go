@@ -0,0 +1,113 @@
+package assert
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	for _, c := range []struct {
+		yaml, json string
+	}{
+		{"a: 1\nb: two\n", `{"a": 1, "b": "two"}`},
+		{"a:\n  b:\n    c: true\n  d: ~\n", `{"a": {"b": {"c": true}, "d": null}}`},
+		{"- 1\n- - 2\n  - 3\n-\n- x: 1\n  y: 2\n", `[1, [2, 3], null, {"x": 1, "y": 2}]`},
+		{"list:\n- a\n- b\nnext: c\n", `{"list": ["a", "b"], "next": "c"}`},
+		{"a: [1, 'two', {b: \"three\", c: [ ]}]\nd: {}\n", `{"a": [1, "two", {"b": "three", "c": []}], "d": {}}`},
+		{"{\"a\": [1, 2.5e3, true, null], \"b\": \"x\"}", `{"a": [1, 2500, true, null], "b": "x"}`},
+		{"n: [0x10, 0o17, +5, 007, 1., .5, -1e2]", `{"n": [16, 15, 5, 7, 1, 0.5, -100]}`},
+		{"s: [yes, no, 'true', \"1\", 1.2.3, 0xZZ, a b]", `{"s": ["yes", "no", "true", "1", "1.2.3", "0xZZ", "a b"]}`},
+		{"b: [true, False, TRUE, null, Null, '']", `{"b": [true, false, true, null, null, ""]}`},
+		{"1: a\ntrue: b\n~: c\n", `{"1": "a", "true": "b", "null": "c"}`},
+		{"url: http://example.com/a#b # comment\n# full line\nq: 'it''s # not a comment'\n", `{"url": "http://example.com/a#b", "q": "it's # not a comment"}`},
+		{"d: \"tab\\there\\n\\u00e9\\x41\\\"\"\n", `{"d": "tab\there\n\u00e9A\""}`},
+		{"p: this is\n  a plain\n  scalar\nq: \"a quoted\n  scalar\"\n", `{"p": "this is a plain scalar", "q": "a quoted scalar"}`},
+		{"f: [1,\n  2,\n  3]\n", `{"f": [1, 2, 3]}`},
+		{"l: |\n  line 1\n    line 2\n\n  line 3\nnext: 1\n", `{"l": "line 1\n  line 2\n\nline 3\n", "next": 1}`},
+		{"l: |-\n  a\n  b\n\n", `{"l": "a\nb"}`},
+		{"l: |+\n  a\n\n\nn: 1\n", `{"l": "a\n\n\n", "n": 1}`},
+		{"l: |2\n    indented\n  x\n", `{"l": "  indented\nx\n"}`},
+		{"f: >\n  folded\n  text\n\n  new paragraph\n    kept\n  end\n", `{"f": "folded text\nnew paragraph\n  kept\nend\n"}`},
+		{"- |\n  in a list\n- >-\n  folded\n  entry\n", `["in a list\n", "folded entry"]`},
+		{"base: &base\n  a: 1\n  b: 2\nderived:\n  <<: *base\n  b: 3\nref: *base\n", `{"base": {"a": 1, "b": 2}, "derived": {"a": 1, "b": 3}, "ref": {"a": 1, "b": 2}}`},
+		{"x: &v 5\ny: *v\nz: [&w a, *w]\n", `{"x": 5, "y": 5, "z": ["a", "a"]}`},
+		{"m:\n  <<: [{a: 1}, {a: 2, b: 2}]\n", `{"m": {"a": 1, "b": 2}}`},
+		{"s: !!str 123\ni: !!int \"12\"\nl: !!seq [a]\n", `{"s": "123", "i": 12, "l": ["a"]}`},
+		{"plain scalar\n", `"plain scalar"`},
+		{"'quoted: not a key'\n", `"quoted: not a key"`},
+		{"- a: 1\n  b:\n  - x\n  - y\n- c\n", `[{"a": 1, "b": ["x", "y"]}, "c"]`},
+		{"k: v\r\nl: w\r\n", `{"k": "v", "l": "w"}`},
+	} {
+		documents, err := parseYAML(c.yaml)
+		if !NoError(t, err, c.yaml) || !Len(t, documents, 1, c.yaml) {
+			continue
+		}
+		expected, err := parseJSON(c.json)
+		if NoError(t, err, c.json) {
+			Empty(t, jsonDiff(expected, documents[0], "", false), "%q", c.yaml)
+		}
+	}
+}
+
+func TestParseYAMLSpecialFloats(t *testing.T) {
+	documents, err := parseYAML("[.inf, +.INF, -.Inf, .NaN]")
+	if NoError(t, err) {
+		Equal(t, []interface{}{[]interface{}{json.Number(".inf"), json.Number(".inf"), json.Number("-.inf"), json.Number(".nan")}}, documents)
+	}
+}
+
+func TestParseYAMLStreams(t *testing.T) {
+	for _, c := range []struct {
+		yaml      string
+		documents []interface{}
+	}{
+		{"", []interface{}{}},
+		{"# only a comment\n", []interface{}{}},
+		{"a\n", []interface{}{"a"}},
+		{"---\n", []interface{}{nil}},
+		{"--- a\n--- b\n", []interface{}{"a", "b"}},
+		{"%YAML 1.2\n---\nx\n...\n---\ny\n...\n", []interface{}{"x", "y"}},
+		{"a\n---\nb\n", []interface{}{"a", "b"}},
+		{"--- |\n  text\n", []interface{}{"text\n"}},
+	} {
+		documents, err := parseYAML(c.yaml)
+		if NoError(t, err, c.yaml) {
+			Equal(t, c.documents, documents, "%q", c.yaml)
+		}
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	for _, c := range []struct {
+		yaml, message string
+	}{
+		{"a: 1\na: 2\n", `line 2: duplicate key "a"`},
+		{"{a: 1, a: 2}", `line 1: duplicate key "a"`},
+		{"a: 1\n  b: 2\n", `line 2: unexpected "b: 2"`},
+		{"a:\n\tb: 1\n", "line 2: tabs are not allowed for indentation"},
+		{"a: *missing\n", "line 1: unknown alias *missing"},
+		{"a: [1, 2\n", "line 1: expected , or ] in flow sequence [1, 2"},
+		{"a: {b: 1\n", "line 1: expected , or } in flow mapping {b: 1"},
+		{"a: 'open\n", "line 1: unterminated quoted scalar 'open"},
+		{"a: \"\\q\"\n", `line 1: invalid escape sequence \q`},
+		{"a: |x\n  b\n", `line 1: invalid block scalar header "|x"`},
+		{"a: !!int abc\n", `line 1: "abc" is not a valid !!int`},
+		{"a:\n  <<: 1\n", "merge key << must refer to mappings, not 1"},
+		{"a: 1\nb\n", `line 2: expected a mapping key in "b"`},
+		{"- a\nb: 1\n", `line 2: unexpected "b: 1"`},
+		{"t: !custom value\n", "line 1: unsupported tag !custom, only !!str, !!null, !!bool, !!int, !!float, !!seq and !!map are supported"},
+		{"t: !!str\n  a: 1\n", `line 1: {"a":1} is not a valid !!str`},
+		{"%TAG ! tag:example.com,2000:\n---\na\n", `line 1: unsupported directive "%TAG ! tag:example.com,2000:", only %YAML 1.2 is supported`},
+		{"%YAML 1.1\n---\na\n", `line 1: unsupported directive "%YAML 1.1", only %YAML 1.2 is supported`},
+		{"? a\n: 1\n", "line 1: explicit keys (?) are not supported"},
+		{"? a: 1\n", "line 1: explicit keys (?) are not supported"},
+		{"a: [? b]\n", "line 1: explicit keys (?) are not supported"},
+		{"a: {<<: {b: 1}}\n", "line 1: merge keys (<<) are only supported in block mappings"},
+		{"a: @b\n", "line 1: plain scalars cannot start with '@'"},
+	} {
+		_, err := parseYAML(c.yaml)
+		if Error(t, err, c.yaml) {
+			Equal(t, c.message, err.Error(), "%q", c.yaml)
+		}
+	}
+}
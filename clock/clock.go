@@ -0,0 +1,105 @@
+package clock
+
+import (
+	"time"
+)
+
+// Clock tells the time and waits for durations, as the functions of the
+// time package of the same names do.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the current
+	// time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+
+	// NewTimer returns a Timer that sends the current time on its
+	// channel after at least duration d.
+	NewTimer(d time.Duration) Timer
+
+	// NewTicker returns a Ticker that sends the time on its channel
+	// every d, dropping ticks for slow receivers.  It panics if d is not
+	// positive.
+	NewTicker(d time.Duration) Ticker
+
+	// Sleep pauses the current goroutine for at least the duration d.
+	Sleep(d time.Duration)
+
+	// AfterFunc waits for the duration to elapse and then calls f in its
+	// own goroutine.  The returned Timer can be used to cancel the call,
+	// and its channel is not used.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a single event, as a *time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+
+	// Stop prevents the Timer from firing, and returns whether it was
+	// active.
+	Stop() bool
+
+	// Reset changes the timer to expire after duration d, and returns
+	// whether it was active.
+	Reset(d time.Duration) bool
+}
+
+// Ticker delivers ticks at intervals, as a *time.Ticker.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// Real is the Clock of the time package.
+type Real struct{}
+
+// Now returns time.Now().
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// After returns time.After(d).
+func (Real) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// NewTimer returns time.NewTimer(d).
+func (Real) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+// NewTicker returns time.NewTicker(d).
+func (Real) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+// Sleep calls time.Sleep(d).
+func (Real) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// AfterFunc returns time.AfterFunc(d, f).
+func (Real) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
@@ -0,0 +1,37 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRealImplementsClock(t *testing.T) {
+	var _ Clock = Real{}
+}
+
+func TestRealTimer(t *testing.T) {
+	c := Real{}
+	before := c.Now()
+
+	timer := c.NewTimer(time.Millisecond)
+	if fired := <-timer.C(); fired.Before(before) {
+		t.Errorf("timer fired at %v, before %v", fired, before)
+	}
+	if timer.Stop() {
+		t.Error("Stop() of a fired timer should return false")
+	}
+
+	done := make(chan struct{})
+	c.AfterFunc(time.Millisecond, func() { close(done) })
+	<-done
+
+	ticker := c.NewTicker(time.Millisecond)
+	<-ticker.C()
+	ticker.Stop()
+
+	c.Sleep(time.Millisecond)
+	<-c.After(time.Millisecond)
+	if elapsed := c.Now().Sub(before); elapsed < 4*time.Millisecond {
+		t.Errorf("only %v elapsed", elapsed)
+	}
+}
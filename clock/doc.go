@@ -0,0 +1,34 @@
+// Package clock provides a Clock interface over the functions of the time
+// package, so that code depending on time can be tested without waiting.
+//
+// Example Usage
+//
+// Code under test takes a Clock, which is Real in production:
+//
+//    type Cache struct {
+//      Clock clock.Clock
+//      ...
+//    }
+//
+//    func (c *Cache) expired(entry *entry) bool {
+//      return c.Clock.Now().After(entry.expires)
+//    }
+//
+// Tests give it a Fake instead, whose time only moves when told to:
+//
+//    func TestExpiry(t *testing.T) {
+//      fake := clock.NewFake(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+//      cache := &Cache{Clock: fake}
+//      cache.Put("key", "value", time.Minute)
+//
+//      fake.Advance(59 * time.Second)
+//      assert.True(t, cache.Has("key"))
+//      fake.Advance(time.Second)
+//      assert.False(t, cache.Has("key"))
+//    }
+//
+// Timers, tickers and sleeps of a Fake fire when Advance or Set moves its
+// time past their deadline.  When they are started by another goroutine,
+// BlockUntil waits for that goroutine to start them before advancing the
+// time.
+package clock
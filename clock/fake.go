@@ -0,0 +1,213 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake is a Clock whose time only moves when Advance or Set is called.
+// Its timers, tickers and sleeps fire when the time moves past their
+// deadline, in the order of their deadlines.
+type Fake struct {
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter is a timer, ticker or sleep of a Fake.
+type fakeWaiter struct {
+	clock  *Fake
+	when   time.Time
+	period time.Duration
+	c      chan time.Time
+	f      func()
+}
+
+// NewFake returns a Fake set to the specified time.
+func NewFake(now time.Time) *Fake {
+	c := &Fake{now: now}
+	c.cond = sync.NewCond(&c.mutex)
+	return c
+}
+
+// Now returns the time of the clock.
+func (c *Fake) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the time of the clock once it has
+// been advanced by d.
+func (c *Fake) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer returns a Timer that fires once the clock has been advanced by
+// d.
+func (c *Fake) NewTimer(d time.Duration) Timer {
+	return fakeTimer{c.add(d, 0, nil)}
+}
+
+// NewTicker returns a Ticker that ticks each time the clock is advanced
+// past a multiple of d.
+func (c *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	return fakeTicker{c.add(d, d, nil)}
+}
+
+// Sleep blocks until the clock has been advanced by d.
+func (c *Fake) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// AfterFunc calls f in its own goroutine once the clock has been advanced
+// by d.
+func (c *Fake) AfterFunc(d time.Duration, f func()) Timer {
+	return fakeTimer{c.add(d, 0, f)}
+}
+
+// Advance moves the time of the clock forward by d, firing the timers,
+// tickers and sleeps whose deadline it reaches.
+func (c *Fake) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.moveTo(c.now.Add(d))
+}
+
+// Set sets the time of the clock.  Moving it forward fires the timers,
+// tickers and sleeps whose deadline it reaches, as Advance does, while
+// moving it backward fires nothing.
+func (c *Fake) Set(now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.moveTo(now)
+}
+
+// BlockUntil blocks until at least n timers, tickers and sleeps are
+// waiting for the clock, so that a test can advance the time once the
+// goroutines it started are waiting.
+func (c *Fake) BlockUntil(n int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+// moveTo sets the time of the clock, firing the waiters whose deadline it
+// reaches.  The time is set to the deadline of each waiter as it fires.
+// The mutex must be held.
+func (c *Fake) moveTo(now time.Time) {
+	if now.Before(c.now) {
+		c.now = now
+		return
+	}
+	for {
+		w := c.next()
+		if w == nil || w.when.After(now) {
+			break
+		}
+		c.now = w.when
+		w.fire(c.now)
+	}
+	c.now = now
+}
+
+// next returns the waiter with the earliest deadline, or nil if there is
+// none.  The mutex must be held.
+func (c *Fake) next() *fakeWaiter {
+	var next *fakeWaiter
+	for _, w := range c.waiters {
+		if next == nil || w.when.Before(next.when) {
+			next = w
+		}
+	}
+	return next
+}
+
+func (c *Fake) add(d, period time.Duration, f func()) *fakeWaiter {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	w := &fakeWaiter{clock: c, period: period, c: make(chan time.Time, 1), f: f}
+	w.schedule(d)
+	return w
+}
+
+// fire delivers the time to the waiter, and schedules its next tick if it
+// is a ticker.  The mutex must be held.
+func (w *fakeWaiter) fire(now time.Time) {
+	if w.period > 0 {
+		w.when = w.when.Add(w.period)
+	} else {
+		w.remove()
+	}
+	if w.f != nil {
+		go w.f()
+		return
+	}
+	// Ticks are dropped for slow receivers, as those of time.Ticker.
+	select {
+	case w.c <- now:
+	default:
+	}
+}
+
+// schedule registers the waiter to fire after d.  The mutex must be held.
+func (w *fakeWaiter) schedule(d time.Duration) bool {
+	active := w.remove()
+	w.when = w.clock.now.Add(d)
+	w.clock.waiters = append(w.clock.waiters, w)
+	w.clock.cond.Broadcast()
+	if d <= 0 && w.period == 0 {
+		w.fire(w.clock.now)
+	}
+	return active
+}
+
+// remove unregisters the waiter, and returns whether it was registered.
+// The mutex must be held.
+func (w *fakeWaiter) remove() bool {
+	for i, other := range w.clock.waiters {
+		if other == w {
+			w.clock.waiters = append(w.clock.waiters[:i], w.clock.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (w *fakeWaiter) C() <-chan time.Time {
+	return w.c
+}
+
+func (w *fakeWaiter) Reset(d time.Duration) bool {
+	w.clock.mutex.Lock()
+	defer w.clock.mutex.Unlock()
+	return w.schedule(d)
+}
+
+func (w *fakeWaiter) stop() bool {
+	w.clock.mutex.Lock()
+	defer w.clock.mutex.Unlock()
+	return w.remove()
+}
+
+type fakeTimer struct {
+	*fakeWaiter
+}
+
+func (t fakeTimer) Stop() bool {
+	return t.stop()
+}
+
+type fakeTicker struct {
+	*fakeWaiter
+}
+
+func (t fakeTicker) Stop() {
+	t.stop()
+}
@@ -0,0 +1,167 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func received(c <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-c:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestFakeNow(t *testing.T) {
+	c := NewFake(start)
+	if !c.Now().Equal(start) {
+		t.Errorf("Now() = %v, want %v", c.Now(), start)
+	}
+	c.Advance(time.Hour)
+	if want := start.Add(time.Hour); !c.Now().Equal(want) {
+		t.Errorf("Now() = %v, want %v", c.Now(), want)
+	}
+	c.Set(start)
+	if !c.Now().Equal(start) {
+		t.Errorf("Now() = %v, want %v", c.Now(), start)
+	}
+}
+
+func TestFakeTimer(t *testing.T) {
+	c := NewFake(start)
+	timer := c.NewTimer(time.Second)
+
+	c.Advance(999 * time.Millisecond)
+	if _, ok := received(timer.C()); ok {
+		t.Fatal("timer fired before its deadline")
+	}
+	c.Advance(time.Hour)
+	fired, ok := received(timer.C())
+	if !ok {
+		t.Fatal("timer did not fire")
+	}
+	if want := start.Add(time.Second); !fired.Equal(want) {
+		t.Errorf("timer fired at %v, want %v", fired, want)
+	}
+	if timer.Stop() {
+		t.Error("Stop() of a fired timer should return false")
+	}
+
+	if timer.Reset(time.Second) {
+		t.Error("Reset() of a fired timer should return false")
+	}
+	if !timer.Reset(2 * time.Second) {
+		t.Error("Reset() of an active timer should return true")
+	}
+	c.Advance(time.Second)
+	if _, ok := received(timer.C()); ok {
+		t.Fatal("timer fired at its previous deadline")
+	}
+	if !timer.Stop() {
+		t.Error("Stop() of an active timer should return true")
+	}
+	c.Advance(time.Hour)
+	if _, ok := received(timer.C()); ok {
+		t.Fatal("stopped timer fired")
+	}
+}
+
+func TestFakeTimerOrder(t *testing.T) {
+	c := NewFake(start)
+	var order []int
+	done := make(chan int, 3)
+	for _, i := range []int{3, 1, 2} {
+		i := i
+		c.AfterFunc(time.Duration(i)*time.Second, func() { done <- i })
+	}
+	c.Advance(2 * time.Second)
+	for len(order) < 2 {
+		order = append(order, <-done)
+	}
+	if order[0]+order[1] != 3 {
+		t.Errorf("fired %v, want 1 and 2", order)
+	}
+	c.Set(start.Add(time.Minute))
+	if i := <-done; i != 3 {
+		t.Errorf("fired %d, want 3", i)
+	}
+}
+
+func TestFakeTicker(t *testing.T) {
+	c := NewFake(start)
+	ticker := c.NewTicker(time.Second)
+
+	c.Advance(time.Second)
+	tick, ok := received(ticker.C())
+	if !ok || !tick.Equal(start.Add(time.Second)) {
+		t.Fatalf("got tick %v, %v", tick, ok)
+	}
+
+	// Ticks are dropped while the channel is full.
+	c.Advance(3 * time.Second)
+	tick, ok = received(ticker.C())
+	if !ok || !tick.Equal(start.Add(2*time.Second)) {
+		t.Fatalf("got tick %v, %v", tick, ok)
+	}
+	if _, ok := received(ticker.C()); ok {
+		t.Fatal("got more than one buffered tick")
+	}
+
+	ticker.Stop()
+	c.Advance(time.Hour)
+	if _, ok := received(ticker.C()); ok {
+		t.Fatal("stopped ticker ticked")
+	}
+}
+
+func TestFakeTickerPanicsOnNonPositiveInterval(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewTicker(0) should panic")
+		}
+	}()
+	NewFake(start).NewTicker(0)
+}
+
+func TestFakeSetBackwardFiresNothing(t *testing.T) {
+	c := NewFake(start)
+	timer := c.NewTimer(time.Second)
+	c.Set(start.Add(-time.Hour))
+	if _, ok := received(timer.C()); ok {
+		t.Fatal("timer fired when the time moved backward")
+	}
+	c.Advance(time.Hour)
+	if _, ok := received(timer.C()); ok {
+		t.Fatal("timer fired before its deadline")
+	}
+	c.Advance(time.Second)
+	if _, ok := received(timer.C()); !ok {
+		t.Fatal("timer did not fire")
+	}
+}
+
+func TestFakeSleepAndBlockUntil(t *testing.T) {
+	c := NewFake(start)
+	done := make(chan time.Time)
+	go func() {
+		c.Sleep(time.Minute)
+		done <- c.Now()
+	}()
+
+	c.BlockUntil(1)
+	c.Advance(time.Minute)
+	if woke := <-done; !woke.Equal(start.Add(time.Minute)) {
+		t.Errorf("woke at %v", woke)
+	}
+}
+
+func TestFakeZeroDuration(t *testing.T) {
+	c := NewFake(start)
+	if _, ok := received(c.After(0)); !ok {
+		t.Error("After(0) should fire immediately")
+	}
+}
@@ -0,0 +1,421 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestingT is an interface wrapper around *testing.T
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+// FakeServer is an HTTP server listening on the loopback interface that
+// responds to requests according to stubs, and records every request it
+// receives so that assertions can be made about them.
+//
+//    server := http.NewFakeServer()
+//    defer server.Close()
+//
+//    server.Stub("GET", "/users/{id}").RespondJSON(200, user)
+//    client := NewClient(server.URL)
+//    ...
+//    server.AssertExpectations(t)
+type FakeServer struct {
+	*httptest.Server
+
+	mutex    sync.Mutex
+	stubs    []*Stub
+	requests []*RecordedRequest
+	closed   chan struct{}
+}
+
+// NewFakeServer starts and returns a new FakeServer.  The caller should
+// call Close when finished, to shut it down.
+func NewFakeServer() *FakeServer {
+	s := &FakeServer{closed: make(chan struct{})}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Close shuts down the server, interrupting the stubs that are delaying
+// their response or waiting for the client to time out.
+func (s *FakeServer) Close() {
+	s.mutex.Lock()
+	select {
+	case <-s.closed:
+	default:
+		close(s.closed)
+	}
+	s.mutex.Unlock()
+	s.Server.Close()
+}
+
+// Stub adds a stub responding to requests with the method whose path
+// matches pattern.  Segments of the pattern enclosed in braces, such as
+// {id} in "/users/{id}", match any single path segment.  Stubs are
+// matched in the order they were added.
+func (s *FakeServer) Stub(method, pattern string) *Stub {
+	stub := &Stub{
+		server:         s,
+		Method:         method,
+		Pattern:        pattern,
+		status:         http.StatusOK,
+		responseHeader: make(http.Header),
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stubs = append(s.stubs, stub)
+	return stub
+}
+
+// Requests returns the requests received by the server so far.
+func (s *FakeServer) Requests() []*RecordedRequest {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]*RecordedRequest{}, s.requests...)
+}
+
+// AssertExpectations asserts that every stub was called, as many times as
+// set with Times if it was, and that every request matched a stub.
+func (s *FakeServer) AssertExpectations(t TestingT) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	failures := []string{}
+	for _, stub := range s.stubs {
+		switch {
+		case stub.times > 0 && stub.calls != stub.times:
+			failures = append(failures, fmt.Sprintf("%s was called %d time(s) instead of %d", stub, stub.calls, stub.times))
+		case stub.calls == 0:
+			failures = append(failures, fmt.Sprintf("%s was not called", stub))
+		}
+	}
+	for _, req := range s.requests {
+		if req.Stub == nil {
+			failures = append(failures, fmt.Sprintf("%s %s matched no stub", req.Method, req.URL))
+		}
+	}
+
+	if len(failures) > 0 {
+		return assert.Fail(t, fmt.Sprintf("The fake server did not receive the expected requests:\n%s", strings.Join(failures, "\n")))
+	}
+	return true
+}
+
+// serveHTTP records the request and responds with the first stub it
+// matches.
+func (s *FakeServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	recorded := &RecordedRequest{
+		Method: r.Method,
+		URL:    r.URL,
+		Header: r.Header,
+		Body:   body,
+	}
+
+	s.mutex.Lock()
+	s.requests = append(s.requests, recorded)
+	var stub *Stub
+	for _, candidate := range s.stubs {
+		if candidate.times > 0 && candidate.calls >= candidate.times {
+			continue
+		}
+		if params, ok := candidate.match(r, body); ok {
+			stub = candidate
+			stub.calls++
+			recorded.Stub = stub
+			recorded.Params = params
+			break
+		}
+	}
+	s.mutex.Unlock()
+
+	if stub == nil {
+		http.Error(w, fmt.Sprintf("testify: no stub matches %s %s", r.Method, r.URL), http.StatusNotImplemented)
+		return
+	}
+	stub.respond(w, r)
+}
+
+// RecordedRequest is a request received by a FakeServer.
+type RecordedRequest struct {
+	Method string
+	URL    *url.URL
+	Header http.Header
+	Body   []byte
+
+	// Params holds the path segments matched by the braced segments of
+	// the pattern of the stub, keyed by their name.
+	Params map[string]string
+
+	// Stub is the stub that responded to the request, or nil if the
+	// request matched no stub.
+	Stub *Stub
+}
+
+// failure is a way for a stub to fail rather than respond.
+type failure int
+
+const (
+	noFailure failure = iota
+	resetFailure
+	timeoutFailure
+)
+
+// Stub describes the requests that a FakeServer responds to, and how it
+// responds to them.  Stubs are created by FakeServer.Stub, and respond
+// with an empty 200 OK response unless told otherwise.
+type Stub struct {
+	Method  string
+	Pattern string
+
+	server   *FakeServer
+	matchers []requestMatcher
+
+	status         int
+	responseHeader http.Header
+	responseBody   []byte
+	delay          time.Duration
+	failure        failure
+
+	times int
+	calls int
+}
+
+// String returns a description of the requests matched by the stub.
+func (s *Stub) String() string {
+	description := s.Method + " " + s.Pattern
+	for _, m := range s.matchers {
+		description += " " + m.description
+	}
+	return description
+}
+
+func (s *Stub) lock() {
+	s.server.mutex.Lock()
+}
+
+func (s *Stub) unlock() {
+	s.server.mutex.Unlock()
+}
+
+// with adds a request matcher to the stub.
+func (s *Stub) with(matcher requestMatcher) *Stub {
+	s.lock()
+	defer s.unlock()
+	s.matchers = append(s.matchers, matcher)
+	return s
+}
+
+// WithHeader restricts the stub to requests having the header with the
+// value.
+func (s *Stub) WithHeader(name, value string) *Stub {
+	return s.with(headerMatcher(name, value))
+}
+
+// WithQuery restricts the stub to requests having the query parameter
+// with the value.
+func (s *Stub) WithQuery(name, value string) *Stub {
+	return s.with(requestMatcher{fmt.Sprintf("[query %s=%s]", name, value), func(r *http.Request, _ []byte) bool {
+		for _, v := range r.URL.Query()[name] {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	}})
+}
+
+// WithBody restricts the stub to requests whose body is body.
+func (s *Stub) WithBody(body string) *Stub {
+	return s.with(requestMatcher{fmt.Sprintf("[body %q]", body), func(_ *http.Request, actual []byte) bool {
+		return string(actual) == body
+	}})
+}
+
+// WithJSONBody restricts the stub to requests whose body is JSON
+// equivalent to expected.
+func (s *Stub) WithJSONBody(expected string) *Stub {
+	matcher, err := jsonBodyMatcher(expected)
+	if err != nil {
+		panic(fmt.Sprintf("testify: invalid JSON body %q for stub %s: %s", expected, s, err))
+	}
+	return s.with(matcher)
+}
+
+// Respond sets the status code and the body of the response.
+func (s *Stub) Respond(status int, body string) *Stub {
+	s.lock()
+	defer s.unlock()
+	s.status = status
+	s.responseBody = []byte(body)
+	return s
+}
+
+// RespondJSON sets the status code of the response, and its body to the
+// JSON encoding of v.
+func (s *Stub) RespondJSON(status int, v interface{}) *Stub {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("testify: cannot encode response of stub %s as JSON: %s", s, err))
+	}
+	s.ResponseHeader("Content-Type", "application/json")
+	return s.Respond(status, string(body))
+}
+
+// ResponseHeader adds a header to the response.
+func (s *Stub) ResponseHeader(name, value string) *Stub {
+	s.lock()
+	defer s.unlock()
+	s.responseHeader.Add(name, value)
+	return s
+}
+
+// After delays the response by d.
+func (s *Stub) After(d time.Duration) *Stub {
+	s.lock()
+	defer s.unlock()
+	s.delay = d
+	return s
+}
+
+// FailWithReset makes the stub reset the connection instead of
+// responding.
+func (s *Stub) FailWithReset() *Stub {
+	s.lock()
+	defer s.unlock()
+	s.failure = resetFailure
+	return s
+}
+
+// FailWithTimeout makes the stub never respond, so that the client times
+// out.  The request is abandoned when the client gives up or the server
+// is closed.
+func (s *Stub) FailWithTimeout() *Stub {
+	s.lock()
+	defer s.unlock()
+	s.failure = timeoutFailure
+	return s
+}
+
+// Times restricts the stub to respond to i requests, and makes
+// AssertExpectations check that it was called exactly i times.
+func (s *Stub) Times(i int) *Stub {
+	s.lock()
+	defer s.unlock()
+	s.times = i
+	return s
+}
+
+// Once restricts the stub to respond to a single request.
+func (s *Stub) Once() *Stub {
+	return s.Times(1)
+}
+
+// match returns whether the request matches the stub, and the path
+// parameters it matched if it does.
+func (s *Stub) match(r *http.Request, body []byte) (map[string]string, bool) {
+	if s.Method != r.Method {
+		return nil, false
+	}
+	params, ok := matchPath(s.Pattern, r.URL.Path)
+	if !ok {
+		return nil, false
+	}
+	for _, m := range s.matchers {
+		if !m.match(r, body) {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// matchPath matches path against pattern, whose braced segments match
+// any single segment of the path.
+func matchPath(pattern, path string) (map[string]string, bool) {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternSegments) != len(pathSegments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, segment := range patternSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params[segment[1:len(segment)-1]] = pathSegments[i]
+		} else if segment != pathSegments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// respond writes the response of the stub to w, or fails as configured.
+func (s *Stub) respond(w http.ResponseWriter, r *http.Request) {
+	s.lock()
+	status, header, body := s.status, s.responseHeader, s.responseBody
+	delay, fail := s.delay, s.failure
+	s.unlock()
+
+	if delay > 0 && !s.wait(r, time.After(delay)) {
+		return
+	}
+
+	switch fail {
+	case resetFailure:
+		resetConnection(w)
+		return
+	case timeoutFailure:
+		s.wait(r, nil)
+		return
+	}
+
+	for name, values := range header {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// wait blocks until done is ready, and returns false if the request was
+// abandoned by the client or the server was closed first.
+func (s *Stub) wait(r *http.Request, done <-chan time.Time) bool {
+	select {
+	case <-done:
+		return true
+	case <-r.Context().Done():
+	case <-s.server.closed:
+	}
+	return false
+}
+
+// resetConnection closes the connection underlying w so that the client
+// sees it reset.
+func resetConnection(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		panic("testify: cannot reset a connection that cannot be hijacked")
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		panic(fmt.Sprintf("testify: cannot hijack connection to reset it: %s", err))
+	}
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		tcpConn.SetLinger(0)
+	}
+	conn.Close()
+}
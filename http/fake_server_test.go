@@ -0,0 +1,130 @@
+package http
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// bufferT is a TestingT recording the messages of failed assertions.
+type bufferT struct {
+	messages []string
+}
+
+func (t *bufferT) Errorf(format string, args ...interface{}) {
+	t.messages = append(t.messages, fmt.Sprintf(format, args...))
+}
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	resp, err := client.Get(url)
+	if !assert.NoError(t, err) {
+		return 0, ""
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestFakeServer(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+
+	server.Stub("GET", "/users/{id}").
+		WithHeader("Accept", "application/json").
+		RespondJSON(http.StatusOK, map[string]string{"name": "Mat"})
+	server.Stub("GET", "/users/{id}").Respond(http.StatusNotAcceptable, "json only")
+	server.Stub("POST", "/users").
+		WithQuery("notify", "true").
+		WithJSONBody(`{"name": "Tyler"}`).
+		ResponseHeader("Location", "/users/2").
+		Respond(http.StatusCreated, "").
+		Once()
+
+	req, _ := http.NewRequest("GET", server.URL+"/users/1", nil)
+	req.Header.Set("Accept", "application/json")
+	resp, err := server.Client().Do(req)
+	if assert.NoError(t, err) {
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"name": "Mat"}`, string(body))
+	}
+
+	code, body := get(t, server.Client(), server.URL+"/users/1")
+	assert.Equal(t, http.StatusNotAcceptable, code)
+	assert.Equal(t, "json only", body)
+
+	resp, err = server.Client().Post(server.URL+"/users?notify=true", "application/json", strings.NewReader(`{ "name" : "Tyler" }`))
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "/users/2", resp.Header.Get("Location"))
+	}
+
+	requests := server.Requests()
+	if assert.Len(t, requests, 3) {
+		assert.Equal(t, map[string]string{"id": "1"}, requests[0].Params)
+		assert.Equal(t, "/users/{id}", requests[0].Stub.Pattern)
+		assert.Equal(t, `{ "name" : "Tyler" }`, string(requests[2].Body))
+	}
+	assert.True(t, server.AssertExpectations(t))
+}
+
+func TestFakeServerAssertExpectations(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+
+	server.Stub("GET", "/called").Times(2)
+	server.Stub("GET", "/never")
+
+	get(t, server.Client(), server.URL+"/called")
+	code, body := get(t, server.Client(), server.URL+"/unknown")
+	assert.Equal(t, http.StatusNotImplemented, code)
+	assert.Contains(t, body, "no stub matches GET /unknown")
+
+	mockT := new(bufferT)
+	assert.False(t, server.AssertExpectations(mockT))
+	if assert.Len(t, mockT.messages, 1) {
+		assert.Contains(t, mockT.messages[0], "GET /called was called 1 time(s) instead of 2")
+		assert.Contains(t, mockT.messages[0], "GET /never was not called")
+		assert.Contains(t, mockT.messages[0], "GET /unknown matched no stub")
+	}
+}
+
+func TestFakeServerFailures(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+
+	server.Stub("GET", "/slow").After(50 * time.Millisecond)
+	server.Stub("GET", "/reset").FailWithReset()
+	server.Stub("GET", "/timeout").FailWithTimeout()
+
+	start := time.Now()
+	code, _ := get(t, server.Client(), server.URL+"/slow")
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	_, err := server.Client().Get(server.URL + "/reset")
+	assert.Error(t, err)
+
+	client := &http.Client{Timeout: 50 * time.Millisecond}
+	_, err = client.Get(server.URL + "/timeout")
+	assert.Error(t, err)
+}
+
+func TestMatchPath(t *testing.T) {
+	params, ok := matchPath("/users/{id}/posts/{post}", "/users/1/posts/2")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"id": "1", "post": "2"}, params)
+
+	_, ok = matchPath("/users/{id}", "/users/1/posts")
+	assert.False(t, ok)
+	_, ok = matchPath("/users", "/groups")
+	assert.False(t, ok)
+}
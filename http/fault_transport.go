@@ -0,0 +1,357 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Fault is a kind of fault injected by a FaultTransport.
+type Fault string
+
+// The faults injected by a FaultTransport.
+const (
+	FaultLatency  Fault = "latency"
+	FaultError    Fault = "error"
+	FaultDrop     Fault = "drop"
+	FaultTruncate Fault = "truncate"
+	FaultThrottle Fault = "throttle"
+)
+
+var (
+	// ErrInjectedFailure is the error returned for requests failed by a
+	// FaultTransport.
+	ErrInjectedFailure = errors.New("testify: injected request failure")
+
+	// ErrInjectedDrop is the error returned when reading a response body
+	// whose connection was dropped by a FaultTransport.
+	ErrInjectedDrop = errors.New("testify: injected connection drop")
+)
+
+// FaultTransport is a http.RoundTripper wrapping another transport and
+// injecting faults into the requests matching its rules, so that retry
+// and timeout logic can be tested.  Random failures are drawn from a
+// seeded generator, so that tests are reproducible.
+//
+//    transport := http.NewFaultTransport(nil, 42)
+//    transport.Route("GET", "/users/{id}").Fail(100).Times(2)
+//    transport.Route("", "/files/{name}").Latency(50 * time.Millisecond).Throttle(1024)
+//
+//    client := &http.Client{Transport: transport}
+//    ...
+//    transport.AssertAttempts(t, "GET", "/users/1", 3)
+type FaultTransport struct {
+	// Transport is the transport the requests are forwarded to.
+	Transport http.RoundTripper
+
+	mutex sync.Mutex
+	rand  *rand.Rand
+	rules []*FaultRule
+	log   []FaultLogEntry
+}
+
+// NewFaultTransport returns a FaultTransport forwarding requests to
+// transport, or to http.DefaultTransport if it is nil, whose random
+// failures are drawn from a generator seeded with seed.
+func NewFaultTransport(transport http.RoundTripper, seed int64) *FaultTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &FaultTransport{
+		Transport: transport,
+		rand:      rand.New(rand.NewSource(seed)),
+	}
+}
+
+// FaultLogEntry records a request made through a FaultTransport and the
+// faults injected into it.
+type FaultLogEntry struct {
+	Method string
+	URL    string
+	Faults []Fault
+}
+
+// Route adds a rule injecting faults into the requests with the method
+// whose URL matches pattern, as with MatchRequest.  An empty method
+// matches any method, and an empty pattern matches any URL.  Only the
+// first rule matching a request applies.
+func (f *FaultTransport) Route(method, pattern string) *FaultRule {
+	rule := &FaultRule{transport: f, Method: method, Pattern: pattern}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.rules = append(f.rules, rule)
+	return rule
+}
+
+// Log returns the requests made so far, with the faults injected.
+func (f *FaultTransport) Log() []FaultLogEntry {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]FaultLogEntry{}, f.log...)
+}
+
+// closeBody closes the body of a request that is not forwarded, as a
+// RoundTripper must even when it fails.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+// RoundTrip forwards the request to the wrapped transport, injecting the
+// faults of the first rule it matches.
+func (f *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.mutex.Lock()
+	var rule FaultRule
+	var faults []Fault
+	for _, candidate := range f.rules {
+		if candidate.matches(req) {
+			if candidate.times == 0 || candidate.applied < candidate.times {
+				candidate.applied++
+				rule = *candidate
+				faults = rule.faults(f.rand)
+			}
+			break
+		}
+	}
+	f.log = append(f.log, FaultLogEntry{Method: req.Method, URL: req.URL.String(), Faults: faults})
+	f.mutex.Unlock()
+
+	if hasFault(faults, FaultLatency) {
+		select {
+		case <-time.After(rule.latency):
+		case <-req.Context().Done():
+			closeBody(req)
+			return nil, req.Context().Err()
+		}
+	}
+	if hasFault(faults, FaultError) {
+		closeBody(req)
+		return nil, ErrInjectedFailure
+	}
+
+	resp, err := f.Transport.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		return resp, err
+	}
+
+	body := &faultBody{ReadCloser: resp.Body, limit: -1}
+	switch {
+	case hasFault(faults, FaultDrop):
+		body.limit, body.err = rule.dropAfter, ErrInjectedDrop
+	case hasFault(faults, FaultTruncate):
+		body.limit, body.err = rule.truncateAfter, io.EOF
+	}
+	if hasFault(faults, FaultThrottle) {
+		body.bytesPerSecond = rule.bytesPerSecond
+	}
+	if body.limit < 0 && body.bytesPerSecond == 0 {
+		return resp, nil
+	}
+	resp.Body = body
+	return resp, nil
+}
+
+// AssertAttempts asserts that count requests with the method were made
+// to URLs matching pattern, as with Route.
+func (f *FaultTransport) AssertAttempts(t TestingT, method, pattern string, count int) bool {
+	rule := &FaultRule{Method: method, Pattern: pattern}
+	actual := 0
+	for _, entry := range f.Log() {
+		req, err := http.NewRequest(entry.Method, entry.URL, nil)
+		if err == nil && rule.matches(req) {
+			actual++
+		}
+	}
+	if actual != count {
+		return assert.Fail(t, fmt.Sprintf("Expected %d request(s) matching %s but got %d:\n%s", count, rule, actual, f.dump()))
+	}
+	return true
+}
+
+// AssertInjected asserts that the fault was injected into count requests.
+func (f *FaultTransport) AssertInjected(t TestingT, fault Fault, count int) bool {
+	actual := 0
+	for _, entry := range f.Log() {
+		if hasFault(entry.Faults, fault) {
+			actual++
+		}
+	}
+	if actual != count {
+		return assert.Fail(t, fmt.Sprintf("Expected fault %q to be injected %d time(s) but it was injected %d time(s):\n%s", fault, count, actual, f.dump()))
+	}
+	return true
+}
+
+// dump describes the requests made and the faults injected into them.
+func (f *FaultTransport) dump() string {
+	lines := []string{}
+	for _, entry := range f.Log() {
+		faults := "no fault"
+		if len(entry.Faults) > 0 {
+			names := make([]string, len(entry.Faults))
+			for i, fault := range entry.Faults {
+				names[i] = string(fault)
+			}
+			faults = strings.Join(names, ", ")
+		}
+		lines = append(lines, fmt.Sprintf("%s %s: %s", entry.Method, entry.URL, faults))
+	}
+	if len(lines) == 0 {
+		return "(no request)"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FaultRule describes the faults injected by a FaultTransport into the
+// requests of a route.  Rules are created by FaultTransport.Route, and
+// inject no fault unless told otherwise.
+type FaultRule struct {
+	Method  string
+	Pattern string
+
+	transport *FaultTransport
+
+	latency        time.Duration
+	failPercent    float64
+	dropAfter      int64
+	truncateAfter  int64
+	bytesPerSecond int
+	drop           bool
+	truncate       bool
+
+	times   int
+	applied int
+}
+
+// String returns a description of the requests matched by the rule.
+func (r *FaultRule) String() string {
+	description := strings.TrimSpace(r.Method + " " + r.Pattern)
+	if description == "" {
+		return "any request"
+	}
+	return description
+}
+
+// update changes the rule while holding the lock of its transport.
+func (r *FaultRule) update(change func()) *FaultRule {
+	r.transport.mutex.Lock()
+	defer r.transport.mutex.Unlock()
+	change()
+	return r
+}
+
+// Latency delays the requests by d before forwarding them.
+func (r *FaultRule) Latency(d time.Duration) *FaultRule {
+	return r.update(func() { r.latency = d })
+}
+
+// Fail fails percent percent of the requests with ErrInjectedFailure,
+// without forwarding them.
+func (r *FaultRule) Fail(percent float64) *FaultRule {
+	return r.update(func() { r.failPercent = percent })
+}
+
+// DropAfter drops the connection after n bytes of the response bodies
+// were read, which then fail with ErrInjectedDrop.
+func (r *FaultRule) DropAfter(n int64) *FaultRule {
+	return r.update(func() { r.drop, r.dropAfter = true, n })
+}
+
+// TruncateAfter ends the response bodies after n bytes, as if the server
+// sent a short body.
+func (r *FaultRule) TruncateAfter(n int64) *FaultRule {
+	return r.update(func() { r.truncate, r.truncateAfter = true, n })
+}
+
+// Throttle limits the bandwidth of the response bodies to bytesPerSecond.
+func (r *FaultRule) Throttle(bytesPerSecond int) *FaultRule {
+	return r.update(func() { r.bytesPerSecond = bytesPerSecond })
+}
+
+// Times restricts the rule to the first i requests it matches.  Later
+// requests matching it are forwarded without faults.
+func (r *FaultRule) Times(i int) *FaultRule {
+	return r.update(func() { r.times = i })
+}
+
+// matches returns whether the rule applies to the request.
+func (r *FaultRule) matches(req *http.Request) bool {
+	if r.Method != "" && r.Method != req.Method {
+		return false
+	}
+	return r.Pattern == "" || matchURL(r.Pattern, req.URL)
+}
+
+// faults returns the faults to inject into a request, drawing random
+// failures from rnd.  It must be called with the lock of the transport
+// held.
+func (r *FaultRule) faults(rnd *rand.Rand) []Fault {
+	faults := []Fault{}
+	if r.latency > 0 {
+		faults = append(faults, FaultLatency)
+	}
+	if r.failPercent > 0 && rnd.Float64()*100 < r.failPercent {
+		return append(faults, FaultError)
+	}
+	if r.drop {
+		faults = append(faults, FaultDrop)
+	} else if r.truncate {
+		faults = append(faults, FaultTruncate)
+	}
+	if r.bytesPerSecond > 0 {
+		faults = append(faults, FaultThrottle)
+	}
+	return faults
+}
+
+// hasFault returns whether fault is one of faults.
+func hasFault(faults []Fault, fault Fault) bool {
+	for _, f := range faults {
+		if f == fault {
+			return true
+		}
+	}
+	return false
+}
+
+// faultBody is a response body ending with err after limit bytes unless
+// limit is negative, and read at bytesPerSecond unless it is 0.
+type faultBody struct {
+	io.ReadCloser
+
+	limit          int64
+	err            error
+	bytesPerSecond int
+}
+
+func (b *faultBody) Read(p []byte) (int, error) {
+	if b.limit == 0 {
+		return 0, b.err
+	}
+	if b.limit > 0 && int64(len(p)) > b.limit {
+		p = p[:b.limit]
+	}
+	if b.bytesPerSecond > 0 {
+		// Read at most a tenth of a second worth of data at a time.
+		if chunk := b.bytesPerSecond/10 + 1; len(p) > chunk {
+			p = p[:chunk]
+		}
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	if b.limit > 0 {
+		b.limit -= int64(n)
+	}
+	if b.bytesPerSecond > 0 && n > 0 {
+		time.Sleep(time.Duration(n) * time.Second / time.Duration(b.bytesPerSecond))
+	}
+	return n, err
+}
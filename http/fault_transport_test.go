@@ -0,0 +1,144 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaultTransport(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	server.Stub("GET", "/users/{id}").Respond(http.StatusOK, "Mat")
+	server.Stub("GET", "/files/{name}").Respond(http.StatusOK, strings.Repeat("x", 100))
+
+	transport := NewFaultTransport(server.Client().Transport, 1)
+	transport.Route("GET", "/users/{id}").Fail(100).Times(2)
+	transport.Route("", "/files/big").DropAfter(10)
+	transport.Route("", "/files/short").TruncateAfter(10)
+	transport.Route("", "/files/slow").Latency(20 * time.Millisecond).Throttle(2000)
+	client := &http.Client{Transport: transport}
+
+	// A client retrying three times eventually succeeds.
+	var resp *http.Response
+	var err error
+	for i := 0; i < 3; i++ {
+		if resp, err = client.Get(server.URL + "/users/1"); err == nil {
+			break
+		}
+		assert.Contains(t, err.Error(), ErrInjectedFailure.Error())
+	}
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+
+	resp, err = client.Get(server.URL + "/files/big")
+	if assert.NoError(t, err) {
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, ErrInjectedDrop, err)
+		assert.Len(t, body, 10)
+	}
+
+	resp, err = client.Get(server.URL + "/files/short")
+	if assert.NoError(t, err) {
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NoError(t, err)
+		assert.Len(t, body, 10)
+	}
+
+	start := time.Now()
+	resp, err = client.Get(server.URL + "/files/slow")
+	if assert.NoError(t, err) {
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Len(t, body, 100)
+		assert.True(t, time.Since(start) >= 70*time.Millisecond, "took %s", time.Since(start))
+	}
+
+	assert.True(t, transport.AssertAttempts(t, "GET", "/users/{id}", 3))
+	assert.True(t, transport.AssertInjected(t, FaultError, 2))
+	assert.True(t, transport.AssertInjected(t, FaultThrottle, 1))
+	log := transport.Log()
+	if assert.Len(t, log, 6) {
+		assert.Empty(t, log[2].Faults)
+		assert.Equal(t, []Fault{FaultDrop}, log[3].Faults)
+		assert.Equal(t, []Fault{FaultLatency, FaultThrottle}, log[5].Faults)
+	}
+
+	mockT := new(bufferT)
+	assert.False(t, transport.AssertAttempts(mockT, "", "/users/{id}", 1))
+	assert.False(t, transport.AssertInjected(mockT, FaultLatency, 2))
+	if assert.Len(t, mockT.messages, 2) {
+		assert.Contains(t, mockT.messages[0], "Expected 1 request(s) matching /users/{id} but got 3")
+		assert.Contains(t, mockT.messages[0], "/users/1: error\n")
+		assert.Contains(t, mockT.messages[1], `Expected fault "latency" to be injected 2 time(s) but it was injected 1 time(s)`)
+		assert.Contains(t, mockT.messages[1], "/files/slow: latency, throttle")
+	}
+}
+
+func TestFaultTransportFailRate(t *testing.T) {
+	failures := func(seed int64) []bool {
+		rt := new(TestRoundTripper)
+		rt.On("RoundTrip", MatchRequest("", "/")).Return(Respond(http.StatusOK, "", nil))
+		transport := NewFaultTransport(rt, seed)
+		transport.Route("", "").Fail(30)
+		results := []bool{}
+		for i := 0; i < 100; i++ {
+			_, err := transport.RoundTrip(mustRequest("GET", "http://example.com/"))
+			results = append(results, err != nil)
+		}
+		return results
+	}
+
+	first := failures(7)
+	assert.Equal(t, first, failures(7), "the same seed should fail the same requests")
+	count := 0
+	for _, failed := range first {
+		if failed {
+			count++
+		}
+	}
+	assert.InDelta(t, 30, count, 15)
+}
+
+// closeRecorder is a request body recording whether it was closed.
+type closeRecorder struct {
+	*strings.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestFaultTransportClosesRequestBody(t *testing.T) {
+	transport := NewFaultTransport(new(TestRoundTripper), 1)
+	transport.Route("", "/fail").Fail(100)
+	transport.Route("", "/slow").Latency(time.Minute)
+
+	body := &closeRecorder{Reader: strings.NewReader("payload")}
+	req, err := http.NewRequest("POST", "http://example.com/fail", body)
+	if assert.NoError(t, err) {
+		_, err = transport.RoundTrip(req)
+		assert.Equal(t, ErrInjectedFailure, err)
+		assert.True(t, body.closed)
+	}
+
+	body = &closeRecorder{Reader: strings.NewReader("payload")}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err = http.NewRequest("POST", "http://example.com/slow", body)
+	if assert.NoError(t, err) {
+		_, err = transport.RoundTrip(req.WithContext(ctx))
+		assert.Equal(t, context.Canceled, err)
+		assert.True(t, body.closed)
+	}
+}
@@ -0,0 +1,206 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RecordingTransport is a http.RoundTripper recording the requests it
+// forwards to another transport, so that assertions can be made about
+// what a client sent.
+//
+//    transport := http.NewRecordingTransport(nil)
+//    client := NewClient(&http.Client{Transport: transport})
+//    ...
+//    transport.AssertRequestCount(t, 2)
+//    transport.AssertRequested(t, "POST", "/users")
+//    transport.AssertJSONBody(t, "POST", "/users", `{"name": "Mat"}`)
+type RecordingTransport struct {
+	// Transport is the transport the requests are forwarded to.
+	Transport http.RoundTripper
+
+	mutex    sync.Mutex
+	requests []*RecordedRequest
+}
+
+// NewRecordingTransport returns a RecordingTransport forwarding requests
+// to transport, or to http.DefaultTransport if it is nil.
+func NewRecordingTransport(transport http.RoundTripper) *RecordingTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &RecordingTransport{Transport: transport}
+}
+
+// NewRecordingHandlerTransport returns a RecordingTransport serving the
+// requests with handler, in process, instead of sending them.
+func NewRecordingHandlerTransport(handler http.Handler) *RecordingTransport {
+	return NewRecordingTransport(handlerTransport{handler})
+}
+
+// handlerTransport is a http.RoundTripper serving requests with a handler.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Handlers expect the body of server requests to be non-nil.
+	served := *req
+	if served.Body == nil {
+		served.Body = http.NoBody
+	}
+	served.RequestURI = req.URL.RequestURI()
+
+	w := httptest.NewRecorder()
+	t.handler.ServeHTTP(w, &served)
+	resp := w.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+// RoundTrip records the request and forwards a copy of it, whose body is
+// restored after being recorded for the wrapped transport to send.  The
+// request is not recorded if its body cannot be read.
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	forwarded := req
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("recording the body of %s %s: %s", req.Method, req.URL, err)
+		}
+		forwarded = req.Clone(req.Context())
+		forwarded.Body = ioutil.NopCloser(bytes.NewReader(body))
+		forwarded.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	t.mutex.Lock()
+	t.requests = append(t.requests, &RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL,
+		Header: req.Header.Clone(),
+		Body:   body,
+	})
+	t.mutex.Unlock()
+
+	return t.Transport.RoundTrip(forwarded)
+}
+
+// Requests returns the requests recorded so far.
+func (t *RecordingTransport) Requests() []*RecordedRequest {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]*RecordedRequest{}, t.requests...)
+}
+
+// matching returns the requests with the method whose URL matches
+// pattern, as with MatchRequest.
+func (t *RecordingTransport) matching(method, pattern string) []*RecordedRequest {
+	matched := []*RecordedRequest{}
+	for _, req := range t.Requests() {
+		if (method == "" || req.Method == method) && matchURL(pattern, req.URL) {
+			matched = append(matched, req)
+		}
+	}
+	return matched
+}
+
+// fail reports a failed assertion along with a table of the requests
+// recorded.
+func (t *RecordingTransport) fail(tt TestingT, failureMessage string) bool {
+	return assert.Fail(tt, fmt.Sprintf("%s\n\nRequests recorded:\n%s", failureMessage, t.dump()))
+}
+
+// dump returns a table of the requests recorded.
+func (t *RecordingTransport) dump() string {
+	requests := t.Requests()
+	if len(requests) == 0 {
+		return "(no request)"
+	}
+
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tMETHOD\tURL\tHEADERS\tBODY")
+	for i, req := range requests {
+		headers := []string{}
+		for name := range req.Header {
+			headers = append(headers, name+": "+strings.Join(req.Header[name], ", "))
+		}
+		sort.Strings(headers)
+		body := string(req.Body)
+		if len(body) > 60 {
+			body = body[:57] + "..."
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%q\n", i, req.Method, req.URL, strings.Join(headers, "; "), body)
+	}
+	w.Flush()
+	return b.String()
+}
+
+// AssertRequestCount asserts that count requests were recorded.
+func (t *RecordingTransport) AssertRequestCount(tt TestingT, count int) bool {
+	if actual := len(t.Requests()); actual != count {
+		return t.fail(tt, fmt.Sprintf("Expected %d request(s) but got %d", count, actual))
+	}
+	return true
+}
+
+// AssertRequested asserts that a request with the method was made to a
+// URL matching pattern, as with MatchRequest.  An empty method matches
+// any method.
+func (t *RecordingTransport) AssertRequested(tt TestingT, method, pattern string) bool {
+	if len(t.matching(method, pattern)) == 0 {
+		return t.fail(tt, fmt.Sprintf("Expected a request matching %s", strings.TrimSpace(method+" "+pattern)))
+	}
+	return true
+}
+
+// AssertHeader asserts that a request with the method was made to a URL
+// matching pattern with the header having the value.
+func (t *RecordingTransport) AssertHeader(tt TestingT, method, pattern, name, value string) bool {
+	matched := t.matching(method, pattern)
+	if len(matched) == 0 {
+		return t.fail(tt, fmt.Sprintf("Expected a request matching %s with header %s: %s, but none matches", strings.TrimSpace(method+" "+pattern), name, value))
+	}
+	matcher := headerMatcher(name, value)
+	for _, req := range matched {
+		if matcher.match(&http.Request{Header: req.Header}, req.Body) {
+			return true
+		}
+	}
+	return t.fail(tt, fmt.Sprintf("Expected a request matching %s with header %s: %s", strings.TrimSpace(method+" "+pattern), name, value))
+}
+
+// AssertJSONBody asserts that a request with the method was made to a URL
+// matching pattern with a body JSON equivalent to expected.
+func (t *RecordingTransport) AssertJSONBody(tt TestingT, method, pattern, expected string) bool {
+	matcher, err := jsonBodyMatcher(expected)
+	if err != nil {
+		return assert.Fail(tt, fmt.Sprintf("Expected value ('%s') is not valid json.\nJSON parsing error: '%s'", expected, err))
+	}
+
+	matched := t.matching(method, pattern)
+	if len(matched) == 0 {
+		return t.fail(tt, fmt.Sprintf("Expected a request matching %s with body %s, but none matches", strings.TrimSpace(method+" "+pattern), expected))
+	}
+	for _, req := range matched {
+		if matcher.match(nil, req.Body) {
+			return true
+		}
+	}
+	return t.fail(tt, fmt.Sprintf("Expected a request matching %s with body %s", strings.TrimSpace(method+" "+pattern), expected))
+}
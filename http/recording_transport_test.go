@@ -0,0 +1,112 @@
+package http
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordingTransport(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}
+	transport := NewRecordingHandlerTransport(http.HandlerFunc(handler))
+	client := &http.Client{Transport: transport}
+
+	req, _ := http.NewRequest("GET", "http://example.com/users/1", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	_, err := client.Do(req)
+	assert.NoError(t, err)
+
+	// The body is restored for the handler to read.
+	resp, err := client.Post("http://example.com/users", "application/json", strings.NewReader(`{"name": "Mat"}`))
+	if assert.NoError(t, err) {
+		body, _ := ioutil.ReadAll(resp.Body)
+		assert.Equal(t, `{"name": "Mat"}`, string(body))
+	}
+
+	assert.True(t, transport.AssertRequestCount(t, 2))
+	assert.True(t, transport.AssertRequested(t, "GET", "/users/{id}"))
+	assert.True(t, transport.AssertRequested(t, "", "http://example.com/users"))
+	assert.True(t, transport.AssertHeader(t, "GET", "/users/{id}", "Authorization", "Bearer token"))
+	assert.True(t, transport.AssertJSONBody(t, "POST", "/users", `{"name":"Mat"}`))
+
+	requests := transport.Requests()
+	if assert.Len(t, requests, 2) {
+		assert.Equal(t, "POST", requests[1].Method)
+		assert.Equal(t, "application/json", requests[1].Header.Get("Content-Type"))
+	}
+
+	mockT := new(bufferT)
+	assert.False(t, transport.AssertRequestCount(mockT, 3))
+	assert.False(t, transport.AssertRequested(mockT, "DELETE", "/users/{id}"))
+	assert.False(t, transport.AssertHeader(mockT, "POST", "/users", "Authorization", "Bearer token"))
+	assert.False(t, transport.AssertJSONBody(mockT, "POST", "/users", `{"name": "Tyler"}`))
+	assert.False(t, transport.AssertJSONBody(mockT, "POST", "/users", `{not json`))
+	if assert.Len(t, mockT.messages, 5) {
+		assert.Contains(t, mockT.messages[0], "Expected 3 request(s) but got 2")
+		assert.Contains(t, mockT.messages[0], "#  METHOD  URL")
+		assert.Contains(t, mockT.messages[0], `1  POST    http://example.com/users`)
+		assert.Contains(t, mockT.messages[0], `"{\"name\": \"Mat\"}"`)
+		assert.Contains(t, mockT.messages[1], "Expected a request matching DELETE /users/{id}")
+		assert.Contains(t, mockT.messages[2], "Expected a request matching POST /users with header Authorization: Bearer token")
+		assert.Contains(t, mockT.messages[3], `Expected a request matching POST /users with body {"name": "Tyler"}`)
+		assert.Contains(t, mockT.messages[4], "is not valid json")
+	}
+}
+
+func TestRecordingTransportForwards(t *testing.T) {
+	server := NewFakeServer()
+	defer server.Close()
+	server.Stub("PUT", "/users/1").WithBody("Mat").Respond(http.StatusNoContent, "")
+
+	transport := NewRecordingTransport(server.Client().Transport)
+	req, _ := http.NewRequest("PUT", server.URL+"/users/1", strings.NewReader("Mat"))
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+	transport.AssertRequested(t, "PUT", "/users/1")
+	server.AssertExpectations(t)
+}
+
+func TestRecordingTransportKeepsRequest(t *testing.T) {
+	transport := NewRecordingHandlerTransport(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}))
+
+	body := &closeRecorder{Reader: strings.NewReader("Mat")}
+	req, _ := http.NewRequest("PUT", "http://example.com/users/1", body)
+	resp, err := transport.RoundTrip(req)
+	if assert.NoError(t, err) {
+		forwarded, _ := ioutil.ReadAll(resp.Body)
+		assert.Equal(t, "Mat", string(forwarded))
+		assert.True(t, resp.Request != req, "a copy of the request is forwarded")
+	}
+	assert.True(t, req.Body == body, "the body of the request is not replaced")
+	assert.Nil(t, req.GetBody)
+	assert.True(t, body.closed)
+	assert.True(t, transport.AssertRequestCount(t, 1))
+}
+
+// failingReader is a request body failing to be read.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestRecordingTransportBodyError(t *testing.T) {
+	transport := NewRecordingTransport(new(TestRoundTripper))
+	req, _ := http.NewRequest("POST", "http://example.com/users", failingReader{})
+	_, err := transport.RoundTrip(req)
+	assert.EqualError(t, err, "recording the body of POST http://example.com/users: connection reset")
+	assert.Empty(t, transport.Requests())
+}
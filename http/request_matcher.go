@@ -0,0 +1,161 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// RequestMatcher is a mock.ArgumentMatcher matching *http.Request
+// arguments, such as the request passed to TestRoundTripper.RoundTrip.
+// It is created by MatchRequest and refined by its With methods.
+type RequestMatcher struct {
+	method   string
+	pattern  string
+	matchers []requestMatcher
+}
+
+var _ mock.ArgumentMatcher = (*RequestMatcher)(nil)
+
+// MatchRequest returns a RequestMatcher matching requests with the method
+// whose URL matches pattern.  The pattern is either a path or an absolute
+// URL, in which case the scheme and host of the request must match too.
+// Segments of the path enclosed in braces, such as {id} in "/users/{id}",
+// match any single path segment.  An empty method matches any method.
+func MatchRequest(method, pattern string) *RequestMatcher {
+	return &RequestMatcher{method: method, pattern: pattern}
+}
+
+// String describes the requests matched.
+func (m *RequestMatcher) String() string {
+	description := strings.TrimSpace(m.method + " " + m.pattern)
+	for _, matcher := range m.matchers {
+		description += " " + matcher.description
+	}
+	return description
+}
+
+// WithHeader restricts the matched requests to those having the header
+// with the value.
+func (m *RequestMatcher) WithHeader(name, value string) *RequestMatcher {
+	m.matchers = append(m.matchers, headerMatcher(name, value))
+	return m
+}
+
+// WithJSONBody restricts the matched requests to those whose body is JSON
+// equivalent to expected.
+func (m *RequestMatcher) WithJSONBody(expected string) *RequestMatcher {
+	matcher, err := jsonBodyMatcher(expected)
+	if err != nil {
+		panic(fmt.Sprintf("testify: invalid JSON body %q for request matcher %s: %s", expected, m, err))
+	}
+	m.matchers = append(m.matchers, matcher)
+	return m
+}
+
+// Matches returns whether argument is a *http.Request matched by m.  The
+// body of the request is left unread, so that it can be read again.
+func (m *RequestMatcher) Matches(argument interface{}) bool {
+	req, ok := argument.(*http.Request)
+	if !ok || req == nil {
+		return false
+	}
+	if m.method != "" && m.method != req.Method {
+		return false
+	}
+	if !matchURL(m.pattern, req.URL) {
+		return false
+	}
+	if len(m.matchers) == 0 {
+		return true
+	}
+
+	body := requestBody(req)
+	for _, matcher := range m.matchers {
+		if !matcher.match(req, body) {
+			return false
+		}
+	}
+	return true
+}
+
+// requestBody returns the body of req without consuming it, reading it
+// from a copy returned by GetBody when possible.  Otherwise the body is
+// read and replaced by a copy, and GetBody is set to return more copies;
+// TestRoundTripper.RoundTrip matches a clone of the request so that this
+// does not change the request it is given.
+func requestBody(req *http.Request) []byte {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			defer rc.Close()
+			body, _ := ioutil.ReadAll(rc)
+			return body
+		}
+	}
+	body, _ := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body
+}
+
+// matchURL matches u against pattern, which is either a path pattern as
+// accepted by matchPath, or an absolute URL with such a path.
+func matchURL(pattern string, u *url.URL) bool {
+	p, err := url.Parse(pattern)
+	if err != nil {
+		return false
+	}
+	if p.Scheme != "" && p.Scheme != u.Scheme {
+		return false
+	}
+	if p.Host != "" && p.Host != u.Host {
+		return false
+	}
+	_, ok := matchPath(p.Path, u.Path)
+	return ok
+}
+
+// requestMatcher matches a request on a condition it describes.
+type requestMatcher struct {
+	description string
+	match       func(r *http.Request, body []byte) bool
+}
+
+// headerMatcher matches requests having the header with the value.
+func headerMatcher(name, value string) requestMatcher {
+	return requestMatcher{fmt.Sprintf("[header %s: %s]", name, value), func(r *http.Request, _ []byte) bool {
+		for _, v := range r.Header[http.CanonicalHeaderKey(name)] {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	}}
+}
+
+// jsonBodyMatcher matches requests whose body is JSON equivalent to
+// expected, as with assert.JSONEq, so that numbers are compared exactly.
+func jsonBodyMatcher(expected string) (requestMatcher, error) {
+	var expectedJSON interface{}
+	if err := json.Unmarshal([]byte(expected), &expectedJSON); err != nil {
+		return requestMatcher{}, err
+	}
+	return requestMatcher{fmt.Sprintf("[JSON body %s]", expected), func(_ *http.Request, actual []byte) bool {
+		// The Collector keeps the failure of the assertion to itself.
+		return assert.JSONEq(assert.NewCollector(nil), expected, string(actual))
+	}}, nil
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Responder builds the response to a request.  A Responder can be
+// returned from a TestRoundTripper expectation instead of a
+// *http.Response, so that a fresh response is built for every request.
+type Responder func(req *http.Request) (*http.Response, error)
+
+// Respond returns a Responder responding with the status code, body and
+// headers.  The header may be nil.
+func Respond(status int, body string, header http.Header) Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		return NewResponse(req, status, body, header), nil
+	}
+}
+
+// RespondError returns a Responder failing with err, as a transport
+// does when it cannot get a response.
+func RespondError(err error) Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		return nil, err
+	}
+}
+
+// NewResponse returns a complete HTTP/1.1 response to req with the
+// status code, body and headers, whose body is a TrackedBody.
+func NewResponse(req *http.Request, status int, body string, header http.Header) *http.Response {
+	respHeader := make(http.Header)
+	for name, values := range header {
+		respHeader[name] = append([]string{}, values...)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        respHeader,
+		Body:          NewTrackedBody(body),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// TrackedBody is a response body recording whether it was closed.
+type TrackedBody struct {
+	io.ReadCloser
+
+	mutex  sync.Mutex
+	closed bool
+}
+
+// NewTrackedBody returns a TrackedBody reading s.
+func NewTrackedBody(s string) *TrackedBody {
+	return &TrackedBody{ReadCloser: ioutil.NopCloser(strings.NewReader(s))}
+}
+
+// Close closes the body and records that it was closed.
+func (b *TrackedBody) Close() error {
+	b.mutex.Lock()
+	b.closed = true
+	b.mutex.Unlock()
+	return b.ReadCloser.Close()
+}
+
+// Closed returns whether the body was closed.
+func (b *TrackedBody) Closed() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.closed
+}
@@ -0,0 +1,361 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Chunk is the data passed to a single call to ResponseRecorder.Write.
+type Chunk struct {
+	// Data is the data written.
+	Data []byte
+
+	// Elapsed is the time elapsed between the creation of the recorder
+	// and the write.
+	Elapsed time.Duration
+
+	// Flushed is whether the recorder was flushed after the write and
+	// before the next one.
+	Flushed bool
+}
+
+// ResponseRecorder is a http.ResponseWriter recording how a handler
+// responds, with the semantics of a real server: the headers are sent
+// when WriteHeader is called or the first write happens, and the status
+// code cannot be changed afterwards.  It also implements http.Flusher,
+// http.Hijacker, http.Pusher and io.ReaderFrom, and records every write
+// along with its timing, so that streaming handlers can be tested.
+//
+//    rr := http.NewResponseRecorder()
+//    handler.ServeHTTP(rr, req)
+//
+//    rr.AssertStatus(t, 200)
+//    rr.AssertHeader(t, "Content-Type", "text/event-stream")
+//    rr.AssertChunks(t, "data: 1\n\n", "data: 2\n\n")
+//    rr.AssertFlushed(t)
+//
+// The recorder can be inspected while the handler is still running.
+type ResponseRecorder struct {
+	mutex sync.Mutex
+
+	start       time.Time
+	header      http.Header
+	sentHeader  http.Header
+	status      int
+	body        bytes.Buffer
+	chunks      []Chunk
+	flushes     int
+	pushed      []string
+	hijacked    bool
+	clientConn  net.Conn
+	wroteHeader bool
+}
+
+// NewResponseRecorder returns a new ResponseRecorder.
+func NewResponseRecorder() *ResponseRecorder {
+	return &ResponseRecorder{
+		start:  time.Now(),
+		header: make(http.Header),
+	}
+}
+
+// Header returns the headers that will be sent by WriteHeader.  Once the
+// headers are sent, it returns a copy of them instead, whose changes have
+// no effect except for trailers.
+func (rr *ResponseRecorder) Header() http.Header {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	if rr.wroteHeader {
+		// Keep the copy, whose trailers are returned by Trailers.
+		rr.header = rr.header.Clone()
+	}
+	return rr.header
+}
+
+// WriteHeader sends the headers with the status code.  Later calls are
+// ignored, as they are by a real server.
+func (rr *ResponseRecorder) WriteHeader(status int) {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	rr.writeHeader(status)
+}
+
+// writeHeader sends the headers with the status code if they have not
+// been sent yet.  It must be called with the mutex held.
+func (rr *ResponseRecorder) writeHeader(status int) {
+	if rr.wroteHeader || rr.hijacked {
+		return
+	}
+	rr.wroteHeader = true
+	rr.status = status
+	rr.sentHeader = make(http.Header, len(rr.header))
+	for name, values := range rr.header {
+		rr.sentHeader[name] = append([]string{}, values...)
+	}
+}
+
+// Write records the data as a new chunk of the body, sending the headers
+// with a 200 OK status code first if they have not been sent yet.  As a
+// real server does, the content type is then detected from data unless
+// it was set.
+func (rr *ResponseRecorder) Write(data []byte) (int, error) {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+
+	if rr.hijacked {
+		return 0, http.ErrHijacked
+	}
+	if !rr.wroteHeader {
+		if _, ok := rr.header["Content-Type"]; !ok && rr.header.Get("Transfer-Encoding") == "" {
+			rr.header.Set("Content-Type", http.DetectContentType(data))
+		}
+		rr.writeHeader(http.StatusOK)
+	}
+	rr.body.Write(data)
+	rr.chunks = append(rr.chunks, Chunk{
+		Data:    append([]byte{}, data...),
+		Elapsed: time.Since(rr.start),
+	})
+	return len(data), nil
+}
+
+// ReadFrom writes the data read from r until EOF, recording a chunk for
+// each read.
+func (rr *ResponseRecorder) ReadFrom(r io.Reader) (int64, error) {
+	// Hide the ReadFrom method of the recorder from io.Copy.
+	return io.Copy(struct{ io.Writer }{rr}, r)
+}
+
+// Flush sends the headers if they have not been sent yet, and marks the
+// last chunk as flushed.
+func (rr *ResponseRecorder) Flush() {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+
+	if rr.hijacked {
+		return
+	}
+	rr.writeHeader(http.StatusOK)
+	rr.flushes++
+	if len(rr.chunks) > 0 {
+		rr.chunks[len(rr.chunks)-1].Flushed = true
+	}
+}
+
+// Hijack takes over the connection from the recorder.  The connection
+// returned is one end of an in-memory pipe whose other end is returned
+// by Conn.  Writes to the recorder fail once it has been hijacked.
+func (rr *ResponseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+
+	if rr.hijacked {
+		return nil, nil, http.ErrHijacked
+	}
+	rr.hijacked = true
+	server, client := net.Pipe()
+	rr.clientConn = client
+	return server, bufio.NewReadWriter(bufio.NewReader(server), bufio.NewWriter(server)), nil
+}
+
+// Push records that target was pushed.  Only absolute paths can be
+// pushed, as with a real HTTP/2 server.
+func (rr *ResponseRecorder) Push(target string, opts *http.PushOptions) error {
+	if !strings.HasPrefix(target, "/") {
+		return fmt.Errorf("testify: cannot push %q, which is not an absolute path", target)
+	}
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	rr.pushed = append(rr.pushed, target)
+	return nil
+}
+
+// Status returns the status code sent, or 0 if the headers were not sent.
+func (rr *ResponseRecorder) Status() int {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	return rr.status
+}
+
+// SentHeader returns the headers as they were when they were sent, or nil
+// if they were not sent.
+func (rr *ResponseRecorder) SentHeader() http.Header {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	return rr.sentHeader
+}
+
+// Trailers returns the trailers, which are the headers declared in the
+// Trailer header before the headers were sent, and those set afterwards
+// with the http.TrailerPrefix prefix.
+func (rr *ResponseRecorder) Trailers() http.Header {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+
+	trailers := make(http.Header)
+	if rr.sentHeader == nil {
+		return trailers
+	}
+	for _, declared := range rr.sentHeader["Trailer"] {
+		for _, name := range strings.Split(declared, ",") {
+			name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+			if values, ok := rr.header[name]; ok {
+				trailers[name] = append([]string{}, values...)
+			}
+		}
+	}
+	for name, values := range rr.header {
+		if strings.HasPrefix(name, http.TrailerPrefix) {
+			trailers[http.CanonicalHeaderKey(strings.TrimPrefix(name, http.TrailerPrefix))] = append([]string{}, values...)
+		}
+	}
+	return trailers
+}
+
+// Body returns the data written so far.
+func (rr *ResponseRecorder) Body() []byte {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	return append([]byte{}, rr.body.Bytes()...)
+}
+
+// Chunks returns the chunks written so far.
+func (rr *ResponseRecorder) Chunks() []Chunk {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	return append([]Chunk{}, rr.chunks...)
+}
+
+// Flushes returns the number of times the recorder was flushed.
+func (rr *ResponseRecorder) Flushes() int {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	return rr.flushes
+}
+
+// Pushed returns the targets pushed, in order.
+func (rr *ResponseRecorder) Pushed() []string {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	return append([]string{}, rr.pushed...)
+}
+
+// Hijacked returns whether the connection was hijacked.
+func (rr *ResponseRecorder) Hijacked() bool {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	return rr.hijacked
+}
+
+// Conn returns the client end of the connection once it has been
+// hijacked, or nil before.
+func (rr *ResponseRecorder) Conn() net.Conn {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+	return rr.clientConn
+}
+
+// fail reports a failed assertion about the response, along with the
+// status code, headers and chunks recorded.
+func (rr *ResponseRecorder) fail(t TestingT, failureMessage string) bool {
+	return assert.Fail(t, fmt.Sprintf("%s\n\nResponse recorded:\n%s", failureMessage, rr.dump()))
+}
+
+// dump describes the response recorded.
+func (rr *ResponseRecorder) dump() string {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+
+	var b bytes.Buffer
+	if !rr.wroteHeader {
+		b.WriteString("(headers not sent)\n")
+	} else {
+		fmt.Fprintf(&b, "%d %s\n", rr.status, http.StatusText(rr.status))
+		rr.sentHeader.Write(&b)
+	}
+	for i, chunk := range rr.chunks {
+		flushed := ""
+		if chunk.Flushed {
+			flushed = ", flushed"
+		}
+		fmt.Fprintf(&b, "chunk %d at %s%s: %q\n", i, chunk.Elapsed, flushed, chunk.Data)
+	}
+	if rr.hijacked {
+		b.WriteString("(hijacked)\n")
+	}
+	return b.String()
+}
+
+// AssertStatus asserts that the headers were sent with the status code.
+func (rr *ResponseRecorder) AssertStatus(t TestingT, status int) bool {
+	if actual := rr.Status(); actual != status {
+		return rr.fail(t, fmt.Sprintf("Expected status code %d but got %d", status, actual))
+	}
+	return true
+}
+
+// AssertHeader asserts that the headers were sent with the header having
+// the value.  Headers set after the headers were sent are not taken into
+// account.
+func (rr *ResponseRecorder) AssertHeader(t TestingT, name, value string) bool {
+	values := rr.SentHeader()[http.CanonicalHeaderKey(name)]
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	if len(values) == 0 {
+		return rr.fail(t, fmt.Sprintf("Expected header %q to be %q but it was not sent", name, value))
+	}
+	return rr.fail(t, fmt.Sprintf("Expected header %q to be %q but got %q", name, value, strings.Join(values, ", ")))
+}
+
+// AssertTrailer asserts that the trailer was set to the value.
+func (rr *ResponseRecorder) AssertTrailer(t TestingT, name, value string) bool {
+	if actual := rr.Trailers().Get(name); actual != value {
+		return rr.fail(t, fmt.Sprintf("Expected trailer %q to be %q but got %q", name, value, actual))
+	}
+	return true
+}
+
+// AssertBody asserts that the data written is body.
+func (rr *ResponseRecorder) AssertBody(t TestingT, body string) bool {
+	if actual := string(rr.Body()); actual != body {
+		return rr.fail(t, fmt.Sprintf("Expected body %q but got %q", body, actual))
+	}
+	return true
+}
+
+// AssertChunks asserts that the data was written in the chunks, in order.
+func (rr *ResponseRecorder) AssertChunks(t TestingT, chunks ...string) bool {
+	actual := rr.Chunks()
+	if len(actual) != len(chunks) {
+		return rr.fail(t, fmt.Sprintf("Expected %d chunk(s) but got %d", len(chunks), len(actual)))
+	}
+	for i, chunk := range chunks {
+		if string(actual[i].Data) != chunk {
+			return rr.fail(t, fmt.Sprintf("Expected chunk %d to be %q but got %q", i, chunk, actual[i].Data))
+		}
+	}
+	return true
+}
+
+// AssertFlushed asserts that the recorder was flushed after every chunk,
+// as streaming handlers should.
+func (rr *ResponseRecorder) AssertFlushed(t TestingT) bool {
+	for i, chunk := range rr.Chunks() {
+		if !chunk.Flushed {
+			return rr.fail(t, fmt.Sprintf("Expected chunk %d to be flushed", i))
+		}
+	}
+	return true
+}
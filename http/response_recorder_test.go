@@ -0,0 +1,119 @@
+package http
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseRecorder(t *testing.T) {
+	rr := NewResponseRecorder()
+	var _ http.Flusher = rr
+	var _ http.Hijacker = rr
+	var _ http.Pusher = rr
+
+	rr.Header().Set("Content-Type", "text/event-stream")
+	rr.Header().Set("Trailer", "X-Checksum")
+	assert.NoError(t, rr.Push("/style.css", nil))
+	assert.Error(t, rr.Push("style.css", nil))
+	rr.WriteHeader(http.StatusAccepted)
+
+	// Changes made once the headers are sent are not sent.
+	rr.WriteHeader(http.StatusInternalServerError)
+	rr.Header().Set("X-Late", "true")
+	rr.Header().Del("Content-Type")
+	assert.Equal(t, "true", rr.Header().Get("X-Late"), "the copies of the headers are kept")
+
+	n, err := rr.Write([]byte("data: 1\n\n"))
+	assert.Equal(t, 9, n)
+	assert.NoError(t, err)
+	rr.Flush()
+	time.Sleep(10 * time.Millisecond)
+	rr.Write([]byte("data: 2\n\n"))
+	rr.Header().Set("X-Checksum", "abc")
+	rr.Header().Set(http.TrailerPrefix+"X-Extra", "def")
+
+	assert.True(t, rr.AssertStatus(t, http.StatusAccepted))
+	assert.True(t, rr.AssertHeader(t, "Content-Type", "text/event-stream"))
+	assert.True(t, rr.AssertBody(t, "data: 1\n\ndata: 2\n\n"))
+	assert.True(t, rr.AssertChunks(t, "data: 1\n\n", "data: 2\n\n"))
+	assert.True(t, rr.AssertTrailer(t, "X-Checksum", "abc"))
+	assert.True(t, rr.AssertTrailer(t, "X-Extra", "def"))
+	assert.Equal(t, []string{"/style.css"}, rr.Pushed())
+
+	chunks := rr.Chunks()
+	assert.True(t, chunks[1].Elapsed-chunks[0].Elapsed >= 10*time.Millisecond)
+	assert.True(t, chunks[0].Flushed)
+	assert.False(t, chunks[1].Flushed)
+
+	mockT := new(bufferT)
+	assert.False(t, rr.AssertHeader(mockT, "X-Late", "true"))
+	assert.False(t, rr.AssertFlushed(mockT))
+	assert.False(t, rr.AssertStatus(mockT, http.StatusOK))
+	assert.False(t, rr.AssertChunks(mockT, "data: 1\n\n"))
+	if assert.Len(t, mockT.messages, 4) {
+		assert.Contains(t, mockT.messages[0], `Expected header "X-Late" to be "true" but it was not sent`)
+		assert.Contains(t, mockT.messages[1], "Expected chunk 1 to be flushed")
+		assert.Contains(t, mockT.messages[2], "Expected status code 200 but got 202")
+		assert.Contains(t, mockT.messages[2], "202 Accepted\n")
+		assert.Contains(t, mockT.messages[2], `, flushed: "data: 1\n\n"`)
+		assert.Contains(t, mockT.messages[3], "Expected 1 chunk(s) but got 2")
+	}
+}
+
+func TestResponseRecorderImplicitHeader(t *testing.T) {
+	rr := NewResponseRecorder()
+	n, err := rr.ReadFrom(strings.NewReader("<html></html>"))
+	assert.Equal(t, int64(13), n)
+	assert.NoError(t, err)
+
+	rr.AssertStatus(t, http.StatusOK)
+	rr.AssertHeader(t, "Content-Type", "text/html; charset=utf-8")
+	rr.AssertChunks(t, "<html></html>")
+}
+
+func TestResponseRecorderHijack(t *testing.T) {
+	rr := NewResponseRecorder()
+	assert.Nil(t, rr.Conn())
+
+	conn, buf, err := rr.Hijack()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close()
+	assert.True(t, rr.Hijacked())
+
+	_, _, err = rr.Hijack()
+	assert.Equal(t, http.ErrHijacked, err)
+	_, err = rr.Write([]byte("ignored"))
+	assert.Equal(t, http.ErrHijacked, err)
+
+	go func() {
+		buf.WriteString("HTTP/1.1 101 Switching Protocols\r\n\r\n")
+		buf.Flush()
+	}()
+	line, err := bufio.NewReader(rr.Conn()).ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "HTTP/1.1 101 Switching Protocols\r\n", line)
+	assert.Equal(t, 0, rr.Status())
+}
+
+func TestTestResponseWriter(t *testing.T) {
+	rw := new(TestResponseWriter)
+	n, err := rw.Write([]byte("hello"))
+	assert.Equal(t, 5, n)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rw.StatusCode)
+	assert.Equal(t, "hello", rw.Output)
+
+	// io.Copy fails with io.ErrShortWrite if Write does not report the
+	// bytes written.
+	_, err = io.Copy(rw, strings.NewReader(" world"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", rw.Output)
+}
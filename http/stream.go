@@ -0,0 +1,252 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Event is an event received from a Stream.  Server-sent events are
+// parsed into all the fields, while in other streams, such as
+// newline-delimited JSON, every non-empty line is an event whose Data is
+// the line.
+type Event struct {
+	ID    string
+	Event string
+	Data  string
+
+	// Retry is the reconnection time last requested by the server, or 0.
+	// Like ID, it applies to all the events that follow.
+	Retry time.Duration
+}
+
+// Stream is a streaming response of a handler served by a live loopback
+// server, whose events can be asserted as they arrive, while the handler
+// is still running.
+//
+//    req, _ := http.NewRequest("GET", "/events", nil)
+//    stream := http.OpenStream(t, handler, req)
+//    defer stream.Close()
+//
+//    event, _ := stream.NextEvent(time.Second)
+//    stream.EventuallyReceives(func(e http.Event) bool { return e.Event == "done" }, time.Second)
+//    stream.AssertClosed(time.Second)
+type Stream struct {
+	// Response is the response of the handler, whose body is read by the
+	// stream.  It is nil if the request failed.
+	Response *http.Response
+
+	t      TestingT
+	server *httptest.Server
+	cancel context.CancelFunc
+	events chan Event
+	closed chan struct{}
+	stop   chan struct{}
+	err    error
+}
+
+// OpenStream serves req with handler on a new loopback server and starts
+// reading the response as a stream.  The response is parsed as
+// server-sent events if its content type is text/event-stream, and as
+// lines otherwise.  The URL of req may be a path only.  If the request
+// fails, the failure is reported and the stream is closed.  The caller
+// should call Close when finished.
+func OpenStream(t TestingT, handler http.Handler, req *http.Request) *Stream {
+	ctx, cancel := context.WithCancel(req.Context())
+	s := &Stream{
+		t:      t,
+		server: httptest.NewServer(handler),
+		cancel: cancel,
+		events: make(chan Event, 64),
+		closed: make(chan struct{}),
+		stop:   make(chan struct{}),
+	}
+
+	u := *req.URL
+	u.Scheme = "http"
+	u.Host = strings.TrimPrefix(s.server.URL, "http://")
+	req = req.WithContext(ctx)
+	req.URL = &u
+	req.Host = ""
+
+	resp, err := s.server.Client().Do(req)
+	if err != nil {
+		s.err = err
+		close(s.events)
+		close(s.closed)
+		assert.Fail(t, fmt.Sprintf("Cannot open stream %s %s: %s", req.Method, req.URL.Path, err))
+		return s
+	}
+	s.Response = resp
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	go s.read(mediaType == "text/event-stream")
+	return s
+}
+
+// read parses the body of the response into events until it ends.
+func (s *Stream) read(sse bool) {
+	defer close(s.closed)
+	defer close(s.events)
+
+	scanner := bufio.NewScanner(s.Response.Body)
+	scanner.Buffer(make([]byte, 4096), 1<<20)
+	var event Event
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !sse {
+			if line != "" && !s.send(Event{Data: line}) {
+				return
+			}
+			continue
+		}
+
+		if line == "" {
+			if len(data) > 0 {
+				event.Data = strings.Join(data, "\n")
+				if !s.send(event) {
+					return
+				}
+			}
+			event = Event{ID: event.ID, Retry: event.Retry}
+			data = nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value := line, ""
+		if i := strings.Index(line, ":"); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+		switch field {
+		case "id":
+			event.ID = value
+		case "event":
+			event.Event = value
+		case "data":
+			data = append(data, value)
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil {
+				event.Retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	s.err = scanner.Err()
+}
+
+// send sends the event to the channel of events, and returns false if
+// the stream was closed first.
+func (s *Stream) send(event Event) bool {
+	select {
+	case s.events <- event:
+		return true
+	case <-s.stop:
+		return false
+	}
+}
+
+// Events returns the channel on which the events are received.  It is
+// closed when the stream ends.
+func (s *Stream) Events() <-chan Event {
+	return s.events
+}
+
+// Closed returns a channel closed when the stream ends, because the
+// handler returned, the connection was closed or Close was called.
+func (s *Stream) Closed() <-chan struct{} {
+	return s.closed
+}
+
+// NextEvent waits up to timeout for the next event, and reports a failure
+// if none arrives.
+func (s *Stream) NextEvent(timeout time.Duration) (Event, bool) {
+	select {
+	case event, ok := <-s.events:
+		if !ok {
+			return Event{}, assert.Fail(s.t, fmt.Sprintf("Expected an event but the stream was closed%s", s.reason()))
+		}
+		return event, true
+	case <-time.After(timeout):
+		return Event{}, assert.Fail(s.t, fmt.Sprintf("Expected an event within %s but none was received", timeout))
+	}
+}
+
+// NextLine waits up to timeout for the next event, and returns its data.
+// It is meant for streams of lines, such as newline-delimited JSON.
+func (s *Stream) NextLine(timeout time.Duration) (string, bool) {
+	event, ok := s.NextEvent(timeout)
+	return event.Data, ok
+}
+
+// EventuallyReceives waits up to timeout for an event for which match
+// returns true, discarding the events received before it, and reports a
+// failure if none arrives.
+func (s *Stream) EventuallyReceives(match func(Event) bool, timeout time.Duration) (Event, bool) {
+	deadline := time.After(timeout)
+	discarded := 0
+	for {
+		select {
+		case event, ok := <-s.events:
+			if !ok {
+				return Event{}, assert.Fail(s.t, fmt.Sprintf("Expected a matching event but the stream was closed after %d other event(s)%s", discarded, s.reason()))
+			}
+			if match(event) {
+				return event, true
+			}
+			discarded++
+		case <-deadline:
+			return Event{}, assert.Fail(s.t, fmt.Sprintf("Expected a matching event within %s but received %d other event(s)", timeout, discarded))
+		}
+	}
+}
+
+// AssertClosed asserts that the stream ends within timeout, without
+// receiving any more events.
+func (s *Stream) AssertClosed(timeout time.Duration) bool {
+	select {
+	case event, ok := <-s.events:
+		if ok {
+			return assert.Fail(s.t, fmt.Sprintf("Expected the stream to be closed but received %#v", event))
+		}
+		return true
+	case <-time.After(timeout):
+		return assert.Fail(s.t, fmt.Sprintf("Expected the stream to be closed within %s", timeout))
+	}
+}
+
+// Close closes the connection and shuts down the server, which waits for
+// the handler to return: streaming handlers should return once the
+// context of their request is done.
+func (s *Stream) Close() {
+	select {
+	case <-s.stop:
+		return
+	default:
+		close(s.stop)
+	}
+	s.cancel()
+	<-s.closed
+	if s.Response != nil {
+		s.Response.Body.Close()
+	}
+	s.server.Close()
+}
+
+// reason describes why the stream ended, if it ended with an error.
+func (s *Stream) reason() string {
+	<-s.closed
+	if s.err != nil {
+		return fmt.Sprintf(": %s", s.err)
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sseHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	fmt.Fprint(w, ": connected\nretry: 1000\n\n")
+	for i := 1; i <= 3; i++ {
+		fmt.Fprintf(w, "id: %d\nevent: tick\ndata: line %d\ndata: of tick\n\n", i, i)
+		w.(http.Flusher).Flush()
+		time.Sleep(10 * time.Millisecond)
+	}
+	fmt.Fprint(w, "event: done\ndata: bye\n\n")
+}
+
+func TestStreamServerSentEvents(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/events", nil)
+	stream := OpenStream(t, http.HandlerFunc(sseHandler), req)
+	defer stream.Close()
+
+	assert.Equal(t, http.StatusOK, stream.Response.StatusCode)
+	event, ok := stream.NextEvent(time.Second)
+	assert.True(t, ok)
+	assert.Equal(t, Event{ID: "1", Event: "tick", Data: "line 1\nof tick", Retry: time.Second}, event)
+
+	event, ok = stream.EventuallyReceives(func(e Event) bool { return e.Event == "done" }, time.Second)
+	assert.True(t, ok)
+	assert.Equal(t, Event{ID: "3", Event: "done", Data: "bye", Retry: time.Second}, event)
+	assert.True(t, stream.AssertClosed(time.Second))
+
+	mockT := new(bufferT)
+	stream.t = mockT
+	_, ok = stream.NextEvent(time.Second)
+	assert.False(t, ok)
+	if assert.Len(t, mockT.messages, 1) {
+		assert.Contains(t, mockT.messages[0], "Expected an event but the stream was closed")
+	}
+}
+
+func TestStreamLines(t *testing.T) {
+	release := make(chan struct{})
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		fmt.Fprintln(w, `{"n": 1}`)
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+		fmt.Fprintln(w, `{"n": 2}`)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}
+
+	req, _ := http.NewRequest("GET", "/feed", nil)
+	stream := OpenStream(t, http.HandlerFunc(handler), req)
+	defer stream.Close()
+
+	line, _ := stream.NextLine(time.Second)
+	assert.JSONEq(t, `{"n": 1}`, line)
+
+	mockT := new(bufferT)
+	stream.t = mockT
+	_, ok := stream.NextLine(20 * time.Millisecond)
+	assert.False(t, ok)
+	assert.False(t, stream.AssertClosed(20*time.Millisecond))
+	if assert.Len(t, mockT.messages, 2) {
+		assert.Contains(t, mockT.messages[0], "Expected an event within 20ms but none was received")
+		assert.Contains(t, mockT.messages[1], "Expected the stream to be closed within 20ms")
+	}
+	stream.t = t
+
+	close(release)
+	line, _ = stream.NextLine(time.Second)
+	assert.JSONEq(t, `{"n": 2}`, line)
+
+	stream.Close()
+	select {
+	case <-stream.Closed():
+	default:
+		t.Error("the stream should be closed")
+	}
+}
+
+func TestStreamCannotOpen(t *testing.T) {
+	mockT := new(bufferT)
+	req, _ := http.NewRequest("GET", "/", nil)
+	req.Header.Set("Bad Header", "x")
+	stream := OpenStream(mockT, http.NotFoundHandler(), req)
+	defer stream.Close()
+
+	assert.Nil(t, stream.Response)
+	if assert.Len(t, mockT.messages, 1) {
+		assert.Contains(t, mockT.messages[0], "Cannot open stream GET /")
+	}
+}
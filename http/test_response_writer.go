@@ -7,7 +7,8 @@ import (
 // TestResponseWriter is a http.ResponseWriter object that keeps track of all activity
 // allowing you to make assertions about how it was used.
 //
-// DEPRECATED: We recommend you use http://golang.org/pkg/net/http/httptest instead.
+// DEPRECATED: We recommend you use ResponseRecorder, or
+// http://golang.org/pkg/net/http/httptest instead.
 type TestResponseWriter struct {
 
 	// StatusCode is the last int written by the call to WriteHeader(int)
@@ -42,7 +43,7 @@ func (rw *TestResponseWriter) Write(bytes []byte) (int, error) {
 	rw.Output = rw.Output + string(bytes)
 
 	// return normal values
-	return 0, nil
+	return len(bytes), nil
 
 }
 
@@ -1,15 +1,98 @@
 package http
 
 import (
-	"github.com/stretchr/testify/mock"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+// TestRoundTripper is a http.RoundTripper mocked with mock.Mock.  The
+// expected requests are usually described with MatchRequest, and the
+// responses with Respond or RespondError:
+//
+//    rt := new(http.TestRoundTripper)
+//    rt.On("RoundTrip", http.MatchRequest("GET", "/users/{id}")).
+//      Return(http.Respond(200, `{"name": "Mat"}`, nil))
+//
+// Returning a *http.Response and an error is also supported.  The bodies
+// of all the responses are tracked, so that AssertBodiesClosed can check
+// that none of them leaked.
 type TestRoundTripper struct {
 	mock.Mock
+
+	bodiesMutex sync.Mutex
+	bodies      []trackedResponse
+}
+
+// trackedResponse is the body of a response returned by RoundTrip, along
+// with the request it responded to.
+type trackedResponse struct {
+	req  *http.Request
+	body *TrackedBody
 }
 
+// RoundTrip returns the response of the expectation matching a clone of
+// the request.  The body of the request is read and closed, and the clone
+// holds a copy of it that can be read again, through GetBody too, by the
+// matchers and the Responder.
 func (t *TestRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	args := t.Called(req)
-	return args.Get(0).(*http.Response), args.Error(1)
+	called := req
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading the body of %s %s: %s", req.Method, req.URL, err)
+		}
+		called = req.Clone(req.Context())
+		called.Body = ioutil.NopCloser(bytes.NewReader(body))
+		called.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+	args := t.Called(called)
+
+	var resp *http.Response
+	var err error
+	if responder, ok := args.Get(0).(Responder); ok {
+		resp, err = responder(called)
+	} else {
+		resp, err = args.Get(0).(*http.Response), args.Error(1)
+	}
+
+	if resp != nil && resp.Body != nil {
+		body, ok := resp.Body.(*TrackedBody)
+		if !ok {
+			body = &TrackedBody{ReadCloser: resp.Body}
+			resp.Body = body
+		}
+		t.bodiesMutex.Lock()
+		t.bodies = append(t.bodies, trackedResponse{req, body})
+		t.bodiesMutex.Unlock()
+	}
+	return resp, err
+}
+
+// AssertBodiesClosed asserts that the bodies of all the responses
+// returned so far were closed.
+func (t *TestRoundTripper) AssertBodiesClosed(tt TestingT) bool {
+	t.bodiesMutex.Lock()
+	defer t.bodiesMutex.Unlock()
+
+	leaked := []string{}
+	for _, tracked := range t.bodies {
+		if !tracked.body.Closed() {
+			leaked = append(leaked, fmt.Sprintf("%s %s", tracked.req.Method, tracked.req.URL))
+		}
+	}
+	if len(leaked) > 0 {
+		return assert.Fail(tt, fmt.Sprintf("%d of %d response bodies were not closed:\n%s", len(leaked), len(t.bodies), strings.Join(leaked, "\n")))
+	}
+	return true
 }
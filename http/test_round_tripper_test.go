@@ -0,0 +1,135 @@
+package http
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestRoundTripper(t *testing.T) {
+	rt := new(TestRoundTripper)
+	rt.On("RoundTrip", MatchRequest("GET", "http://example.com/users/{id}").WithHeader("Accept", "application/json")).
+		Return(Respond(http.StatusOK, `{"name": "Mat"}`, http.Header{"Content-Type": {"application/json"}}))
+	rt.On("RoundTrip", MatchRequest("POST", "/users").WithJSONBody(`{"name": "Tyler"}`)).
+		Return(Respond(http.StatusCreated, "", nil))
+	rt.On("RoundTrip", MatchRequest("", "/down")).
+		Return(RespondError(errors.New("connection refused")))
+
+	client := &http.Client{Transport: rt}
+
+	req, _ := http.NewRequest("GET", "http://example.com/users/1", nil)
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if assert.NoError(t, err) {
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, `{"name": "Mat"}`, string(body))
+		assert.Equal(t, "200 OK", resp.Status)
+		assert.Equal(t, "HTTP/1.1", resp.Proto)
+		assert.Equal(t, int64(len(body)), resp.ContentLength)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		assert.Equal(t, req, resp.Request)
+	}
+
+	resp, err = client.Post("http://example.com/users", "application/json", strings.NewReader(`{"name":"Tyler"}`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		// The request body can still be read after being matched, and
+		// matched again by AssertExpectations.
+		body, _ := ioutil.ReadAll(resp.Request.Body)
+		assert.Equal(t, `{"name":"Tyler"}`, string(body))
+	}
+
+	_, err = client.Get("http://example.com/down")
+	assert.Error(t, err)
+
+	rt.AssertExpectations(t)
+
+	// The body of the POST response was never closed.
+	mockT := new(bufferT)
+	assert.False(t, rt.AssertBodiesClosed(mockT))
+	if assert.Len(t, mockT.messages, 1) {
+		assert.Contains(t, mockT.messages[0], "1 of 2 response bodies were not closed")
+		assert.Contains(t, mockT.messages[0], "POST http://example.com/users")
+	}
+
+	resp.Body.Close()
+	assert.True(t, rt.AssertBodiesClosed(t))
+}
+
+func TestTestRoundTripperWithResponse(t *testing.T) {
+	rt := new(TestRoundTripper)
+	rt.On("RoundTrip", MatchRequest("GET", "/legacy")).
+		Return(&http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("ok"))}, nil)
+
+	resp, err := rt.RoundTrip(mustRequest("GET", "http://example.com/legacy"))
+	if assert.NoError(t, err) {
+		assert.IsType(t, new(TrackedBody), resp.Body)
+		assert.False(t, rt.AssertBodiesClosed(new(bufferT)))
+	}
+}
+
+func TestRequestMatcher(t *testing.T) {
+	m := MatchRequest("GET", "https://example.com/users/{id}").WithHeader("X-Token", "secret")
+	assert.Equal(t, "GET https://example.com/users/{id} [header X-Token: secret]", m.String())
+
+	req := mustRequest("GET", "https://example.com/users/1")
+	assert.False(t, m.Matches(req))
+	req.Header.Set("X-Token", "secret")
+	assert.True(t, m.Matches(req))
+
+	assert.False(t, m.Matches(mustRequest("GET", "http://example.com/users/1")))
+	assert.False(t, m.Matches(mustRequest("GET", "https://example.org/users/1")))
+	assert.False(t, m.Matches(mustRequest("PUT", "https://example.com/users/1")))
+	assert.False(t, m.Matches("not a request"))
+
+	assert.Panics(t, func() {
+		MatchRequest("POST", "/users").WithJSONBody("{not json")
+	})
+}
+
+func mustRequest(method, url string) *http.Request {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		panic(err)
+	}
+	return req
+}
+
+func TestTestRoundTripperKeepsRequest(t *testing.T) {
+	rt := new(TestRoundTripper)
+	rt.On("RoundTrip", MatchRequest("POST", "/ids").WithJSONBody(`{"id": 9007199254740993}`)).
+		Return(Responder(func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			return Respond(http.StatusOK, string(body), nil)(req)
+		}))
+
+	body := &closeRecorder{Reader: strings.NewReader(`{"id": 9007199254740993}`)}
+	req, _ := http.NewRequest("POST", "http://example.com/ids", body)
+	resp, err := rt.RoundTrip(req)
+	if assert.NoError(t, err) {
+		echoed, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, `{"id": 9007199254740993}`, string(echoed), "the responder reads the body")
+	}
+	assert.True(t, req.Body == body, "the body of the request is not replaced")
+	assert.Nil(t, req.GetBody)
+	assert.True(t, body.closed)
+
+	m := MatchRequest("POST", "/ids").WithJSONBody(`{"id": 9007199254740993}`)
+	assert.False(t, m.Matches(mustRequestWithBody("POST", "http://example.com/ids", `{"id": 9007199254740992}`)))
+	assert.True(t, m.Matches(mustRequestWithBody("POST", "http://example.com/ids", `{"id": 9007199254740993.0}`)))
+}
+
+func mustRequestWithBody(method, url, body string) *http.Request {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		panic(err)
+	}
+	return req
+}
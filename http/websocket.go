@@ -0,0 +1,519 @@
+package http
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// MessageType is the type of a WebSocket message.
+type MessageType int
+
+// The types of WebSocket messages, as defined by RFC 6455.
+const (
+	TextMessage   MessageType = 1
+	BinaryMessage MessageType = 2
+)
+
+// String returns the name of the message type.
+func (m MessageType) String() string {
+	switch m {
+	case TextMessage:
+		return "text"
+	case BinaryMessage:
+		return "binary"
+	}
+	return fmt.Sprintf("MessageType(%d)", int(m))
+}
+
+// Some of the close codes defined by RFC 6455.
+const (
+	CloseNormal         = 1000
+	CloseGoingAway      = 1001
+	CloseProtocolError  = 1002
+	CloseUnsupported    = 1003
+	CloseNoStatus       = 1005
+	CloseInvalidData    = 1007
+	ClosePolicyViolated = 1008
+	CloseTooBig         = 1009
+	CloseInternalError  = 1011
+)
+
+// The opcodes of WebSocket frames.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+// websocketGUID is appended to the key of a handshake to compute the
+// accept key of the response.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxFramePayload limits the size of the frames read, so that a broken
+// handler cannot make the client allocate unbounded memory.
+const maxFramePayload = 16 << 20
+
+// Message is a message received from a WebSocket.
+type Message struct {
+	Type MessageType
+	Data []byte
+}
+
+// WebSocket is a minimal RFC 6455 client connected to a handler served by
+// a live loopback server.  Pings from the handler are answered
+// automatically, and the messages received can be asserted as they
+// arrive.
+//
+//    ws := http.DialWebSocket(t, handler, "/chat", nil)
+//    defer ws.Close()
+//
+//    ws.SendText("hello")
+//    ws.ReceivesText("hello", time.Second)
+//    ws.SendJSON(map[string]string{"type": "quit"})
+//    ws.AssertClosed(http.CloseNormal, time.Second)
+type WebSocket struct {
+	// Response is the response to the opening handshake.  It is nil if
+	// the connection could not be established.
+	Response *http.Response
+
+	t      TestingT
+	server *httptest.Server
+	conn   net.Conn
+
+	writeMutex sync.Mutex
+	messages   chan Message
+	pongs      chan []byte
+	stop       chan struct{}
+	closed     chan struct{}
+
+	// The close code and reason sent by the handler, and the error that
+	// ended the connection otherwise, set before closed is closed.
+	closeCode   int
+	closeReason string
+	err         error
+}
+
+// DialWebSocket serves handler on a new loopback server and opens a
+// WebSocket connection to path with the headers, which may be nil.  If the
+// connection cannot be established, the failure is reported and the
+// WebSocket is closed.  The caller should call Close when finished.
+func DialWebSocket(t TestingT, handler http.Handler, path string, header http.Header) *WebSocket {
+	ws := &WebSocket{
+		t:        t,
+		server:   httptest.NewServer(handler),
+		messages: make(chan Message, 64),
+		pongs:    make(chan []byte, 16),
+		stop:     make(chan struct{}),
+		closed:   make(chan struct{}),
+	}
+
+	reader, err := ws.handshake(path, header)
+	if err != nil {
+		ws.err = err
+		if ws.conn != nil {
+			ws.conn.Close()
+		}
+		close(ws.messages)
+		close(ws.closed)
+		assert.Fail(t, fmt.Sprintf("Cannot open WebSocket %s: %s", path, err))
+		return ws
+	}
+	go ws.read(reader)
+	return ws
+}
+
+// handshake connects to the server and performs the opening handshake,
+// returning the reader of the frames that follow.
+func (ws *WebSocket) handshake(path string, header http.Header) (*bufio.Reader, error) {
+	conn, err := net.Dial("tcp", ws.server.Listener.Addr().String())
+	if err != nil {
+		return nil, err
+	}
+	ws.conn = conn
+
+	nonce := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	req, err := http.NewRequest("GET", ws.server.URL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = append([]string{}, values...)
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		return nil, err
+	}
+	ws.Response = resp
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, fmt.Errorf("expected status code 101 but got %d", resp.StatusCode)
+	}
+	if !strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") {
+		return nil, fmt.Errorf("expected header Upgrade to be \"websocket\" but got %q", resp.Header.Get("Upgrade"))
+	}
+	if accept := resp.Header.Get("Sec-WebSocket-Accept"); accept != acceptKey(key) {
+		return nil, fmt.Errorf("expected header Sec-WebSocket-Accept to be %q but got %q", acceptKey(key), accept)
+	}
+	return reader, nil
+}
+
+// acceptKey returns the accept key of the response to a handshake whose
+// key is key.
+func acceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// read reads the frames sent by the handler until the connection is
+// closed, answering pings and reassembling fragmented messages.
+func (ws *WebSocket) read(reader *bufio.Reader) {
+	defer close(ws.closed)
+	defer ws.conn.Close()
+	defer close(ws.messages)
+
+	var message *Message
+	for {
+		f, err := readFrame(reader)
+		if err != nil {
+			ws.err = err
+			return
+		}
+
+		switch f.opcode {
+		case opPing:
+			ws.writeFrame(opPong, f.payload)
+		case opPong:
+			select {
+			case ws.pongs <- f.payload:
+			default:
+			}
+		case opClose:
+			ws.closeCode = CloseNoStatus
+			if len(f.payload) >= 2 {
+				ws.closeCode = int(binary.BigEndian.Uint16(f.payload))
+				ws.closeReason = string(f.payload[2:])
+			}
+			ws.writeFrame(opClose, closePayload(ws.closeCode, ""))
+			return
+		case opText, opBinary:
+			message = &Message{Type: MessageType(f.opcode), Data: f.payload}
+		case opContinuation:
+			if message == nil {
+				ws.err = errors.New("continuation frame without a message to continue")
+				return
+			}
+			message.Data = append(message.Data, f.payload...)
+		default:
+			ws.err = fmt.Errorf("unknown opcode %#x", f.opcode)
+			return
+		}
+
+		if message != nil && f.fin && (f.opcode == opText || f.opcode == opBinary || f.opcode == opContinuation) {
+			select {
+			case ws.messages <- *message:
+			case <-ws.stop:
+				return
+			}
+			message = nil
+		}
+	}
+}
+
+// writeFrame sends a masked frame holding the payload.
+func (ws *WebSocket) writeFrame(opcode byte, payload []byte) error {
+	ws.writeMutex.Lock()
+	defer ws.writeMutex.Unlock()
+	return writeFrame(ws.conn, opcode, payload, true)
+}
+
+// send sends a frame, reporting a failure if it cannot be sent.
+func (ws *WebSocket) send(opcode byte, payload []byte) bool {
+	select {
+	case <-ws.closed:
+		return assert.Fail(ws.t, fmt.Sprintf("Cannot send a frame to a closed WebSocket%s", ws.reason()))
+	default:
+	}
+	if err := ws.writeFrame(opcode, payload); err != nil {
+		return assert.Fail(ws.t, fmt.Sprintf("Cannot send a frame to the WebSocket: %s", err))
+	}
+	return true
+}
+
+// SendText sends a text message.
+func (ws *WebSocket) SendText(text string) bool {
+	return ws.send(opText, []byte(text))
+}
+
+// SendBinary sends a binary message.
+func (ws *WebSocket) SendBinary(data []byte) bool {
+	return ws.send(opBinary, data)
+}
+
+// SendJSON sends the JSON encoding of v as a text message.
+func (ws *WebSocket) SendJSON(v interface{}) bool {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return assert.Fail(ws.t, fmt.Sprintf("Cannot encode message as JSON: %s", err))
+	}
+	return ws.send(opText, data)
+}
+
+// Ping sends a ping with the data, whose pong can be awaited with
+// ReceivesPong.
+func (ws *WebSocket) Ping(data []byte) bool {
+	return ws.send(opPing, data)
+}
+
+// NextMessage waits up to timeout for the next message, and reports a
+// failure if none arrives.
+func (ws *WebSocket) NextMessage(timeout time.Duration) (Message, bool) {
+	select {
+	case message, ok := <-ws.messages:
+		if !ok {
+			return Message{}, assert.Fail(ws.t, fmt.Sprintf("Expected a message but the WebSocket was closed%s", ws.reason()))
+		}
+		return message, true
+	case <-time.After(timeout):
+		return Message{}, assert.Fail(ws.t, fmt.Sprintf("Expected a message within %s but none was received", timeout))
+	}
+}
+
+// receives waits up to timeout for the next message, and asserts that it
+// has the type.
+func (ws *WebSocket) receives(messageType MessageType, timeout time.Duration) (Message, bool) {
+	message, ok := ws.NextMessage(timeout)
+	if ok && message.Type != messageType {
+		return message, assert.Fail(ws.t, fmt.Sprintf("Expected a %s message but got a %s message: %q", messageType, message.Type, message.Data))
+	}
+	return message, ok
+}
+
+// ReceivesText asserts that the next message, received within timeout,
+// is a text message equal to expected.
+func (ws *WebSocket) ReceivesText(expected string, timeout time.Duration) bool {
+	message, ok := ws.receives(TextMessage, timeout)
+	if !ok {
+		return false
+	}
+	return assert.Equal(ws.t, expected, string(message.Data))
+}
+
+// ReceivesBinary asserts that the next message, received within timeout,
+// is a binary message equal to expected.
+func (ws *WebSocket) ReceivesBinary(expected []byte, timeout time.Duration) bool {
+	message, ok := ws.receives(BinaryMessage, timeout)
+	if !ok {
+		return false
+	}
+	return assert.Equal(ws.t, expected, message.Data)
+}
+
+// ReceivesJSON asserts that the next message, received within timeout,
+// is a text message JSON equivalent to expected.
+func (ws *WebSocket) ReceivesJSON(expected string, timeout time.Duration) bool {
+	message, ok := ws.receives(TextMessage, timeout)
+	if !ok {
+		return false
+	}
+	return assert.JSONEq(ws.t, expected, string(message.Data))
+}
+
+// ReceivesPong asserts that a pong is received within timeout.
+func (ws *WebSocket) ReceivesPong(timeout time.Duration) bool {
+	select {
+	case <-ws.pongs:
+		return true
+	case <-time.After(timeout):
+		return assert.Fail(ws.t, fmt.Sprintf("Expected a pong within %s but none was received", timeout))
+	}
+}
+
+// AssertClosed asserts that the handler closes the connection with the
+// close code within timeout, without sending any more messages.
+func (ws *WebSocket) AssertClosed(code int, timeout time.Duration) bool {
+	select {
+	case message, ok := <-ws.messages:
+		if ok {
+			return assert.Fail(ws.t, fmt.Sprintf("Expected the WebSocket to be closed but received a %s message: %q", message.Type, message.Data))
+		}
+	case <-time.After(timeout):
+		return assert.Fail(ws.t, fmt.Sprintf("Expected the WebSocket to be closed within %s", timeout))
+	}
+
+	<-ws.closed
+	if ws.closeCode == 0 {
+		return assert.Fail(ws.t, fmt.Sprintf("Expected the WebSocket to be closed with code %d but the connection was lost%s", code, ws.reason()))
+	}
+	if ws.closeCode != code {
+		return assert.Fail(ws.t, fmt.Sprintf("Expected the WebSocket to be closed with code %d but got %d (%q)", code, ws.closeCode, ws.closeReason))
+	}
+	return true
+}
+
+// CloseReason returns the reason sent by the handler when it closed the
+// connection, or "" if it did not.
+func (ws *WebSocket) CloseReason() string {
+	<-ws.closed
+	return ws.closeReason
+}
+
+// Close closes the connection, sending a normal close frame if it is
+// still open, and shuts down the server.
+func (ws *WebSocket) Close() {
+	select {
+	case <-ws.stop:
+		return
+	default:
+		close(ws.stop)
+	}
+
+	select {
+	case <-ws.closed:
+	default:
+		ws.writeFrame(opClose, closePayload(CloseNormal, ""))
+		select {
+		case <-ws.closed:
+		case <-time.After(time.Second):
+			ws.conn.Close()
+			<-ws.closed
+		}
+	}
+	ws.server.Close()
+}
+
+// reason describes why the connection ended, if it ended with an error.
+func (ws *WebSocket) reason() string {
+	<-ws.closed
+	if ws.err != nil && ws.err != io.EOF {
+		return fmt.Sprintf(": %s", ws.err)
+	}
+	return ""
+}
+
+// closePayload returns the payload of a close frame.
+func closePayload(code int, reason string) []byte {
+	if code == CloseNoStatus {
+		return nil
+	}
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	return append(payload, reason...)
+}
+
+// frame is a WebSocket frame, unmasked.
+type frame struct {
+	fin     bool
+	opcode  byte
+	payload []byte
+}
+
+// readFrame reads a frame, unmasking its payload if it is masked.
+func readFrame(r *bufio.Reader) (frame, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return frame{}, err
+	}
+	f := frame{fin: header[0]&0x80 != 0, opcode: header[0] & 0x0F}
+	masked := header[1]&0x80 != 0
+
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var extended [2]byte
+		if _, err := io.ReadFull(r, extended[:]); err != nil {
+			return frame{}, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		if _, err := io.ReadFull(r, extended[:]); err != nil {
+			return frame{}, err
+		}
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+	if length > maxFramePayload {
+		return frame{}, fmt.Errorf("frame of %d bytes is too large", length)
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(r, mask[:]); err != nil {
+			return frame{}, err
+		}
+	}
+	f.payload = make([]byte, length)
+	if _, err := io.ReadFull(r, f.payload); err != nil {
+		return frame{}, err
+	}
+	if masked {
+		for i := range f.payload {
+			f.payload[i] ^= mask[i%4]
+		}
+	}
+	return f, nil
+}
+
+// writeFrame writes a final frame holding the payload, masked with a
+// random key if mask is true, as frames sent by clients must be.
+func writeFrame(w io.Writer, opcode byte, payload []byte, mask bool) error {
+	header := []byte{0x80 | opcode, 0}
+	switch {
+	case len(payload) < 126:
+		header[1] = byte(len(payload))
+	case len(payload) <= 0xFFFF:
+		header[1] = 126
+		header = append(header, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(len(payload)))
+	default:
+		header[1] = 127
+		header = append(header, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(len(payload)))
+	}
+
+	data := payload
+	if mask {
+		header[1] |= 0x80
+		var key [4]byte
+		if _, err := io.ReadFull(rand.Reader, key[:]); err != nil {
+			return err
+		}
+		header = append(header, key[:]...)
+		data = make([]byte, len(payload))
+		for i := range payload {
+			data[i] = payload[i] ^ key[i%4]
+		}
+	}
+	_, err := w.Write(append(header, data...))
+	return err
+}
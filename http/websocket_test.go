@@ -0,0 +1,135 @@
+package http
+
+import (
+	"bufio"
+	"encoding/binary"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// echoWebSocket is a minimal WebSocket handler echoing the messages it
+// receives.  It closes the connection with code 4000 when it receives
+// "quit", sends a ping when it receives "ping", and sends a fragmented
+// message when it receives "fragments".
+func echoWebSocket(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Upgrade") != "websocket" {
+		http.Error(w, "websocket only", http.StatusBadRequest)
+		return
+	}
+	conn, rw, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+	rw.WriteString("Sec-WebSocket-Accept: " + acceptKey(r.Header.Get("Sec-WebSocket-Key")) + "\r\n\r\n")
+	rw.Flush()
+
+	reader := bufio.NewReader(rw)
+	for {
+		f, err := readFrame(reader)
+		if err != nil {
+			return
+		}
+		switch {
+		case f.opcode == opPing:
+			writeFrame(conn, opPong, f.payload, false)
+		case f.opcode == opClose:
+			writeFrame(conn, opClose, f.payload, false)
+			return
+		case string(f.payload) == "quit":
+			writeFrame(conn, opClose, closePayload(4000, "bye"), false)
+			readFrame(reader)
+			return
+		case string(f.payload) == "ping":
+			writeFrame(conn, opPing, []byte("are you there?"), false)
+		case string(f.payload) == "fragments":
+			conn.Write([]byte{opText, 3, 'o', 'n', 'e'})
+			conn.Write([]byte{0x80 | opPing, 0})
+			conn.Write([]byte{0x80 | opContinuation, 4, ' ', 't', 'w', 'o'})
+		default:
+			writeFrame(conn, f.opcode, f.payload, false)
+		}
+	}
+}
+
+func TestWebSocket(t *testing.T) {
+	ws := DialWebSocket(t, http.HandlerFunc(echoWebSocket), "/echo", http.Header{"Origin": {"http://example.com"}})
+	defer ws.Close()
+
+	assert.Equal(t, http.StatusSwitchingProtocols, ws.Response.StatusCode)
+
+	assert.True(t, ws.SendText("hello"))
+	assert.True(t, ws.ReceivesText("hello", time.Second))
+
+	large := []byte(strings.Repeat("x", 70000))
+	assert.True(t, ws.SendBinary(large))
+	assert.True(t, ws.ReceivesBinary(large, time.Second))
+
+	assert.True(t, ws.SendJSON(map[string]int{"n": 1}))
+	assert.True(t, ws.ReceivesJSON(`{"n": 1}`, time.Second))
+
+	assert.True(t, ws.Ping([]byte("ping?")))
+	assert.True(t, ws.ReceivesPong(time.Second))
+
+	// Pings from the handler are answered without surfacing as messages.
+	ws.SendText("ping")
+	assert.True(t, ws.ReceivesPong(time.Second))
+
+	ws.SendText("fragments")
+	assert.True(t, ws.ReceivesText("one two", time.Second))
+
+	ws.SendText("quit")
+	assert.True(t, ws.AssertClosed(4000, time.Second))
+	assert.Equal(t, "bye", ws.CloseReason())
+
+	mockT := new(bufferT)
+	ws.t = mockT
+	assert.False(t, ws.SendText("too late"))
+	if assert.Len(t, mockT.messages, 1) {
+		assert.Contains(t, mockT.messages[0], "Cannot send a frame to a closed WebSocket")
+	}
+}
+
+func TestWebSocketFailures(t *testing.T) {
+	mockT := new(bufferT)
+	ws := DialWebSocket(mockT, http.HandlerFunc(echoWebSocket), "/echo", nil)
+	defer ws.Close()
+
+	ws.SendText("hello")
+	assert.False(t, ws.ReceivesBinary([]byte("hello"), time.Second))
+	_, ok := ws.NextMessage(20 * time.Millisecond)
+	assert.False(t, ok)
+	assert.False(t, ws.ReceivesPong(20*time.Millisecond))
+	ws.SendText("quit")
+	assert.False(t, ws.AssertClosed(CloseNormal, time.Second))
+
+	if assert.Len(t, mockT.messages, 4) {
+		assert.Contains(t, mockT.messages[0], `Expected a binary message but got a text message: "hello"`)
+		assert.Contains(t, mockT.messages[1], "Expected a message within 20ms but none was received")
+		assert.Contains(t, mockT.messages[2], "Expected a pong within 20ms but none was received")
+		assert.Contains(t, mockT.messages[3], `Expected the WebSocket to be closed with code 1000 but got 4000 ("bye")`)
+	}
+}
+
+func TestWebSocketHandshakeFailure(t *testing.T) {
+	mockT := new(bufferT)
+	ws := DialWebSocket(mockT, http.NotFoundHandler(), "/missing", nil)
+	defer ws.Close()
+
+	assert.Equal(t, http.StatusNotFound, ws.Response.StatusCode)
+	if assert.Len(t, mockT.messages, 1) {
+		assert.Contains(t, mockT.messages[0], "Cannot open WebSocket /missing: expected status code 101 but got 404")
+	}
+}
+
+func TestClosePayload(t *testing.T) {
+	payload := closePayload(CloseGoingAway, "restart")
+	assert.Equal(t, uint16(CloseGoingAway), binary.BigEndian.Uint16(payload))
+	assert.Equal(t, "restart", string(payload[2:]))
+	assert.Nil(t, closePayload(CloseNoStatus, ""))
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/objx"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/clock"
 )
 
 // TestingT is an interface wrapper around *testing.T
@@ -117,11 +118,12 @@ func (self *Call) WaitUntil(w <-chan time.Time) *Call {
 	return self
 }
 
-// After sets how long to block until the call returns
+// After sets how long to block until the call returns, as measured by the
+// clock of the mock from now on.
 //
 //    Mock.On("MyMethod", arg1, arg2).After(time.Second)
 func (self *Call) After(d time.Duration) *Call {
-	return self.WaitUntil(time.After(d))
+	return self.WaitUntil(self.Parent.clock().After(d))
 }
 
 // Run sets a handler to be called before returning. It can be used when
@@ -164,9 +166,36 @@ type Mock struct {
 	// this data completely allowing you to do whatever you like with it.
 	testData objx.Map
 
+	// The clock used by Call.After, which is the real one unless set.
+	timeSource clock.Clock
+
 	mutex sync.Mutex
 }
 
+// SetClock sets the clock that the calls of the mock wait on, so that a
+// test can advance the time of a fake clock past the delay of Call.After
+// instead of sleeping.  It applies to the calls set up after it.
+//
+//    fake := clock.NewFake(time.Now())
+//    Mock.SetClock(fake)
+//    Mock.On("MyMethod", arg1, arg2).After(time.Minute)
+//    go object.MyMethod(arg1, arg2) // Blocks until the time is advanced.
+//    fake.Advance(time.Minute)
+func (m *Mock) SetClock(c clock.Clock) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.timeSource = c
+}
+
+func (m *Mock) clock() clock.Clock {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.timeSource == nil {
+		return clock.Real{}
+	}
+	return m.timeSource
+}
+
 // TestData holds any data that might be useful for testing.  Testify ignores
 // this data completely allowing you to do whatever you like with it.
 func (m *Mock) TestData() objx.Map {
@@ -458,6 +487,18 @@ func AnythingOfType(t string) AnythingOfTypeArgument {
 	return AnythingOfTypeArgument(t)
 }
 
+// ArgumentMatcher matches an argument of a method call.  Expected
+// arguments implementing ArgumentMatcher are compared with the actual
+// arguments using Matches in Diff and Assert, which allows expectations
+// on arguments that cannot be compared for equality.
+type ArgumentMatcher interface {
+	// Matches returns whether the actual argument matches.
+	Matches(argument interface{}) bool
+
+	// String describes the arguments that are matched.
+	String() string
+}
+
 // Get Returns the argument at the specified index.
 func (args Arguments) Get(index int) interface{} {
 	if index+1 > len(args) {
@@ -505,7 +546,17 @@ func (args Arguments) Diff(objects []interface{}) (string, int) {
 			expected = args[i]
 		}
 
-		if reflect.TypeOf(expected) == reflect.TypeOf((*AnythingOfTypeArgument)(nil)).Elem() {
+		if matcher, ok := expected.(ArgumentMatcher); ok {
+
+			// matcher checking
+			if matcher.Matches(actual) {
+				output = fmt.Sprintf("%s\t%d: \u2705  %s matched by %s\n", output, i, actual, matcher)
+			} else {
+				differences++
+				output = fmt.Sprintf("%s\t%d: \u274C  %s not matched by %s\n", output, i, actual, matcher)
+			}
+
+		} else if reflect.TypeOf(expected) == reflect.TypeOf((*AnythingOfTypeArgument)(nil)).Elem() {
 
 			// type checking
 			if reflect.TypeOf(actual).Name() != string(expected.(AnythingOfTypeArgument)) && reflect.TypeOf(actual).String() != string(expected.(AnythingOfTypeArgument)) {
@@ -3,6 +3,7 @@ package mock
 import (
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/clock"
 	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
@@ -937,6 +938,50 @@ func Test_Arguments_Diff_WithAnythingOfTypeArgument_Failing(t *testing.T) {
 
 }
 
+// positiveMatcher is an ArgumentMatcher matching positive ints.
+type positiveMatcher struct{}
+
+func (positiveMatcher) Matches(argument interface{}) bool {
+	n, ok := argument.(int)
+	return ok && n > 0
+}
+
+func (positiveMatcher) String() string {
+	return "positive int"
+}
+
+func Test_Arguments_Diff_WithArgumentMatcher(t *testing.T) {
+
+	var args Arguments = []interface{}{"string", positiveMatcher{}, true}
+	var count int
+	var diff string
+	_, count = args.Diff([]interface{}{"string", 123, true})
+
+	assert.Equal(t, 0, count)
+
+	diff, count = args.Diff([]interface{}{"string", -1, true})
+
+	assert.Equal(t, 1, count)
+	assert.Contains(t, diff, "not matched by positive int")
+
+}
+
+func Test_Mock_On_WithArgumentMatcher(t *testing.T) {
+
+	var mockedService = new(TestExampleImplementation)
+
+	mockedService.On("TheExampleMethod", 1, positiveMatcher{}, 3).Return(0, nil)
+
+	assert.Panics(t, func() {
+		mockedService.TheExampleMethod(1, -2, 3)
+	})
+	assert.NotPanics(t, func() {
+		mockedService.TheExampleMethod(1, 2, 3)
+	})
+	mockedService.AssertCalled(t, "TheExampleMethod", 1, positiveMatcher{}, 3)
+
+}
+
 func Test_Arguments_Assert(t *testing.T) {
 
 	var args Arguments = []interface{}{"string", 123, true}
@@ -987,3 +1032,27 @@ func Test_Arguments_Bool(t *testing.T) {
 	assert.Equal(t, true, args.Bool(2))
 
 }
+
+func Test_Mock_Called_blocks_on_clock(t *testing.T) {
+
+	var mockedService *TestExampleImplementation = new(TestExampleImplementation)
+	fake := clock.NewFake(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	mockedService.Mock.SetClock(fake)
+
+	mockedService.Mock.On("asyncCall", 1, 2, 3).Return(5, "6", true).After(time.Hour)
+
+	ch := make(chan Arguments)
+
+	go asyncCall(&mockedService.Mock, ch)
+
+	fake.Advance(time.Hour - time.Nanosecond)
+	select {
+	case <-ch:
+		t.Fatal("should have waited")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	fake.Advance(time.Nanosecond)
+	returnArguments := <-ch
+	assert.Equal(t, Arguments{5, "6", true}, returnArguments)
+}
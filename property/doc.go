@@ -0,0 +1,48 @@
+// Package property provides property-based testing: asserting that a
+// function holds for many random arguments, and reporting the simplest
+// arguments it does not hold for.
+//
+// Example Usage
+//
+// A property is a function returning whether it holds for its arguments,
+// which are generated from their types:
+//
+//    func TestReverse(t *testing.T) {
+//      property.Check(t, func(s []int) bool {
+//        return reflect.DeepEqual(s, Reverse(Reverse(s)))
+//      })
+//    }
+//
+// or a function making assertions on them, through the TestingT it is
+// given first:
+//
+//    func TestSort(t *testing.T) {
+//      property.Check(t, func(t assert.TestingT, s []int) {
+//        sorted := Sort(s)
+//        assert.Len(t, sorted, len(s))
+//        assert.IsSorted(t, sorted, nil)
+//      })
+//    }
+//
+// Generators generate the arguments otherwise, and are combined with
+// OneOf, Map and Filter:
+//
+//    property.CheckWith(t, property.Options{
+//      Generators: []property.Generator{
+//        property.Struct(User{}, map[string]property.Generator{
+//          "Email": property.Regex(`[a-z]{1,8}@example\.(com|org)`),
+//          "Age":   property.Int(0, 150),
+//        }),
+//      },
+//    }, func(t assert.TestingT, user User) {
+//      assert.NoError(t, user.Validate())
+//    })
+//
+// When a property fails, its arguments are shrunk to the simplest ones
+// that still make it fail, such as shorter slices and smaller numbers,
+// and the failure reports them with the failures of the assertions.  The
+// failure also reports the seed of the random arguments, which running
+// the tests with -testify.seed, or with $TESTIFY_SEED set, reuses to try
+// the same arguments again.  The number of arguments tried is set by
+// -testify.runs, or $TESTIFY_RUNS, and is 100 by default.
+package property
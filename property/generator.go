@@ -0,0 +1,657 @@
+package property
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"sync"
+)
+
+// Generator generates random values of a type, and the simpler values
+// that a failing value is shrunk to.  Generators are built with the
+// functions of this package, and combined with OneOf, Map and Filter.
+type Generator struct {
+	typ      reflect.Type
+	generate func(r *rand.Rand, size int) sample
+}
+
+// sample is a generated value, with the simpler values it shrinks to.
+type sample struct {
+	value   reflect.Value
+	shrinks func() []sample
+}
+
+// noShrinks is the shrinks of the samples that cannot be simpler.
+func noShrinks() []sample {
+	return nil
+}
+
+// Type returns the type of the generated values.
+func (g Generator) Type() reflect.Type {
+	return g.typ
+}
+
+// filterSamples returns the samples whose value satisfies keep, with their
+// own shrinks filtered the same way.
+func filterSamples(samples []sample, keep func(reflect.Value) bool) []sample {
+	kept := []sample{}
+	for _, s := range samples {
+		if keep(s.value) {
+			kept = append(kept, filteredSample(s, keep))
+		}
+	}
+	return kept
+}
+
+func filteredSample(s sample, keep func(reflect.Value) bool) sample {
+	shrinks := s.shrinks
+	return sample{s.value, func() []sample { return filterSamples(shrinks(), keep) }}
+}
+
+// Just returns a Generator always generating the specified value.
+//
+//  property.Just(42)
+func Just(value interface{}) Generator {
+	if value == nil {
+		panic("property: Just(nil) has no type")
+	}
+	v := reflect.ValueOf(value)
+	return Generator{v.Type(), func(*rand.Rand, int) sample {
+		return sample{v, noShrinks}
+	}}
+}
+
+// Bool returns a Generator of booleans, shrinking to false.
+func Bool() Generator {
+	return boolGenerator(reflect.TypeOf(false))
+}
+
+func boolGenerator(typ reflect.Type) Generator {
+	return Generator{typ, func(r *rand.Rand, size int) sample {
+		v := reflect.New(typ).Elem()
+		v.SetBool(r.Intn(2) == 1)
+		return sample{v, func() []sample {
+			if !v.Bool() {
+				return nil
+			}
+			return []sample{{reflect.Zero(typ), noShrinks}}
+		}}
+	}}
+}
+
+// Int returns a Generator of ints between min and max inclusive, which
+// shrinks them toward zero, or toward the bound nearest to zero if zero
+// is out of range.
+//
+//  property.Int(1, 6)
+func Int(min, max int) Generator {
+	if min > max {
+		panic(fmt.Sprintf("property: Int(%d, %d) has an empty range", min, max))
+	}
+	return Generator{reflect.TypeOf(0), func(r *rand.Rand, size int) sample {
+		return intSample(reflect.TypeOf(0), randomInt(r, int64(min), int64(max)), int64(min), int64(max))
+	}}
+}
+
+// randomInt returns an int64 between min and max inclusive, picking one
+// of the bounds now and then as values at the edges are often mishandled.
+func randomInt(r *rand.Rand, min, max int64) int64 {
+	switch r.Intn(10) {
+	case 0:
+		return min
+	case 1:
+		return max
+	}
+	span := uint64(max - min)
+	if span == math.MaxUint64 {
+		return int64(r.Uint64())
+	}
+	return min + int64(r.Uint64()%(span+1))
+}
+
+// intSample returns a sample of an integer type holding x, shrinking
+// toward zero within min and max.
+func intSample(typ reflect.Type, x, min, max int64) sample {
+	v := reflect.New(typ).Elem()
+	v.SetInt(x)
+	target := clampInt(0, min, max)
+	return sample{v, func() []sample {
+		shrinks := []sample{}
+		// The target first, and then values ever closer to x.
+		for d := x - target; d != 0; d /= 2 {
+			shrinks = append(shrinks, intSample(typ, x-d, min, max))
+		}
+		return shrinks
+	}}
+}
+
+func clampInt(x, min, max int64) int64 {
+	if x < min {
+		return min
+	}
+	if x > max {
+		return max
+	}
+	return x
+}
+
+// uintSample returns a sample of an unsigned integer type holding x,
+// shrinking toward zero.
+func uintSample(typ reflect.Type, x uint64) sample {
+	v := reflect.New(typ).Elem()
+	v.SetUint(x)
+	return sample{v, func() []sample {
+		shrinks := []sample{}
+		for d := x; d > 0; d /= 2 {
+			shrinks = append(shrinks, uintSample(typ, x-d))
+		}
+		return shrinks
+	}}
+}
+
+// Float64 returns a Generator of float64s between min and max inclusive,
+// shrinking toward zero, or toward the bound nearest to zero, and toward
+// whole numbers.
+//
+//  property.Float64(0, 1)
+func Float64(min, max float64) Generator {
+	if !(min <= max) {
+		panic(fmt.Sprintf("property: Float64(%v, %v) has an empty range", min, max))
+	}
+	typ := reflect.TypeOf(0.0)
+	return Generator{typ, func(r *rand.Rand, size int) sample {
+		return floatSample(typ, randomFloat(r, min, max), min, max)
+	}}
+}
+
+func randomFloat(r *rand.Rand, min, max float64) float64 {
+	switch r.Intn(10) {
+	case 0:
+		return min
+	case 1:
+		return max
+	}
+	x := min + r.Float64()*(max-min)
+	if math.IsInf(x, 0) {
+		// The span of the range overflows.
+		x = min/2 + r.Float64()*(max/2-min/2)*2
+	}
+	return math.Max(min, math.Min(max, x))
+}
+
+// floatSample returns a sample of a floating point type holding x,
+// shrinking toward zero within min and max.
+func floatSample(typ reflect.Type, x, min, max float64) sample {
+	v := reflect.New(typ).Elem()
+	v.SetFloat(x)
+	target := math.Max(min, math.Min(max, 0))
+	return sample{v, func() []sample {
+		if x == target || math.IsNaN(x) {
+			return nil
+		}
+		shrinks := []sample{floatSample(typ, target, min, max)}
+		if whole := math.Trunc(x); whole != x && whole != target && whole >= min && whole <= max {
+			shrinks = append(shrinks, floatSample(typ, whole, min, max))
+		}
+		if half := target + (x-target)/2; half != x && half != target {
+			shrinks = append(shrinks, floatSample(typ, half, min, max))
+		}
+		return shrinks
+	}}
+}
+
+// String returns a Generator of strings, which are mostly made of
+// printable ASCII characters, shrinking toward shorter strings of a's.
+func String() Generator {
+	return stringGenerator(reflect.TypeOf(""))
+}
+
+func stringGenerator(typ reflect.Type) Generator {
+	return Generator{typ, func(r *rand.Rand, size int) sample {
+		runes := make([]rune, r.Intn(size+1))
+		for i := range runes {
+			runes[i] = randomRune(r)
+		}
+		return stringSample(typ, runes)
+	}}
+}
+
+func randomRune(r *rand.Rand) rune {
+	if r.Intn(20) == 0 {
+		// A character of the Basic Multilingual Plane, above Latin-1.
+		return rune(0x100 + r.Intn(0xD800-0x100))
+	}
+	return rune(' ' + r.Intn('~'-' '+1))
+}
+
+// stringSample returns a sample of a string type holding runes, shrinking
+// by removing runes and then by replacing them with a's.
+func stringSample(typ reflect.Type, runes []rune) sample {
+	v := reflect.New(typ).Elem()
+	v.SetString(string(runes))
+	return sample{v, func() []sample {
+		shrinks := []sample{}
+		for _, kept := range removals(len(runes)) {
+			shorter := make([]rune, 0, len(kept))
+			for _, i := range kept {
+				shorter = append(shorter, runes[i])
+			}
+			shrinks = append(shrinks, stringSample(typ, shorter))
+		}
+		for i, c := range runes {
+			if c != 'a' {
+				simpler := append([]rune{}, runes...)
+				simpler[i] = 'a'
+				shrinks = append(shrinks, stringSample(typ, simpler))
+			}
+		}
+		return shrinks
+	}}
+}
+
+// removals returns the indexes kept by removing chunks from a sequence of
+// n elements, removing everything first and then ever smaller chunks.
+func removals(n int) [][]int {
+	kept := [][]int{}
+	for chunk := n; chunk > 0; chunk /= 2 {
+		for start := 0; start < n; start += chunk {
+			indexes := make([]int, 0, n-chunk)
+			for i := 0; i < n; i++ {
+				if i < start || i >= start+chunk {
+					indexes = append(indexes, i)
+				}
+			}
+			kept = append(kept, indexes)
+		}
+	}
+	return kept
+}
+
+// SliceOf returns a Generator of slices of the values of elem, whose
+// length grows with the size of the run, shrinking by removing elements
+// and then by shrinking them.  The elements are generated with half the
+// size, so that nested slices stay small.
+//
+//  property.SliceOf(property.Int(0, 100))
+func SliceOf(elem Generator) Generator {
+	return sliceGenerator(reflect.SliceOf(elem.typ), elem)
+}
+
+func sliceGenerator(typ reflect.Type, elem Generator) Generator {
+	return Generator{typ, func(r *rand.Rand, size int) sample {
+		elems := make([]sample, r.Intn(size+1))
+		for i := range elems {
+			elems[i] = elem.generate(r, size/2)
+		}
+		return sliceSample(typ, elems)
+	}}
+}
+
+func sliceSample(typ reflect.Type, elems []sample) sample {
+	v := reflect.MakeSlice(typ, len(elems), len(elems))
+	for i, elem := range elems {
+		v.Index(i).Set(elem.value)
+	}
+	return sample{v, func() []sample {
+		shrinks := []sample{}
+		for _, kept := range removals(len(elems)) {
+			fewer := make([]sample, 0, len(kept))
+			for _, i := range kept {
+				fewer = append(fewer, elems[i])
+			}
+			shrinks = append(shrinks, sliceSample(typ, fewer))
+		}
+		for i, elem := range elems {
+			for _, simpler := range elem.shrinks() {
+				replaced := append([]sample{}, elems...)
+				replaced[i] = simpler
+				shrinks = append(shrinks, sliceSample(typ, replaced))
+			}
+		}
+		return shrinks
+	}}
+}
+
+// arraySample returns a sample of an array type holding elems, shrinking
+// each of them in turn.
+func arraySample(typ reflect.Type, elems []sample) sample {
+	v := reflect.New(typ).Elem()
+	for i, elem := range elems {
+		v.Index(i).Set(elem.value)
+	}
+	return sample{v, func() []sample {
+		shrinks := []sample{}
+		for i, elem := range elems {
+			for _, simpler := range elem.shrinks() {
+				replaced := append([]sample{}, elems...)
+				replaced[i] = simpler
+				shrinks = append(shrinks, arraySample(typ, replaced))
+			}
+		}
+		return shrinks
+	}}
+}
+
+// MapOf returns a Generator of maps from the values of key to those of
+// value, whose length grows with the size of the run, shrinking by
+// removing entries and then by shrinking their values.  The entries are
+// generated with half the size.
+//
+//  property.MapOf(property.String(), property.Int(0, 100))
+func MapOf(key, value Generator) Generator {
+	return mapGenerator(reflect.MapOf(key.typ, value.typ), key, value)
+}
+
+func mapGenerator(typ reflect.Type, key, value Generator) Generator {
+	return Generator{typ, func(r *rand.Rand, size int) sample {
+		n := r.Intn(size + 1)
+		keys := reflect.MakeMap(reflect.MapOf(typ.Key(), typ.Key()))
+		entries := [][2]sample{}
+		for i := 0; i < n; i++ {
+			k := key.generate(r, size/2)
+			if keys.MapIndex(k.value).IsValid() {
+				continue
+			}
+			keys.SetMapIndex(k.value, k.value)
+			entries = append(entries, [2]sample{k, value.generate(r, size/2)})
+		}
+		return mapSample(typ, entries)
+	}}
+}
+
+func mapSample(typ reflect.Type, entries [][2]sample) sample {
+	v := reflect.MakeMap(typ)
+	for _, entry := range entries {
+		v.SetMapIndex(entry[0].value, entry[1].value)
+	}
+	return sample{v, func() []sample {
+		shrinks := []sample{}
+		for _, kept := range removals(len(entries)) {
+			fewer := make([][2]sample, 0, len(kept))
+			for _, i := range kept {
+				fewer = append(fewer, entries[i])
+			}
+			shrinks = append(shrinks, mapSample(typ, fewer))
+		}
+		for i, entry := range entries {
+			for _, simpler := range entry[1].shrinks() {
+				replaced := append([][2]sample{}, entries...)
+				replaced[i] = [2]sample{entry[0], simpler}
+				shrinks = append(shrinks, mapSample(typ, replaced))
+			}
+		}
+		return shrinks
+	}}
+}
+
+// pointerGenerator returns a Generator of pointers to the values of elem,
+// which are nil now and then, and always once the size is down to zero so
+// that recursive types are finite.  The elements are generated with half
+// the size.
+func pointerGenerator(typ reflect.Type, elem func() Generator) Generator {
+	return Generator{typ, func(r *rand.Rand, size int) sample {
+		if size == 0 || r.Intn(10) == 0 {
+			return sample{reflect.Zero(typ), noShrinks}
+		}
+		return pointerSample(typ, elem().generate(r, size/2))
+	}}
+}
+
+func pointerSample(typ reflect.Type, elem sample) sample {
+	v := reflect.New(typ.Elem())
+	v.Elem().Set(elem.value)
+	return sample{v, func() []sample {
+		shrinks := []sample{{reflect.Zero(typ), noShrinks}}
+		for _, simpler := range elem.shrinks() {
+			shrinks = append(shrinks, pointerSample(typ, simpler))
+		}
+		return shrinks
+	}}
+}
+
+// Struct returns a Generator of structs of the type of example, whose
+// exported fields are generated by the Generator of the same name in
+// fields, or by Any for the type of the field otherwise.  Unexported
+// fields are left to their zero value.  The structs shrink by shrinking
+// their fields in turn.
+//
+//  property.Struct(User{}, map[string]property.Generator{
+//    "Email": property.Regex(`[a-z]+@example\.com`),
+//  })
+func Struct(example interface{}, fields map[string]Generator) Generator {
+	typ := reflect.TypeOf(example)
+	if typ == nil || typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("property: Struct(%T) needs a struct", example))
+	}
+	for name, g := range fields {
+		field, ok := typ.FieldByName(name)
+		if !ok || field.PkgPath != "" {
+			panic(fmt.Sprintf("property: %s has no exported field %s", typ, name))
+		}
+		if !g.typ.AssignableTo(field.Type) {
+			panic(fmt.Sprintf("property: field %s of %s is a %s, not a %s", name, typ, field.Type, g.typ))
+		}
+	}
+	return structGenerator(typ, fields)
+}
+
+func structGenerator(typ reflect.Type, fields map[string]Generator) Generator {
+	var once sync.Once
+	generators := make([]Generator, typ.NumField())
+	build := func() {
+		for i := range generators {
+			field := typ.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			if g, ok := fields[field.Name]; ok {
+				generators[i] = g
+			} else {
+				generators[i] = forType(field.Type)
+			}
+		}
+	}
+	return Generator{typ, func(r *rand.Rand, size int) sample {
+		once.Do(build)
+		values := make([]sample, len(generators))
+		for i, g := range generators {
+			if g.generate == nil {
+				values[i] = sample{reflect.Zero(typ.Field(i).Type), noShrinks}
+			} else {
+				values[i] = g.generate(r, size)
+			}
+		}
+		return structSample(typ, values)
+	}}
+}
+
+func structSample(typ reflect.Type, fields []sample) sample {
+	v := reflect.New(typ).Elem()
+	for i, field := range fields {
+		if typ.Field(i).PkgPath == "" {
+			v.Field(i).Set(field.value)
+		}
+	}
+	return sample{v, func() []sample {
+		shrinks := []sample{}
+		for i, field := range fields {
+			for _, simpler := range field.shrinks() {
+				replaced := append([]sample{}, fields...)
+				replaced[i] = simpler
+				shrinks = append(shrinks, structSample(typ, replaced))
+			}
+		}
+		return shrinks
+	}}
+}
+
+// Any returns a Generator of values of the type of example, built by
+// reflection: booleans, numbers and strings as by Bool, Int, Float64 and
+// String over the whole range of the type, and slices, arrays, maps,
+// pointers and structs of such values.  It panics for the other types,
+// such as channels, functions and interfaces.
+//
+//  property.Any(map[string][]int{})
+func Any(example interface{}) Generator {
+	typ := reflect.TypeOf(example)
+	if typ == nil {
+		panic("property: Any(nil) has no type")
+	}
+	return forType(typ)
+}
+
+// forType returns the Generator of Any for the type.
+func forType(typ reflect.Type) Generator {
+	switch typ.Kind() {
+	case reflect.Bool:
+		return boolGenerator(typ)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		max := int64(1)<<uint(typ.Bits()-1) - 1
+		return Generator{typ, func(r *rand.Rand, size int) sample {
+			return intSample(typ, randomSized(r, size, -max-1, max), -max-1, max)
+		}}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		max := uint64(math.MaxUint64) >> uint(64-typ.Bits())
+		return Generator{typ, func(r *rand.Rand, size int) sample {
+			var x uint64
+			switch r.Intn(4) {
+			case 0:
+				x = r.Uint64() & max
+			case 1:
+				x = []uint64{0, 1, max}[r.Intn(3)]
+			default:
+				x = uint64(r.Intn(size+1)) & max
+			}
+			return uintSample(typ, x)
+		}}
+	case reflect.Float32, reflect.Float64:
+		max := math.MaxFloat64
+		if typ.Kind() == reflect.Float32 {
+			max = math.MaxFloat32
+		}
+		return Generator{typ, func(r *rand.Rand, size int) sample {
+			var x float64
+			if r.Intn(4) == 0 {
+				x = randomFloat(r, -max, max)
+			} else {
+				x = (r.Float64()*2 - 1) * float64(size)
+			}
+			if typ.Kind() == reflect.Float32 {
+				x = float64(float32(x))
+			}
+			return floatSample(typ, x, -max, max)
+		}}
+	case reflect.String:
+		return stringGenerator(typ)
+	case reflect.Slice:
+		return sliceGenerator(typ, forType(typ.Elem()))
+	case reflect.Array:
+		elem := forType(typ.Elem())
+		return Generator{typ, func(r *rand.Rand, size int) sample {
+			elems := make([]sample, typ.Len())
+			for i := range elems {
+				elems[i] = elem.generate(r, size/2)
+			}
+			return arraySample(typ, elems)
+		}}
+	case reflect.Map:
+		return mapGenerator(typ, forType(typ.Key()), forType(typ.Elem()))
+	case reflect.Ptr:
+		var once sync.Once
+		var elem Generator
+		// The element is built lazily, as it may be the type itself.
+		return pointerGenerator(typ, func() Generator {
+			once.Do(func() { elem = forType(typ.Elem()) })
+			return elem
+		})
+	case reflect.Struct:
+		return structGenerator(typ, nil)
+	}
+	panic(fmt.Sprintf("property: cannot generate values of type %s", typ))
+}
+
+// randomSized returns an integer between min and max, which is mostly
+// between -size and size, and otherwise anywhere in the range or at its
+// edges.
+func randomSized(r *rand.Rand, size int, min, max int64) int64 {
+	switch r.Intn(4) {
+	case 0:
+		return randomInt(r, min, max)
+	case 1:
+		return clampInt([]int64{0, 1, -1}[r.Intn(3)], min, max)
+	}
+	return clampInt(int64(r.Intn(2*size+1)-size), min, max)
+}
+
+// OneOf returns a Generator picking one of the specified generators for
+// each value.  The generators must generate values of the same type.
+//
+//  property.OneOf(property.Just(0), property.Int(1, 9), property.Int(100, 999))
+func OneOf(generators ...Generator) Generator {
+	if len(generators) == 0 {
+		panic("property: OneOf needs at least one generator")
+	}
+	typ := generators[0].typ
+	for _, g := range generators[1:] {
+		if g.typ != typ {
+			panic(fmt.Sprintf("property: OneOf mixes generators of %s and %s", typ, g.typ))
+		}
+	}
+	return Generator{typ, func(r *rand.Rand, size int) sample {
+		return generators[r.Intn(len(generators))].generate(r, size)
+	}}
+}
+
+// Map returns a Generator of the results of f, a function of one argument
+// of the type of the values of g.  The values shrink as the arguments of
+// f do.
+//
+//  even := property.Map(property.Int(0, 100), func(x int) int { return 2 * x })
+func Map(g Generator, f interface{}) Generator {
+	fn := reflect.ValueOf(f)
+	if fn.Kind() != reflect.Func || fn.Type().NumIn() != 1 || fn.Type().NumOut() != 1 || !g.typ.AssignableTo(fn.Type().In(0)) {
+		panic(fmt.Sprintf("property: Map needs a func(%s) of one result, not %T", g.typ, f))
+	}
+	var mapped func(s sample) sample
+	mapped = func(s sample) sample {
+		return sample{fn.Call([]reflect.Value{s.value})[0], func() []sample {
+			shrinks := []sample{}
+			for _, simpler := range s.shrinks() {
+				shrinks = append(shrinks, mapped(simpler))
+			}
+			return shrinks
+		}}
+	}
+	return Generator{fn.Type().Out(0), func(r *rand.Rand, size int) sample {
+		return mapped(g.generate(r, size))
+	}}
+}
+
+// maxFilterTries is how many values in a row Filter can reject before
+// giving up.
+const maxFilterTries = 100
+
+// Filter returns a Generator of the values of g satisfying predicate, a
+// function of one argument returning a bool.  It panics if the predicate
+// rejects too many values in a row, as generating values that satisfy it
+// by construction, with Map, is better then.
+//
+//  odd := property.Filter(property.Int(0, 100), func(x int) bool { return x%2 == 1 })
+func Filter(g Generator, predicate interface{}) Generator {
+	fn := reflect.ValueOf(predicate)
+	if fn.Kind() != reflect.Func || fn.Type().NumIn() != 1 || fn.Type().NumOut() != 1 || fn.Type().Out(0).Kind() != reflect.Bool || !g.typ.AssignableTo(fn.Type().In(0)) {
+		panic(fmt.Sprintf("property: Filter needs a func(%s) bool, not %T", g.typ, predicate))
+	}
+	keep := func(v reflect.Value) bool {
+		return fn.Call([]reflect.Value{v})[0].Bool()
+	}
+	return Generator{g.typ, func(r *rand.Rand, size int) sample {
+		for i := 0; i < maxFilterTries; i++ {
+			if s := g.generate(r, size); keep(s.value) {
+				return filteredSample(s, keep)
+			}
+		}
+		panic(fmt.Sprintf("property: Filter rejected %d values of %s in a row", maxFilterTries, g.typ))
+	}}
+}
@@ -0,0 +1,190 @@
+package property
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// generate returns n values of the generator, with growing sizes.
+func generate(g Generator, n int) []interface{} {
+	r := rand.New(rand.NewSource(1))
+	values := make([]interface{}, n)
+	for i := range values {
+		values[i] = g.generate(r, i*defaultMaxSize/n).value.Interface()
+	}
+	return values
+}
+
+// shrinkValues returns the values of the shrinks of the sample of value.
+func shrinkValues(s sample) []interface{} {
+	values := []interface{}{}
+	for _, simpler := range s.shrinks() {
+		values = append(values, simpler.value.Interface())
+	}
+	return values
+}
+
+func TestInt(t *testing.T) {
+	seen := map[int]bool{}
+	for _, v := range generate(Int(-3, 3), 200) {
+		x := v.(int)
+		assert.True(t, x >= -3 && x <= 3)
+		seen[x] = true
+	}
+	assert.Len(t, seen, 7)
+
+	assert.Panics(t, func() { Int(1, 0) })
+}
+
+func TestIntShrinks(t *testing.T) {
+	typ := reflect.TypeOf(0)
+	assert.Equal(t, []interface{}{0, 50, 75, 88, 94, 97, 99}, shrinkValues(intSample(typ, 100, -1000, 1000)))
+	assert.Equal(t, []interface{}{0, -2, -3}, shrinkValues(intSample(typ, -4, -1000, 1000)))
+	assert.Equal(t, []interface{}{5, 8, 9}, shrinkValues(intSample(typ, 10, 5, 1000)), "toward the bound nearest to zero")
+	assert.Empty(t, shrinkValues(intSample(typ, 0, -1000, 1000)))
+	assert.Equal(t, []interface{}{uint8(0), uint8(2), uint8(3)}, shrinkValues(uintSample(reflect.TypeOf(uint8(0)), 4)))
+}
+
+func TestFloat64(t *testing.T) {
+	for _, v := range generate(Float64(0.5, 1), 100) {
+		x := v.(float64)
+		assert.True(t, x >= 0.5 && x <= 1)
+	}
+	typ := reflect.TypeOf(0.0)
+	assert.Equal(t, []interface{}{0.0, 2.0, 1.25}, shrinkValues(floatSample(typ, 2.5, -10, 10)))
+	assert.Equal(t, []interface{}{0.5, 0.75}, shrinkValues(floatSample(typ, 1, 0.5, 1)))
+}
+
+func TestString(t *testing.T) {
+	for _, v := range generate(String(), 100) {
+		assert.True(t, len([]rune(v.(string))) <= defaultMaxSize)
+	}
+	s := stringSample(reflect.TypeOf(""), []rune("abc"))
+	assert.Equal(t, []interface{}{"", "bc", "ac", "ab", "aac", "aba"}, shrinkValues(s))
+}
+
+func TestSliceOf(t *testing.T) {
+	g := SliceOf(Int(0, 9))
+	assert.Equal(t, reflect.TypeOf([]int{}), g.Type())
+	values := generate(g, 100)
+	assert.Empty(t, values[0], "the first run has size zero")
+	for _, v := range values {
+		for _, x := range v.([]int) {
+			assert.True(t, x >= 0 && x <= 9)
+		}
+	}
+
+	typ := reflect.TypeOf([]int{})
+	s := sliceSample(typ, []sample{intSample(reflect.TypeOf(0), 2, 0, 9), intSample(reflect.TypeOf(0), 3, 0, 9)})
+	assert.Equal(t, []interface{}{[]int{}, []int{3}, []int{2}, []int{0, 3}, []int{1, 3}, []int{2, 0}, []int{2, 2}}, shrinkValues(s))
+}
+
+func TestMapOf(t *testing.T) {
+	g := MapOf(String(), Bool())
+	assert.Equal(t, reflect.TypeOf(map[string]bool{}), g.Type())
+	r := rand.New(rand.NewSource(1))
+	s := g.generate(r, 10)
+	for s.value.Len() == 0 {
+		s = g.generate(r, 10)
+	}
+	shrinks := s.shrinks()
+	assert.Equal(t, 0, shrinks[0].value.Len(), "removing all the entries first")
+}
+
+type node struct {
+	Value    int
+	Children []*node
+	Next     *node
+	hidden   string
+}
+
+func TestAny(t *testing.T) {
+	values := generate(Any(map[string][2]uint16{}), 20)
+	assert.IsType(t, map[string][2]uint16{}, values[0])
+
+	for _, v := range generate(Any(&node{}), 50) {
+		if v.(*node) != nil {
+			assert.Empty(t, v.(*node).hidden, "unexported fields are not generated")
+		}
+	}
+
+	for _, example := range []interface{}{int8(0), uint32(0), float32(0), "", false} {
+		g := Any(example)
+		assert.Equal(t, reflect.TypeOf(example), g.Type())
+		generate(g, 100)
+	}
+
+	assert.Panics(t, func() { Any(make(chan int)) })
+	assert.Panics(t, func() { Any(nil) })
+}
+
+func TestPointerShrinksToNil(t *testing.T) {
+	typ := reflect.TypeOf(&node{})
+	s := pointerSample(typ, structSample(typ.Elem(), []sample{
+		intSample(reflect.TypeOf(0), 1, -10, 10),
+		{reflect.Zero(reflect.TypeOf([]*node{})), noShrinks},
+		{reflect.Zero(typ), noShrinks},
+		{reflect.Zero(reflect.TypeOf("")), noShrinks},
+	}))
+	assert.Equal(t, []interface{}{(*node)(nil), &node{}}, shrinkValues(s))
+}
+
+type user struct {
+	Name string
+	Age  int
+}
+
+func TestStruct(t *testing.T) {
+	g := Struct(user{}, map[string]Generator{"Age": Int(18, 30)})
+	for _, v := range generate(g, 50) {
+		u := v.(user)
+		assert.True(t, u.Age >= 18 && u.Age <= 30)
+	}
+
+	assert.Panics(t, func() { Struct(0, nil) })
+	assert.Panics(t, func() { Struct(user{}, map[string]Generator{"Email": String()}) })
+	assert.Panics(t, func() { Struct(user{}, map[string]Generator{"Age": String()}) })
+}
+
+func TestOneOf(t *testing.T) {
+	seen := map[int]bool{}
+	for _, v := range generate(OneOf(Just(1), Just(2), Int(10, 11)), 100) {
+		seen[v.(int)] = true
+	}
+	assert.Equal(t, map[int]bool{1: true, 2: true, 10: true, 11: true}, seen)
+
+	assert.Panics(t, func() { OneOf() })
+	assert.Panics(t, func() { OneOf(Just(1), Just("1")) })
+}
+
+func TestMap(t *testing.T) {
+	g := Map(Int(0, 100), func(x int) string { return string(rune('a' + x%26)) })
+	assert.Equal(t, reflect.TypeOf(""), g.Type())
+
+	even := Map(Int(0, 100), func(x int) int { return 2 * x })
+	s := even.generate(rand.New(rand.NewSource(1)), 10)
+	for _, simpler := range shrinkValues(s) {
+		assert.Equal(t, 0, simpler.(int)%2, "the shrinks are mapped too")
+	}
+
+	assert.Panics(t, func() { Map(Int(0, 1), func(s string) string { return s }) })
+	assert.Panics(t, func() { Map(Int(0, 1), 42) })
+}
+
+func TestFilter(t *testing.T) {
+	odd := Filter(Int(0, 100), func(x int) bool { return x%2 == 1 })
+	for _, v := range generate(odd, 100) {
+		assert.Equal(t, 1, v.(int)%2)
+	}
+	s := odd.generate(rand.New(rand.NewSource(1)), 10)
+	for _, simpler := range shrinkValues(s) {
+		assert.Equal(t, 1, simpler.(int)%2, "the shrinks are filtered too")
+	}
+
+	never := Filter(Int(0, 100), func(x int) bool { return false })
+	assert.Panics(t, func() { generate(never, 1) })
+	assert.Panics(t, func() { Filter(Int(0, 1), func(x int) int { return x }) })
+}
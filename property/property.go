@@ -0,0 +1,297 @@
+package property
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestingT is an interface wrapper around *testing.T
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+var seedFlag = flag.Int64("testify.seed", envInt64("TESTIFY_SEED", 0), "seed of the values generated by property.Check, random if 0 (defaults to $TESTIFY_SEED)")
+
+var runsFlag = flag.Int("testify.runs", int(envInt64("TESTIFY_RUNS", 100)), "number of values property.Check tries (defaults to $TESTIFY_RUNS or 100)")
+
+// envInt64 returns the integer value of the environment variable, or
+// fallback if it is not set to an integer.
+func envInt64(name string, fallback int64) int64 {
+	if n, err := strconv.ParseInt(os.Getenv(name), 10, 64); err == nil {
+		return n
+	}
+	return fallback
+}
+
+const (
+	// defaultMaxSize is the size of the last run by default.
+	defaultMaxSize = 100
+
+	// maxShrinks is how many times a failing value is shrunk at most.
+	maxShrinks = 1000
+)
+
+// Options configures how CheckWith generates the arguments of a property.
+// The zero value tries the number of values set by -testify.runs, with
+// the seed set by -testify.seed, and the generators of Any.
+type Options struct {
+	// Runs is how many arguments to try.  It defaults to the value of the
+	// -testify.runs flag, which is 100 unless set.
+	Runs int
+
+	// Seed seeds the random generation of the arguments, so that the
+	// same seed tries the same arguments.  It defaults to the value of
+	// the -testify.seed flag, and to a random seed if that is not set.
+	Seed int64
+
+	// MaxSize bounds the lengths of the generated slices, maps and
+	// strings, and the magnitude of the numbers of Any.  The size grows
+	// from zero to MaxSize over the runs, so that the simple arguments
+	// are tried first.  It defaults to 100.
+	MaxSize int
+
+	// Generators generate the arguments of the property, in order.  The
+	// arguments without one, or whose Generator is the zero value, are
+	// generated by Any.
+	Generators []Generator
+}
+
+// failNow is the value recorder.FailNow panics with to stop the property.
+type failNow struct{}
+
+// recorder is the TestingT passed to the properties, recording the
+// failures of their assertions.
+type recorder struct {
+	messages []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) FailNow() {
+	panic(failNow{})
+}
+
+func (r *recorder) Helper() {}
+
+// property is a function checked by Check.
+type property struct {
+	fn reflect.Value
+
+	// withT is whether the first argument of fn is a TestingT.
+	withT bool
+}
+
+// newProperty checks that f is a function that Check can call.
+func newProperty(f interface{}) (property, error) {
+	fn := reflect.ValueOf(f)
+	if fn.Kind() != reflect.Func {
+		return property{}, fmt.Errorf("%T is not a function", f)
+	}
+	typ := fn.Type()
+	p := property{fn: fn}
+	if typ.NumIn() > 0 && typ.In(0).Kind() == reflect.Interface && typ.In(0).NumMethod() > 0 && reflect.TypeOf(&recorder{}).Implements(typ.In(0)) {
+		p.withT = true
+	}
+	if typ.NumOut() > 1 || typ.NumOut() == 1 && typ.Out(0).Kind() != reflect.Bool {
+		return property{}, fmt.Errorf("%s must return a bool or nothing", typ)
+	}
+	if typ.IsVariadic() {
+		return property{}, fmt.Errorf("%s must not be variadic", typ)
+	}
+	return p, nil
+}
+
+// parameters returns the types of the generated arguments.
+func (p property) parameters() []reflect.Type {
+	types := []reflect.Type{}
+	for i := 0; i < p.fn.Type().NumIn(); i++ {
+		if i > 0 || !p.withT {
+			types = append(types, p.fn.Type().In(i))
+		}
+	}
+	return types
+}
+
+// call calls the property with the arguments, and returns the failures it
+// reported, which are empty if it holds.
+func (p property) call(args []sample) (failures []string) {
+	r := &recorder{}
+	values := []reflect.Value{}
+	if p.withT {
+		values = append(values, reflect.ValueOf(r))
+	}
+	for _, arg := range args {
+		values = append(values, arg.value)
+	}
+
+	defer func() {
+		if v := recover(); v != nil {
+			if _, ok := v.(failNow); !ok {
+				r.messages = append(r.messages, fmt.Sprintf("panic: %v", v))
+			}
+			failures = r.messages
+			if len(failures) == 0 {
+				failures = []string{"FailNow was called"}
+			}
+		}
+	}()
+	results := p.fn.Call(values)
+	if len(results) == 1 && !results[0].Bool() {
+		r.messages = append(r.messages, "returned false")
+	}
+	return r.messages
+}
+
+// Check asserts that the property f holds for random arguments, and
+// reports the simplest arguments it finds that it does not hold for.  f
+// is a function of any number of arguments, returning whether it holds:
+//
+//  property.Check(t, func(a, b int) bool {
+//    return Max(a, b) >= a
+//  })
+//
+// or, when its first argument is a TestingT, making assertions:
+//
+//  property.Check(t, func(t assert.TestingT, s []string) {
+//    assert.Equal(t, s, Decode(Encode(s)))
+//  })
+//
+// The first argument can be any interface with the methods of
+// require.TestingT, so that require assertions stop the property.  The
+// other arguments are generated by Any for their type; use CheckWith to
+// generate them otherwise.
+//
+// When the property fails, its arguments are shrunk to simpler ones that
+// still make it fail, and the failure reports the simplest ones with the
+// failures of the assertions for them, and the seed that reproduces them
+// when passed to -testify.seed.
+//
+// Returns whether the assertion was successful (true) or not (false).
+func Check(t TestingT, f interface{}, msgAndArgs ...interface{}) bool {
+	return CheckWith(t, Options{}, f, msgAndArgs...)
+}
+
+// CheckWith asserts that the property f holds for random arguments, as
+// Check does, with the specified options.
+//
+//  property.CheckWith(t, property.Options{
+//    Generators: []property.Generator{property.Regex(`[a-z]+@[a-z]+\.com`)},
+//  }, func(t assert.TestingT, email string) {
+//    assert.NoError(t, Validate(email))
+//  })
+//
+// Returns whether the assertion was successful (true) or not (false).
+func CheckWith(t TestingT, options Options, f interface{}, msgAndArgs ...interface{}) bool {
+	p, err := newProperty(f)
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("Cannot check property: %s", err), msgAndArgs...)
+	}
+
+	generators := []Generator{}
+	for i, typ := range p.parameters() {
+		var g Generator
+		if i < len(options.Generators) {
+			g = options.Generators[i]
+		}
+		if g.generate == nil {
+			g = forType(typ)
+		} else if !g.typ.AssignableTo(typ) {
+			return assert.Fail(t, fmt.Sprintf("Cannot check property: argument %d is a %s, not a %s", i+1, typ, g.typ), msgAndArgs...)
+		}
+		generators = append(generators, g)
+	}
+
+	runs := options.Runs
+	if runs <= 0 {
+		runs = *runsFlag
+	}
+	seed := options.Seed
+	if seed == 0 {
+		seed = *seedFlag
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	maxSize := options.MaxSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxSize
+	}
+
+	r := rand.New(rand.NewSource(seed))
+	for run := 0; run < runs; run++ {
+		size := maxSize
+		if runs > 1 {
+			size = run * maxSize / (runs - 1)
+		}
+		args := make([]sample, len(generators))
+		for i, g := range generators {
+			args[i] = g.generate(r, size)
+		}
+		if failures := p.call(args); len(failures) > 0 {
+			simplest, failures, shrinks := shrink(p, args, failures)
+			return assert.Fail(t, report(run+1, shrinks, seed, args, simplest, failures), msgAndArgs...)
+		}
+	}
+	return true
+}
+
+// shrink replaces the failing arguments with their first shrink that still
+// fails, until none does, and returns the simplest arguments with their
+// failures and the number of shrinks.
+func shrink(p property, args []sample, failures []string) ([]sample, []string, int) {
+	shrinks := 0
+	for shrinks < maxShrinks {
+		shrunk := false
+		for i := 0; i < len(args) && !shrunk; i++ {
+			for _, simpler := range args[i].shrinks() {
+				candidate := append([]sample{}, args...)
+				candidate[i] = simpler
+				if candidateFailures := p.call(candidate); len(candidateFailures) > 0 {
+					args, failures = candidate, candidateFailures
+					shrunk = true
+					shrinks++
+					break
+				}
+			}
+		}
+		if !shrunk {
+			break
+		}
+	}
+	return args, failures, shrinks
+}
+
+// report describes a property failing for the simplest arguments.
+func report(runs, shrinks int, seed int64, original, simplest []sample, failures []string) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "Property failed after %d run(s) and %d shrink(s), with seed %d (rerun with -testify.seed=%d)\n", runs, shrinks, seed, seed)
+	b.WriteString("Counterexample:\n")
+	writeArguments(&b, simplest)
+	if shrinks > 0 {
+		b.WriteString("Original arguments:\n")
+		writeArguments(&b, original)
+	}
+	b.WriteString("Failures:")
+	for _, failure := range failures {
+		b.WriteString("\n\t")
+		b.WriteString(strings.Replace(strings.TrimSpace(strings.Replace(failure, "\r", "", -1)), "\n", "\n\t", -1))
+	}
+	return b.String()
+}
+
+func writeArguments(b *bytes.Buffer, args []sample) {
+	for i, arg := range args {
+		fmt.Fprintf(b, "\t#%d: %#v\n", i+1, arg.value.Interface())
+	}
+}
@@ -0,0 +1,141 @@
+package property
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bufferT is a TestingT recording the messages of failed assertions.
+type bufferT struct {
+	messages []string
+}
+
+func (t *bufferT) Errorf(format string, args ...interface{}) {
+	t.messages = append(t.messages, fmt.Sprintf(format, args...))
+}
+
+func TestCheckHolds(t *testing.T) {
+	runs := 0
+	mockT := new(bufferT)
+	assert.True(t, CheckWith(mockT, Options{Runs: 50}, func(a, b int) bool {
+		runs++
+		return a+b == b+a
+	}))
+	assert.Equal(t, 50, runs)
+
+	assert.True(t, Check(mockT, func(t assert.TestingT, s []string) {
+		assert.Equal(t, len(s), len(append([]string{}, s...)))
+	}))
+	assert.Empty(t, mockT.messages)
+}
+
+func TestCheckShrinksToCounterexample(t *testing.T) {
+	mockT := new(bufferT)
+	assert.False(t, CheckWith(mockT, Options{Seed: 1}, func(s []int) bool {
+		for _, x := range s {
+			if x >= 10 {
+				return false
+			}
+		}
+		return true
+	}))
+	if assert.Len(t, mockT.messages, 1) {
+		assert.Contains(t, mockT.messages[0], "with seed 1 (rerun with -testify.seed=1)")
+		assert.Contains(t, mockT.messages[0], "#1: []int{10}")
+		assert.Contains(t, mockT.messages[0], "returned false")
+	}
+}
+
+func TestCheckReportsAssertionFailures(t *testing.T) {
+	mockT := new(bufferT)
+	assert.False(t, CheckWith(mockT, Options{Seed: 1}, func(t assert.TestingT, a, b int) {
+		assert.True(t, a < 10 || b < 10, "both are large")
+	}))
+	if assert.Len(t, mockT.messages, 1) {
+		assert.Regexp(t, `Counterexample:\n\s*#1: 10\n\s*#2: 10\n`, mockT.messages[0])
+		assert.Contains(t, mockT.messages[0], "both are large")
+		assert.Contains(t, mockT.messages[0], "Original arguments:")
+	}
+}
+
+func TestCheckRequireStopsProperty(t *testing.T) {
+	mockT := new(bufferT)
+	after := false
+	assert.False(t, Check(mockT, func(t require.TestingT, s string) {
+		require.NotContains(t, s, "b")
+		after = true
+	}))
+	if assert.Len(t, mockT.messages, 1) {
+		assert.Contains(t, mockT.messages[0], `#1: "b"`)
+	}
+	assert.True(t, after, "the property runs to the end when it holds")
+}
+
+func TestCheckReportsPanics(t *testing.T) {
+	mockT := new(bufferT)
+	assert.False(t, Check(mockT, func(s []int) bool {
+		return s[0] == s[0]
+	}))
+	if assert.Len(t, mockT.messages, 1) {
+		assert.Contains(t, mockT.messages[0], "#1: []int{}")
+		assert.Contains(t, mockT.messages[0], "panic: runtime error: index out of range")
+	}
+}
+
+func TestCheckIsReproducible(t *testing.T) {
+	generated := func() []string {
+		values := []string{}
+		CheckWith(new(bufferT), Options{Seed: 42, Runs: 20}, func(s string, x float64) bool {
+			values = append(values, fmt.Sprint(s, x))
+			return true
+		})
+		return values
+	}
+	assert.Equal(t, generated(), generated())
+}
+
+func TestCheckWithGenerators(t *testing.T) {
+	mockT := new(bufferT)
+	assert.True(t, CheckWith(mockT, Options{Generators: []Generator{{}, Int(1, 6)}}, func(b bool, x int) bool {
+		return x >= 1 && x <= 6
+	}))
+	assert.Empty(t, mockT.messages)
+}
+
+func TestCheckWithInvalidProperty(t *testing.T) {
+	for _, f := range []interface{}{
+		42,
+		func(x int) int { return x },
+		func(xs ...int) bool { return true },
+	} {
+		mockT := new(bufferT)
+		assert.False(t, Check(mockT, f))
+		if assert.Len(t, mockT.messages, 1) {
+			assert.Contains(t, mockT.messages[0], "Cannot check property")
+		}
+	}
+
+	mockT := new(bufferT)
+	assert.False(t, CheckWith(mockT, Options{Generators: []Generator{String()}}, func(x int) bool { return true }))
+	if assert.Len(t, mockT.messages, 1) {
+		assert.Contains(t, mockT.messages[0], "argument 1 is a int, not a string")
+	}
+}
+
+func TestReportFormatsFailures(t *testing.T) {
+	args := []sample{{reflect.ValueOf("x"), noShrinks}}
+	report := report(3, 0, 7, args, args, []string{"\r\tError:\tfirst\n\r\tline\n"})
+	assert.Equal(t, strings.Join([]string{
+		"Property failed after 3 run(s) and 0 shrink(s), with seed 7 (rerun with -testify.seed=7)",
+		"Counterexample:",
+		`	#1: "x"`,
+		"Failures:",
+		"	Error:	first",
+		"		line",
+	}, "\n"), report)
+}
@@ -0,0 +1,118 @@
+package property
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+	"unicode"
+)
+
+// maxRegexTries is how many strings Regex generates for one value before
+// giving up, when the pattern holds assertions such as \b that the
+// generated strings do not always satisfy.
+const maxRegexTries = 100
+
+// Regex returns a Generator of strings matching the regular expression
+// pattern, in the syntax of the regexp package, as a whole.  Unbounded
+// repetitions such as * and + repeat up to the size of the run.  The
+// strings shrink toward shorter ones that still match.  It panics if the
+// pattern does not compile.
+//
+//  property.Regex(`[a-z]{1,8}@[a-z]+\.(com|org)`)
+func Regex(pattern string) Generator {
+	full := regexp.MustCompile(`^(?:` + pattern + `)$`)
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		panic(fmt.Sprintf("property: Regex(%q): %s", pattern, err))
+	}
+	re = re.Simplify()
+
+	typ := reflect.TypeOf("")
+	keep := func(v reflect.Value) bool {
+		return full.MatchString(v.String())
+	}
+	return Generator{typ, func(r *rand.Rand, size int) sample {
+		for i := 0; i < maxRegexTries; i++ {
+			runes := []rune{}
+			if generateRegex(r, size, re, &runes) && full.MatchString(string(runes)) {
+				return filteredSample(stringSample(typ, runes), keep)
+			}
+		}
+		panic(fmt.Sprintf("property: cannot generate a string matching %q", pattern))
+	}}
+}
+
+// generateRegex appends to runes a random string matched by re, and
+// returns false if re matches nothing.
+func generateRegex(r *rand.Rand, size int, re *syntax.Regexp, runes *[]rune) bool {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return false
+	case syntax.OpLiteral:
+		for _, c := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 && r.Intn(2) == 0 {
+				c = unicode.SimpleFold(c)
+			}
+			*runes = append(*runes, c)
+		}
+	case syntax.OpCharClass:
+		if len(re.Rune) == 0 {
+			return false
+		}
+		*runes = append(*runes, randomClassRune(r, re.Rune))
+	case syntax.OpAnyCharNotNL:
+		c := randomRune(r)
+		for c == '\n' {
+			c = randomRune(r)
+		}
+		*runes = append(*runes, c)
+	case syntax.OpAnyChar:
+		*runes = append(*runes, randomRune(r))
+	case syntax.OpCapture:
+		return generateRegex(r, size, re.Sub[0], runes)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		min, max := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			min, max = 0, -1
+		case syntax.OpPlus:
+			min, max = 1, -1
+		case syntax.OpQuest:
+			min, max = 0, 1
+		}
+		if max < 0 {
+			max = min + size
+		}
+		for n := min + r.Intn(max-min+1); n > 0; n-- {
+			if !generateRegex(r, size, re.Sub[0], runes) {
+				return false
+			}
+		}
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !generateRegex(r, size, sub, runes) {
+				return false
+			}
+		}
+	case syntax.OpAlternate:
+		return generateRegex(r, size, re.Sub[r.Intn(len(re.Sub))], runes)
+	}
+	// The empty matches and the assertions, such as ^ and \b, match
+	// without adding anything.
+	return true
+}
+
+// randomClassRune returns a random rune of a character class, given as
+// pairs of inclusive bounds, picking the ranges with equal probability so
+// that [a-z0-9] does not only give letters.
+func randomClassRune(r *rand.Rand, ranges []rune) rune {
+	i := 2 * r.Intn(len(ranges)/2)
+	lo, hi := ranges[i], ranges[i+1]
+	if lo <= '~' && hi > '~' && r.Intn(4) != 0 {
+		// Mostly ASCII for the negated classes, such as [^a].
+		hi = '~'
+	}
+	return lo + rune(r.Intn(int(hi-lo)+1))
+}
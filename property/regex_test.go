@@ -0,0 +1,38 @@
+package property
+
+import (
+	"math/rand"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegex(t *testing.T) {
+	for _, pattern := range []string{
+		`[a-z]{1,8}@[a-z]+\.(com|org)`,
+		`\d{3}-\d{4}`,
+		`(?i)hello`,
+		`[^a-z]*`,
+		`a|b|`,
+		`\bword\b x?`,
+		`.+\n?`,
+	} {
+		re := regexp.MustCompile(`^(?:` + pattern + `)$`)
+		for _, v := range generate(Regex(pattern), 100) {
+			assert.True(t, re.MatchString(v.(string)), "%q does not match %s", v, pattern)
+		}
+	}
+
+	assert.Panics(t, func() { Regex(`[`) })
+	assert.Panics(t, func() { generate(Regex(`a^b`), 1) })
+}
+
+func TestRegexShrinksToMatches(t *testing.T) {
+	pattern := `[a-z]{2,}[0-9]`
+	re := regexp.MustCompile(`^(?:` + pattern + `)$`)
+	s := Regex(pattern).generate(rand.New(rand.NewSource(1)), 20)
+	for _, simpler := range shrinkValues(s) {
+		assert.Regexp(t, re, simpler)
+	}
+}
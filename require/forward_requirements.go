@@ -1,9 +1,11 @@
 package require
 
 import (
+	"os"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/clock"
 )
 
 type Assertions struct {
@@ -18,17 +20,26 @@ func New(t TestingT) *Assertions {
 
 // Fail reports a failure through
 func (a *Assertions) Fail(failureMessage string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	FailNow(a.t, failureMessage, msgAndArgs...)
 }
 
 // Implements asserts that an object is implemented by the specified interface.
 
 func (a *Assertions) Implements(interfaceObject interface{}, object interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	Implements(a.t, interfaceObject, object, msgAndArgs...)
 }
 
 // IsType asserts that the specified objects are of the same type.
 func (a *Assertions) IsType(expectedType interface{}, object interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	IsType(a.t, expectedType, object, msgAndArgs...)
 }
 
@@ -36,6 +47,9 @@ func (a *Assertions) IsType(expectedType interface{}, object interface{}, msgAnd
 //
 //    require.Equal(123, 123, "123 and 123 should be equal")
 func (a *Assertions) Equal(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	Equal(a.t, expected, actual, msgAndArgs...)
 }
 
@@ -43,6 +57,9 @@ func (a *Assertions) Equal(expected, actual interface{}, msgAndArgs ...interface
 //
 //    require.Exactly(int32(123), int64(123), "123 and 123 should NOT be equal")
 func (a *Assertions) Exactly(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	Exactly(a.t, expected, actual, msgAndArgs...)
 }
 
@@ -50,6 +67,9 @@ func (a *Assertions) Exactly(expected, actual interface{}, msgAndArgs ...interfa
 //
 //    require.NotNil(err, "err should be something")
 func (a *Assertions) NotNil(object interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	NotNil(a.t, object, msgAndArgs...)
 }
 
@@ -57,6 +77,9 @@ func (a *Assertions) NotNil(object interface{}, msgAndArgs ...interface{}) {
 //
 //    require.Nil(err, "err should be nothing")
 func (a *Assertions) Nil(object interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	Nil(a.t, object, msgAndArgs...)
 }
 
@@ -65,6 +88,9 @@ func (a *Assertions) Nil(object interface{}, msgAndArgs ...interface{}) {
 //
 // require.Empty(obj)
 func (a *Assertions) Empty(object interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	Empty(a.t, object, msgAndArgs...)
 }
 
@@ -75,6 +101,9 @@ func (a *Assertions) Empty(object interface{}, msgAndArgs ...interface{}) {
 //   require.Equal("two", obj[1])
 // }
 func (a *Assertions) NotEmpty(object interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	NotEmpty(a.t, object, msgAndArgs...)
 }
 
@@ -83,6 +112,9 @@ func (a *Assertions) NotEmpty(object interface{}, msgAndArgs ...interface{}) {
 //
 //    require.Len(mySlice, 3, "The size of slice is not 3")
 func (a *Assertions) Len(object interface{}, length int, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	Len(a.t, object, length, msgAndArgs...)
 }
 
@@ -90,6 +122,9 @@ func (a *Assertions) Len(object interface{}, length int, msgAndArgs ...interface
 //
 //    require.True(myBool, "myBool should be true")
 func (a *Assertions) True(value bool, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	True(a.t, value, msgAndArgs...)
 }
 
@@ -97,6 +132,9 @@ func (a *Assertions) True(value bool, msgAndArgs ...interface{}) {
 //
 //    require.False(myBool, "myBool should be false")
 func (a *Assertions) False(value bool, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	False(a.t, value, msgAndArgs...)
 }
 
@@ -104,6 +142,9 @@ func (a *Assertions) False(value bool, msgAndArgs ...interface{}) {
 //
 //    require.NotEqual(obj1, obj2, "two objects shouldn't be equal")
 func (a *Assertions) NotEqual(expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	NotEqual(a.t, expected, actual, msgAndArgs...)
 }
 
@@ -111,6 +152,9 @@ func (a *Assertions) NotEqual(expected, actual interface{}, msgAndArgs ...interf
 //
 //    require.Contains("Hello World", "World", "But 'Hello World' does contain 'World'")
 func (a *Assertions) Contains(s, contains interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	Contains(a.t, s, contains, msgAndArgs...)
 }
 
@@ -118,11 +162,17 @@ func (a *Assertions) Contains(s, contains interface{}, msgAndArgs ...interface{}
 //
 //    require.NotContains("Hello World", "Earth", "But 'Hello World' does NOT contain 'Earth'")
 func (a *Assertions) NotContains(s, contains interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	NotContains(a.t, s, contains, msgAndArgs...)
 }
 
 // Uses a Comparison to assert a complex condition.
 func (a *Assertions) Condition(comp assert.Comparison, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	Condition(a.t, comp, msgAndArgs...)
 }
 
@@ -132,6 +182,9 @@ func (a *Assertions) Condition(comp assert.Comparison, msgAndArgs ...interface{}
 //     GoCrazy()
 //   }, "Calling GoCrazy() should panic")
 func (a *Assertions) Panics(f assert.PanicTestFunc, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	Panics(a.t, f, msgAndArgs...)
 }
 
@@ -141,6 +194,9 @@ func (a *Assertions) Panics(f assert.PanicTestFunc, msgAndArgs ...interface{}) {
 //     RemainCalm()
 //   }, "Calling RemainCalm() should NOT panic")
 func (a *Assertions) NotPanics(f assert.PanicTestFunc, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	NotPanics(a.t, f, msgAndArgs...)
 }
 
@@ -148,6 +204,9 @@ func (a *Assertions) NotPanics(f assert.PanicTestFunc, msgAndArgs ...interface{}
 //
 //   require.WithinDuration(time.Now(), time.Now(), 10*time.Second, "The difference should not be more than 10s")
 func (a *Assertions) WithinDuration(expected, actual time.Time, delta time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	WithinDuration(a.t, expected, actual, delta, msgAndArgs...)
 }
 
@@ -155,11 +214,17 @@ func (a *Assertions) WithinDuration(expected, actual time.Time, delta time.Durat
 //
 // 	 require.InDelta(t, math.Pi, (22 / 7.0), 0.01)
 func (a *Assertions) InDelta(expected, actual interface{}, delta float64, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	InDelta(a.t, expected, actual, delta, msgAndArgs...)
 }
 
 // InEpsilon asserts that expected and actual have a relative error less than epsilon
 func (a *Assertions) InEpsilon(expected, actual interface{}, epsilon float64, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	InEpsilon(a.t, expected, actual, epsilon, msgAndArgs...)
 }
 
@@ -170,6 +235,9 @@ func (a *Assertions) InEpsilon(expected, actual interface{}, epsilon float64, ms
 //	   require.Equal(actualObj, expectedObj)
 //   }
 func (a *Assertions) NoError(theError error, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	NoError(a.t, theError, msgAndArgs...)
 }
 
@@ -180,6 +248,9 @@ func (a *Assertions) NoError(theError error, msgAndArgs ...interface{}) {
 //	   require.Equal(err, expectedError)
 //   }
 func (a *Assertions) Error(theError error, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	Error(a.t, theError, msgAndArgs...)
 }
 
@@ -191,6 +262,9 @@ func (a *Assertions) Error(theError error, msgAndArgs ...interface{}) {
 //	   require.Equal(err, expectedError)
 //   }
 func (a *Assertions) EqualError(theError error, errString string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	EqualError(a.t, theError, errString, msgAndArgs...)
 }
 
@@ -199,6 +273,9 @@ func (a *Assertions) EqualError(theError error, errString string, msgAndArgs ...
 //  require.Regexp(t, regexp.MustCompile("start"), "it's starting")
 //  require.Regexp(t, "start...$", "it's not starting")
 func (a *Assertions) Regexp(rx interface{}, str interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	Regexp(a.t, rx, str, msgAndArgs...)
 }
 
@@ -207,16 +284,25 @@ func (a *Assertions) Regexp(rx interface{}, str interface{}, msgAndArgs ...inter
 //  require.NotRegexp(t, regexp.MustCompile("starts"), "it's starting")
 //  require.NotRegexp(t, "^start", "it's not starting")
 func (a *Assertions) NotRegexp(rx interface{}, str interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	NotRegexp(a.t, rx, str, msgAndArgs...)
 }
 
 // Zero asserts that i is the zero value for its type and returns the truth.
 func (a *Assertions) Zero(i interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	Zero(a.t, i, msgAndArgs...)
 }
 
 // NotZero asserts that i is not the zero value for its type and returns the truth.
 func (a *Assertions) NotZero(i interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	NotZero(a.t, i, msgAndArgs...)
 }
 
@@ -226,5 +312,402 @@ func (a *Assertions) NotZero(i interface{}, msgAndArgs ...interface{}) {
 //
 // Returns whether the assertion was successful (true) or not (false).
 func (a *Assertions) JSONEq(expected string, actual string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
 	JSONEq(a.t, expected, actual, msgAndArgs...)
 }
+
+// Greater asserts that the first element is greater than the second.
+//
+//    require.Greater(t, 2, 1)
+//    require.Greater(t, float64(2), float64(1))
+//    require.Greater(t, "b", "a")
+func (a *Assertions) Greater(e1, e2 interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	Greater(a.t, e1, e2, msgAndArgs...)
+}
+
+// GreaterOrEqual asserts that the first element is greater than or equal
+// to the second.
+//
+//    require.GreaterOrEqual(t, 2, 1)
+//    require.GreaterOrEqual(t, 2, 2)
+func (a *Assertions) GreaterOrEqual(e1, e2 interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	GreaterOrEqual(a.t, e1, e2, msgAndArgs...)
+}
+
+// Less asserts that the first element is less than the second.
+//
+//    require.Less(t, 1, 2)
+//    require.Less(t, time.Second, time.Minute)
+func (a *Assertions) Less(e1, e2 interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	Less(a.t, e1, e2, msgAndArgs...)
+}
+
+// LessOrEqual asserts that the first element is less than or equal to the
+// second.
+//
+//    require.LessOrEqual(t, 1, 2)
+//    require.LessOrEqual(t, 2, 2)
+func (a *Assertions) LessOrEqual(e1, e2 interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	LessOrEqual(a.t, e1, e2, msgAndArgs...)
+}
+
+// Positive asserts that the specified number is positive.
+//
+//    require.Positive(t, 1)
+//    require.Positive(t, time.Second)
+func (a *Assertions) Positive(e interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	Positive(a.t, e, msgAndArgs...)
+}
+
+// Negative asserts that the specified number is negative.
+//
+//    require.Negative(t, -1)
+//    require.Negative(t, -time.Second)
+func (a *Assertions) Negative(e interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	Negative(a.t, e, msgAndArgs...)
+}
+
+// InRange asserts that the specified value is between min and max,
+// inclusive.
+//
+//    require.InRange(t, 5, 1, 10)
+//    require.InRange(t, elapsed, time.Second, 2*time.Second)
+func (a *Assertions) InRange(value, min, max interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	InRange(a.t, value, min, max, msgAndArgs...)
+}
+
+// IsIncreasing asserts that the elements of the collection are strictly
+// increasing.
+//
+//    require.IsIncreasing(t, []int{1, 2, 3})
+//    require.IsIncreasing(t, []string{"a", "b"})
+func (a *Assertions) IsIncreasing(list interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	IsIncreasing(a.t, list, msgAndArgs...)
+}
+
+// IsDecreasing asserts that the elements of the collection are strictly
+// decreasing.
+//
+//    require.IsDecreasing(t, []int{3, 2, 1})
+func (a *Assertions) IsDecreasing(list interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	IsDecreasing(a.t, list, msgAndArgs...)
+}
+
+// IsSorted asserts that the elements of the collection are sorted
+// according to less, which must be a func(a, b T) bool reporting whether
+// a sorts before b for elements of type T, as for sort.Slice.  Equal
+// elements may follow each other.  A nil less sorts numbers, strings,
+// times and durations in their natural order.
+//
+//    require.IsSorted(t, users, func(a, b User) bool { return a.Age < b.Age })
+func (a *Assertions) IsSorted(list interface{}, less interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	IsSorted(a.t, list, less, msgAndArgs...)
+}
+
+// JSONPathEq asserts that the value found at path in the JSON document is
+// equal to the JSON encoding of expected.  The path starts with $, which
+// is the document, followed by .key or ["key"] to select the member of
+// an object and [index] to select the element of an array.
+//
+//  require.JSONPathEq(`{"items": [{"id": 42}]}`, "$.items[0].id", 42)
+func (a *Assertions) JSONPathEq(actual string, path string, expected interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	JSONPathEq(a.t, actual, path, expected, msgAndArgs...)
+}
+
+// JSONContains asserts that the actual JSON document contains the
+// expected one: its objects have at least the keys of the expected
+// objects, with values containing the expected values.  Arrays must have
+// the same length, and numbers the same value.
+//
+//  require.JSONContains(`{"name": "Mat"}`, `{"id": 1, "name": "Mat"}`)
+func (a *Assertions) JSONContains(expected string, actual string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	JSONContains(a.t, expected, actual, msgAndArgs...)
+}
+
+// JSONMatchesSchema asserts that the JSON document is valid against the
+// draft-07 JSON Schema.  Only local references, such as
+// "#/definitions/item", are supported, and formats are not validated.
+//
+//  require.JSONMatchesSchema(`{"type": "object", "required": ["id"]}`, `{"id": 1}`)
+func (a *Assertions) JSONMatchesSchema(schema string, actual string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	JSONMatchesSchema(a.t, schema, actual, msgAndArgs...)
+}
+
+// JSONMatchesSchemaFile asserts that the JSON document is valid against
+// the draft-07 JSON Schema stored in the file at path.
+//
+//  require.JSONMatchesSchemaFile("testdata/user.schema.json", body)
+func (a *Assertions) JSONMatchesSchemaFile(path string, actual string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	JSONMatchesSchemaFile(a.t, path, actual, msgAndArgs...)
+}
+
+// YAMLEq asserts that two YAML strings are equivalent: they hold the same
+// documents, whatever the order of the keys of their mappings and however
+// their scalars are written, so that 0x10 and 16, or 'yes' and "yes", are
+// equal.  Differences are reported by JSON pointer.
+//
+//  require.YAMLEq("a: 1\nb: [x, y]\n", "b:\n- x\n- y\na: 1\n")
+func (a *Assertions) YAMLEq(expected string, actual string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	YAMLEq(a.t, expected, actual, msgAndArgs...)
+}
+
+// YAMLContains asserts that each document of the actual YAML stream
+// contains the matching document of the expected one, as JSONContains
+// does.
+//
+//  require.YAMLContains("name: Mat\n", "id: 1\nname: Mat\n")
+func (a *Assertions) YAMLContains(expected string, actual string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	YAMLContains(a.t, expected, actual, msgAndArgs...)
+}
+
+// FileExists asserts that the specified path exists and is not a
+// directory.
+//
+//  require.FileExists("testdata/config.yaml")
+func (a *Assertions) FileExists(path string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	FileExists(a.t, path, msgAndArgs...)
+}
+
+// NoFileExists asserts that no file exists at the specified path.  A
+// directory at the path fails the assertion too.
+//
+//  require.NoFileExists("output/tmp.lock")
+func (a *Assertions) NoFileExists(path string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	NoFileExists(a.t, path, msgAndArgs...)
+}
+
+// DirExists asserts that the specified path exists and is a directory.
+//
+//  require.DirExists("output")
+func (a *Assertions) DirExists(path string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	DirExists(a.t, path, msgAndArgs...)
+}
+
+// FileContains asserts that the content of the file at the specified path
+// contains the specified substring.
+//
+//  require.FileContains("output/log.txt", "server started")
+func (a *Assertions) FileContains(path string, contains string, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	FileContains(a.t, path, contains, msgAndArgs...)
+}
+
+// FileEqual asserts that the content of the file at the specified path is
+// equal to expected, which is either a string, compared as text, or a
+// []byte, compared byte by byte.  Text differences are reported as a
+// unified diff.
+//
+//  require.FileEqual("output/greeting.txt", "Hello\n")
+//  require.FileEqual("output/image.png", pngBytes)
+func (a *Assertions) FileEqual(path string, expected interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	FileEqual(a.t, path, expected, msgAndArgs...)
+}
+
+// FileMode asserts that the file at the specified path has the specified
+// permissions.  If mode has type bits too, such as os.ModeDir, they must
+// match as well.
+//
+//  require.FileMode("bin/run.sh", 0755)
+func (a *Assertions) FileMode(path string, mode os.FileMode, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	FileMode(a.t, path, mode, msgAndArgs...)
+}
+
+// DirMatches asserts that the directory holds exactly the files and
+// directories of the expected tree, with the same contents.  The expected
+// tree is either a Tree or the path of a directory, such as
+// "testdata/expected".  Failures show the tree of both directories, with
+// the missing (-), extra (+) and different (~) entries marked, followed
+// by the diffs of the different files.
+//
+//  require.DirMatches(outputDir, require.Tree{"a.txt": "A", "b/c.txt": "C"})
+//  require.DirMatches(outputDir, "testdata/golden")
+func (a *Assertions) DirMatches(dir string, expectedTree interface{}, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	DirMatches(a.t, dir, expectedTree, msgAndArgs...)
+}
+
+// NoGoroutineLeaks asserts that the specified function does not leave
+// goroutines running once it returns.  The goroutines started by the
+// function are given one second to stop, and those still running then
+// are reported with their stacks, which end with the function that
+// created them.  Running the tests with GODEBUG=tracebackancestors=10
+// adds the stacks of the goroutines that created them.
+//
+//  require.NoGoroutineLeaks(func() {
+//    server := NewServer()
+//    server.Close()
+//  })
+func (a *Assertions) NoGoroutineLeaks(f func(), msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	NoGoroutineLeaks(a.t, f, msgAndArgs...)
+}
+
+// NoGoroutineLeaksWith asserts that the specified function does not leave
+// goroutines running once it returns, as NoGoroutineLeaks does, with the
+// specified options.
+//
+//  require.NoGoroutineLeaksWith(assert.GoroutineLeakOptions{
+//    Ignore: []*regexp.Regexp{regexp.MustCompile(`pool\.\(\*Pool\)\.reap`)},
+//  }, func() {
+//    ...
+//  })
+func (a *Assertions) NoGoroutineLeaksWith(options assert.GoroutineLeakOptions, f func(), msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	NoGoroutineLeaksWith(a.t, options, f, msgAndArgs...)
+}
+
+// Eventually asserts that the condition becomes true within waitFor,
+// checking it every tick.
+//
+//  require.Eventually(func() bool { return server.Ready() }, time.Second, 10*time.Millisecond)
+func (a *Assertions) Eventually(condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	Eventually(a.t, condition, waitFor, tick, msgAndArgs...)
+}
+
+// EventuallyWith asserts that the condition becomes true within waitFor,
+// checking it every tick, as Eventually does, with the time measured by
+// the specified clock.  With a fake clock, the test decides when the
+// condition is checked by advancing the time.
+//
+//  fake := clock.NewFake(time.Now())
+//  go func() {
+//    fake.BlockUntil(2) // The timer and the ticker of EventuallyWith.
+//    fake.Advance(time.Second)
+//  }()
+//  require.EventuallyWith(fake, cache.Expired, time.Minute, time.Second)
+func (a *Assertions) EventuallyWith(c clock.Clock, condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	EventuallyWith(a.t, c, condition, waitFor, tick, msgAndArgs...)
+}
+
+// Never asserts that the condition stays false for waitFor, checking it
+// every tick.
+//
+//  require.Never(func() bool { return queue.Len() > 0 }, time.Second, 10*time.Millisecond)
+func (a *Assertions) Never(condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	Never(a.t, condition, waitFor, tick, msgAndArgs...)
+}
+
+// NeverWith asserts that the condition stays false for waitFor, checking
+// it every tick, as Never does, with the time measured by the specified
+// clock.
+//
+//  require.NeverWith(fake, func() bool { return queue.Len() > 0 }, time.Minute, time.Second)
+func (a *Assertions) NeverWith(c clock.Clock, condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	NeverWith(a.t, c, condition, waitFor, tick, msgAndArgs...)
+}
+
+// Collect calls f with a Collector, and reports the failures of the
+// assertions made through it as a single failure once f returns, as
+// assert.Collector.Report does, and then stops the test if anything
+// failed.
+//
+//  require.Collect(func(c *assert.Collector) {
+//    c.Equal("Mat", user.Name)
+//    assert.Equal(c, 42, user.Age)
+//  })
+func (a *Assertions) Collect(f func(c *assert.Collector), msgAndArgs ...interface{}) {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	Collect(a.t, f, msgAndArgs...)
+}
+
+// Batch returns a Collector of the assertions made through it, reporting
+// them as a single failure when its Report method is called, and then
+// stopping the test if anything failed.
+//
+//  b := require.New(t).Batch()
+//  defer b.Report()
+//  b.Equal("Mat", user.Name)
+//  b.Equal(42, user.Age)
+func (a *Assertions) Batch() *assert.Collector {
+	if h, ok := a.t.(tHelper); ok {
+		h.Helper()
+	}
+	return assert.NewCollector(a.t).StopOnFailure()
+}
@@ -383,3 +383,15 @@ func TestJSONEqWrapper_ArraysOfDifferentOrder(t *testing.T) {
 		t.Error("Check should fail")
 	}
 }
+
+func TestInRangeWrapper(t *testing.T) {
+	require := New(t)
+	require.InRange(5, 1, 10)
+
+	mockT := new(MockT)
+	mockRequire := New(mockT)
+	mockRequire.InRange(11, 1, 10)
+	if !mockT.Failed {
+		t.Error("Check should fail")
+	}
+}
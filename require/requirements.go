@@ -1,10 +1,11 @@
 package require
 
 import (
-	"encoding/json"
+	"os"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/clock"
 )
 
 type TestingT interface {
@@ -12,8 +13,17 @@ type TestingT interface {
 	FailNow()
 }
 
+// tHelper is implemented by *testing.T, which skips the functions calling
+// Helper when printing the file and line of a failure.
+type tHelper interface {
+	Helper()
+}
+
 // Fail reports a failure through
 func FailNow(t TestingT, failureMessage string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	assert.Fail(t, failureMessage, msgAndArgs...)
 	t.FailNow()
 }
@@ -22,6 +32,9 @@ func FailNow(t TestingT, failureMessage string, msgAndArgs ...interface{}) {
 //
 //    require.Implements(t, (*MyInterface)(nil), new(MyObject), "MyObject")
 func Implements(t TestingT, interfaceObject interface{}, object interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.Implements(t, interfaceObject, object, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -29,6 +42,9 @@ func Implements(t TestingT, interfaceObject interface{}, object interface{}, msg
 
 // IsType asserts that the specified objects are of the same type.
 func IsType(t TestingT, expectedType interface{}, object interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.IsType(t, expectedType, object, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -38,6 +54,9 @@ func IsType(t TestingT, expectedType interface{}, object interface{}, msgAndArgs
 //
 //    require.Equal(t, 123, 123, "123 and 123 should be equal")
 func Equal(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.Equal(t, expected, actual, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -47,6 +66,9 @@ func Equal(t TestingT, expected, actual interface{}, msgAndArgs ...interface{})
 //
 //    require.EqualValues(t, uint32(123), int32(123), "123 and 123 should be equal")
 func EqualValues(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.EqualValues(t, expected, actual, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -56,6 +78,9 @@ func EqualValues(t TestingT, expected, actual interface{}, msgAndArgs ...interfa
 //
 //    require.Exactly(t, int32(123), int64(123), "123 and 123 should NOT be equal")
 func Exactly(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.Exactly(t, expected, actual, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -65,6 +90,9 @@ func Exactly(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}
 //
 //    require.NotNil(t, err, "err should be something")
 func NotNil(t TestingT, object interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.NotNil(t, object, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -74,6 +102,9 @@ func NotNil(t TestingT, object interface{}, msgAndArgs ...interface{}) {
 //
 //    require.Nil(t, err, "err should be nothing")
 func Nil(t TestingT, object interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.Nil(t, object, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -84,6 +115,9 @@ func Nil(t TestingT, object interface{}, msgAndArgs ...interface{}) {
 //
 // require.Empty(t, obj)
 func Empty(t TestingT, object interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.Empty(t, object, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -95,6 +129,9 @@ func Empty(t TestingT, object interface{}, msgAndArgs ...interface{}) {
 // require.NotEmpty(t, obj)
 // require.Equal(t, "one", obj[0])
 func NotEmpty(t TestingT, object interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.NotEmpty(t, object, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -105,6 +142,9 @@ func NotEmpty(t TestingT, object interface{}, msgAndArgs ...interface{}) {
 //
 //    require.Len(t, mySlice, 3, "The size of slice is not 3")
 func Len(t TestingT, object interface{}, length int, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.Len(t, object, length, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -114,6 +154,9 @@ func Len(t TestingT, object interface{}, length int, msgAndArgs ...interface{})
 //
 //    require.True(t, myBool, "myBool should be true")
 func True(t TestingT, value bool, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.True(t, value, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -123,6 +166,9 @@ func True(t TestingT, value bool, msgAndArgs ...interface{}) {
 //
 //    require.False(t, myBool, "myBool should be false")
 func False(t TestingT, value bool, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.False(t, value, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -132,6 +178,9 @@ func False(t TestingT, value bool, msgAndArgs ...interface{}) {
 //
 //    require.NotEqual(t, obj1, obj2, "two objects shouldn't be equal")
 func NotEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.NotEqual(t, expected, actual, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -144,6 +193,9 @@ func NotEqual(t TestingT, expected, actual interface{}, msgAndArgs ...interface{
 //    require.Contains(t, ["Hello", "World"], "World", "But ["Hello", "World"] does contain 'World'")
 //    require.Contains(t, {"Hello": "World"}, "Hello", "But {'Hello': 'World'} does contain 'Hello'")
 func Contains(t TestingT, s, contains interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.Contains(t, s, contains, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -153,6 +205,9 @@ func Contains(t TestingT, s, contains interface{}, msgAndArgs ...interface{}) {
 //
 //    require.NotContains(t, "Hello World", "Earth", "But 'Hello World' does NOT contain 'Earth'")
 func NotContains(t TestingT, s, contains interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.NotContains(t, s, contains, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -160,6 +215,9 @@ func NotContains(t TestingT, s, contains interface{}, msgAndArgs ...interface{})
 
 // Condition uses a Comparison to assert a complex condition.
 func Condition(t TestingT, comp assert.Comparison, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.Condition(t, comp, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -171,6 +229,9 @@ func Condition(t TestingT, comp assert.Comparison, msgAndArgs ...interface{}) {
 //     GoCrazy()
 //   }, "Calling GoCrazy() should panic")
 func Panics(t TestingT, f assert.PanicTestFunc, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.Panics(t, f, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -182,6 +243,9 @@ func Panics(t TestingT, f assert.PanicTestFunc, msgAndArgs ...interface{}) {
 //     RemainCalm()
 //   }, "Calling RemainCalm() should NOT panic")
 func NotPanics(t TestingT, f assert.PanicTestFunc, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.NotPanics(t, f, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -191,6 +255,9 @@ func NotPanics(t TestingT, f assert.PanicTestFunc, msgAndArgs ...interface{}) {
 //
 //   require.WithinDuration(t, time.Now(), time.Now(), 10*time.Second, "The difference should not be more than 10s")
 func WithinDuration(t TestingT, expected, actual time.Time, delta time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.WithinDuration(t, expected, actual, delta, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -200,6 +267,9 @@ func WithinDuration(t TestingT, expected, actual time.Time, delta time.Duration,
 //
 //   require.InDelta(t, math.Pi, (22 / 7.0), 0.01)
 func InDelta(t TestingT, expected, actual interface{}, delta float64, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.InDelta(t, expected, actual, delta, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -207,6 +277,9 @@ func InDelta(t TestingT, expected, actual interface{}, delta float64, msgAndArgs
 
 // InEpsilon asserts that expected and actual have a relative error less than epsilon
 func InEpsilon(t TestingT, expected, actual interface{}, epsilon float64, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.InEpsilon(t, expected, actual, epsilon, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -217,6 +290,9 @@ func InEpsilon(t TestingT, expected, actual interface{}, epsilon float64, msgAnd
 //  require.Regexp(t, regexp.MustCompile("start"), "it's starting")
 //  require.Regexp(t, "start...$", "it's not starting")
 func Regexp(t TestingT, rx interface{}, str interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.Regexp(t, rx, str, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -227,6 +303,9 @@ func Regexp(t TestingT, rx interface{}, str interface{}, msgAndArgs ...interface
 //  require.NotRegexp(t, regexp.MustCompile("starts"), "it's starting")
 //  require.NotRegexp(t, "^start", "it's not starting")
 func NotRegexp(t TestingT, rx interface{}, str interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.NotRegexp(t, rx, str, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -238,17 +317,12 @@ func NotRegexp(t TestingT, rx interface{}, str interface{}, msgAndArgs ...interf
 //
 // Returns whether the assertion was successful (true) or not (false).
 func JSONEq(t TestingT, expected string, actual string, msgAndArgs ...interface{}) {
-	var expectedJSONAsInterface, actualJSONAsInterface interface{}
-
-	if err := json.Unmarshal([]byte(expected), &expectedJSONAsInterface); err != nil {
-		t.FailNow()
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
 	}
-
-	if err := json.Unmarshal([]byte(actual), &actualJSONAsInterface); err != nil {
+	if !assert.JSONEq(t, expected, actual, msgAndArgs...) {
 		t.FailNow()
 	}
-
-	Equal(t, expectedJSONAsInterface, actualJSONAsInterface, msgAndArgs...)
 }
 
 /*
@@ -263,6 +337,9 @@ func JSONEq(t TestingT, expected string, actual string, msgAndArgs ...interface{
 //
 // Returns whether the assertion was successful (true) or not (false).
 func NoError(t TestingT, err error, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.NoError(t, err, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -275,6 +352,9 @@ func NoError(t TestingT, err error, msgAndArgs ...interface{}) {
 //   require.Equal(t, err, expectedError)
 //   }
 func Error(t TestingT, err error, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.Error(t, err, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -288,6 +368,9 @@ func Error(t TestingT, err error, msgAndArgs ...interface{}) {
 //   require.Equal(t, err, expectedError)
 //   }
 func EqualError(t TestingT, theError error, errString string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.EqualError(t, theError, errString, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -295,6 +378,9 @@ func EqualError(t TestingT, theError error, errString string, msgAndArgs ...inte
 
 // Zero asserts that i is the zero value for its type and returns the truth.
 func Zero(t TestingT, i interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.Zero(t, i, msgAndArgs...) {
 		t.FailNow()
 	}
@@ -302,7 +388,449 @@ func Zero(t TestingT, i interface{}, msgAndArgs ...interface{}) {
 
 // NotZero asserts that i is not the zero value for its type and returns the truth.
 func NotZero(t TestingT, i interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
 	if !assert.NotZero(t, i, msgAndArgs...) {
 		t.FailNow()
 	}
 }
+
+// Greater asserts that the first element is greater than the second.
+//
+//    require.Greater(t, 2, 1)
+//    require.Greater(t, float64(2), float64(1))
+//    require.Greater(t, "b", "a")
+func Greater(t TestingT, e1, e2 interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.Greater(t, e1, e2, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// GreaterOrEqual asserts that the first element is greater than or equal
+// to the second.
+//
+//    require.GreaterOrEqual(t, 2, 1)
+//    require.GreaterOrEqual(t, 2, 2)
+func GreaterOrEqual(t TestingT, e1, e2 interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.GreaterOrEqual(t, e1, e2, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Less asserts that the first element is less than the second.
+//
+//    require.Less(t, 1, 2)
+//    require.Less(t, time.Second, time.Minute)
+func Less(t TestingT, e1, e2 interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.Less(t, e1, e2, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// LessOrEqual asserts that the first element is less than or equal to the
+// second.
+//
+//    require.LessOrEqual(t, 1, 2)
+//    require.LessOrEqual(t, 2, 2)
+func LessOrEqual(t TestingT, e1, e2 interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.LessOrEqual(t, e1, e2, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Positive asserts that the specified number is positive.
+//
+//    require.Positive(t, 1)
+//    require.Positive(t, time.Second)
+func Positive(t TestingT, e interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.Positive(t, e, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Negative asserts that the specified number is negative.
+//
+//    require.Negative(t, -1)
+//    require.Negative(t, -time.Second)
+func Negative(t TestingT, e interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.Negative(t, e, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// InRange asserts that the specified value is between min and max,
+// inclusive.
+//
+//    require.InRange(t, 5, 1, 10)
+//    require.InRange(t, elapsed, time.Second, 2*time.Second)
+func InRange(t TestingT, value, min, max interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.InRange(t, value, min, max, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// IsIncreasing asserts that the elements of the collection are strictly
+// increasing.
+//
+//    require.IsIncreasing(t, []int{1, 2, 3})
+//    require.IsIncreasing(t, []string{"a", "b"})
+func IsIncreasing(t TestingT, list interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.IsIncreasing(t, list, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// IsDecreasing asserts that the elements of the collection are strictly
+// decreasing.
+//
+//    require.IsDecreasing(t, []int{3, 2, 1})
+func IsDecreasing(t TestingT, list interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.IsDecreasing(t, list, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// IsSorted asserts that the elements of the collection are sorted
+// according to less, which must be a func(a, b T) bool reporting whether
+// a sorts before b for elements of type T, as for sort.Slice.  Equal
+// elements may follow each other.  A nil less sorts numbers, strings,
+// times and durations in their natural order.
+//
+//    require.IsSorted(t, users, func(a, b User) bool { return a.Age < b.Age })
+func IsSorted(t TestingT, list interface{}, less interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.IsSorted(t, list, less, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// JSONPathEq asserts that the value found at path in the JSON document is
+// equal to the JSON encoding of expected.  The path starts with $, which
+// is the document, followed by .key or ["key"] to select the member of
+// an object and [index] to select the element of an array.
+//
+//  require.JSONPathEq(t, `{"items": [{"id": 42}]}`, "$.items[0].id", 42)
+func JSONPathEq(t TestingT, actual string, path string, expected interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.JSONPathEq(t, actual, path, expected, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// JSONContains asserts that the actual JSON document contains the
+// expected one: its objects have at least the keys of the expected
+// objects, with values containing the expected values.  Arrays must have
+// the same length, and numbers the same value.
+//
+//  require.JSONContains(t, `{"name": "Mat"}`, `{"id": 1, "name": "Mat"}`)
+func JSONContains(t TestingT, expected string, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.JSONContains(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// JSONMatchesSchema asserts that the JSON document is valid against the
+// draft-07 JSON Schema.  Only local references, such as
+// "#/definitions/item", are supported, and formats are not validated.
+//
+//  require.JSONMatchesSchema(t, `{"type": "object", "required": ["id"]}`, `{"id": 1}`)
+func JSONMatchesSchema(t TestingT, schema string, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.JSONMatchesSchema(t, schema, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// JSONMatchesSchemaFile asserts that the JSON document is valid against
+// the draft-07 JSON Schema stored in the file at path.
+//
+//  require.JSONMatchesSchemaFile(t, "testdata/user.schema.json", body)
+func JSONMatchesSchemaFile(t TestingT, path string, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.JSONMatchesSchemaFile(t, path, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// YAMLEq asserts that two YAML strings are equivalent: they hold the same
+// documents, whatever the order of the keys of their mappings and however
+// their scalars are written, so that 0x10 and 16, or 'yes' and "yes", are
+// equal.  Differences are reported by JSON pointer.
+//
+//  require.YAMLEq(t, "a: 1\nb: [x, y]\n", "b:\n- x\n- y\na: 1\n")
+func YAMLEq(t TestingT, expected string, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.YAMLEq(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// YAMLContains asserts that each document of the actual YAML stream
+// contains the matching document of the expected one, as JSONContains
+// does.
+//
+//  require.YAMLContains(t, "name: Mat\n", "id: 1\nname: Mat\n")
+func YAMLContains(t TestingT, expected string, actual string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.YAMLContains(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// FileExists asserts that the specified path exists and is not a
+// directory.
+//
+//  require.FileExists(t, "testdata/config.yaml")
+func FileExists(t TestingT, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.FileExists(t, path, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// NoFileExists asserts that no file exists at the specified path.  A
+// directory at the path fails the assertion too.
+//
+//  require.NoFileExists(t, "output/tmp.lock")
+func NoFileExists(t TestingT, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.NoFileExists(t, path, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// DirExists asserts that the specified path exists and is a directory.
+//
+//  require.DirExists(t, "output")
+func DirExists(t TestingT, path string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.DirExists(t, path, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// FileContains asserts that the content of the file at the specified path
+// contains the specified substring.
+//
+//  require.FileContains(t, "output/log.txt", "server started")
+func FileContains(t TestingT, path string, contains string, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.FileContains(t, path, contains, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// FileEqual asserts that the content of the file at the specified path is
+// equal to expected, which is either a string, compared as text, or a
+// []byte, compared byte by byte.  Text differences are reported as a
+// unified diff.
+//
+//  require.FileEqual(t, "output/greeting.txt", "Hello\n")
+//  require.FileEqual(t, "output/image.png", pngBytes)
+func FileEqual(t TestingT, path string, expected interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.FileEqual(t, path, expected, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// FileMode asserts that the file at the specified path has the specified
+// permissions.  If mode has type bits too, such as os.ModeDir, they must
+// match as well.
+//
+//  require.FileMode(t, "bin/run.sh", 0755)
+func FileMode(t TestingT, path string, mode os.FileMode, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.FileMode(t, path, mode, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// DirMatches asserts that the directory holds exactly the files and
+// directories of the expected tree, with the same contents.  The expected
+// tree is either a Tree or the path of a directory, such as
+// "testdata/expected".  Failures show the tree of both directories, with
+// the missing (-), extra (+) and different (~) entries marked, followed
+// by the diffs of the different files.
+//
+//  require.DirMatches(t, outputDir, require.Tree{"a.txt": "A", "b/c.txt": "C"})
+//  require.DirMatches(t, outputDir, "testdata/golden")
+func DirMatches(t TestingT, dir string, expectedTree interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.DirMatches(t, dir, expectedTree, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// NoGoroutineLeaks asserts that the specified function does not leave
+// goroutines running once it returns.  The goroutines started by the
+// function are given one second to stop, and those still running then
+// are reported with their stacks, which end with the function that
+// created them.  Running the tests with GODEBUG=tracebackancestors=10
+// adds the stacks of the goroutines that created them.
+//
+//  require.NoGoroutineLeaks(t, func() {
+//    server := NewServer()
+//    server.Close()
+//  })
+func NoGoroutineLeaks(t TestingT, f func(), msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.NoGoroutineLeaks(t, f, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// NoGoroutineLeaksWith asserts that the specified function does not leave
+// goroutines running once it returns, as NoGoroutineLeaks does, with the
+// specified options.
+//
+//  require.NoGoroutineLeaksWith(t, assert.GoroutineLeakOptions{
+//    Ignore: []*regexp.Regexp{regexp.MustCompile(`pool\.\(\*Pool\)\.reap`)},
+//  }, func() {
+//    ...
+//  })
+func NoGoroutineLeaksWith(t TestingT, options assert.GoroutineLeakOptions, f func(), msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.NoGoroutineLeaksWith(t, options, f, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Eventually asserts that the condition becomes true within waitFor,
+// checking it every tick.
+//
+//  require.Eventually(t, func() bool { return server.Ready() }, time.Second, 10*time.Millisecond)
+func Eventually(t TestingT, condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.Eventually(t, condition, waitFor, tick, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// EventuallyWith asserts that the condition becomes true within waitFor,
+// checking it every tick, as Eventually does, with the time measured by
+// the specified clock.  With a fake clock, the test decides when the
+// condition is checked by advancing the time.
+//
+//  fake := clock.NewFake(time.Now())
+//  go func() {
+//    fake.BlockUntil(2) // The timer and the ticker of EventuallyWith.
+//    fake.Advance(time.Second)
+//  }()
+//  require.EventuallyWith(t, fake, cache.Expired, time.Minute, time.Second)
+func EventuallyWith(t TestingT, c clock.Clock, condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.EventuallyWith(t, c, condition, waitFor, tick, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Never asserts that the condition stays false for waitFor, checking it
+// every tick.
+//
+//  require.Never(t, func() bool { return queue.Len() > 0 }, time.Second, 10*time.Millisecond)
+func Never(t TestingT, condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.Never(t, condition, waitFor, tick, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// NeverWith asserts that the condition stays false for waitFor, checking
+// it every tick, as Never does, with the time measured by the specified
+// clock.
+//
+//  require.NeverWith(t, fake, func() bool { return queue.Len() > 0 }, time.Minute, time.Second)
+func NeverWith(t TestingT, c clock.Clock, condition func() bool, waitFor, tick time.Duration, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.NeverWith(t, c, condition, waitFor, tick, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Collect calls f with a Collector, and reports the failures of the
+// assertions made through it as a single failure once f returns, as
+// assert.Collector.Report does, and then stops the test if anything
+// failed.
+//
+//  require.Collect(t, func(c *assert.Collector) {
+//    c.Equal("Mat", user.Name)
+//    assert.Equal(c, 42, user.Age)
+//  })
+func Collect(t TestingT, f func(c *assert.Collector), msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if !assert.Collect(t, f, msgAndArgs...) {
+		t.FailNow()
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 // AssertionTesterInterface defines an interface to be used for testing assertion methods
@@ -367,3 +369,114 @@ func TestJSONEq_ArraysOfDifferentOrder(t *testing.T) {
 		t.Error("Check should fail")
 	}
 }
+
+func TestGreater(t *testing.T) {
+	Greater(t, 2, 1)
+
+	mockT := new(MockT)
+	Greater(mockT, 1, 2)
+	if !mockT.Failed {
+		t.Error("Check should fail")
+	}
+}
+
+func TestIsSorted(t *testing.T) {
+	IsSorted(t, []int{1, 2, 2}, nil)
+
+	mockT := new(MockT)
+	IsSorted(mockT, []int{2, 1}, func(a, b int) bool { return a < b })
+	if !mockT.Failed {
+		t.Error("Check should fail")
+	}
+}
+
+func TestJSONContains(t *testing.T) {
+	JSONContains(t, `{"a": 1}`, `{"a": 1.0, "b": 2}`)
+
+	mockT := new(MockT)
+	JSONContains(mockT, `{"a": 2}`, `{"a": 1}`)
+	if !mockT.Failed {
+		t.Error("Check should fail")
+	}
+}
+
+func TestJSONMatchesSchema(t *testing.T) {
+	JSONMatchesSchema(t, `{"type": "object", "required": ["id"]}`, `{"id": 1}`)
+
+	mockT := new(MockT)
+	JSONMatchesSchema(mockT, `{"type": "object", "required": ["id"]}`, `{}`)
+	if !mockT.Failed {
+		t.Error("Check should fail")
+	}
+}
+
+func TestYAMLEq(t *testing.T) {
+	YAMLEq(t, "a: 1\nb: [x]\n", "b:\n- x\na: 1.0\n")
+
+	mockT := new(MockT)
+	YAMLEq(mockT, "a: 1\n", "a: 2\n")
+	if !mockT.Failed {
+		t.Error("Check should fail")
+	}
+}
+
+func TestDirMatches(t *testing.T) {
+	dir := assert.TempTree(t, assert.Tree{"a.txt": "A"})
+	DirMatches(t, dir, assert.Tree{"a.txt": "A"})
+
+	mockT := new(MockT)
+	DirMatches(mockT, dir, assert.Tree{"a.txt": "B"})
+	if !mockT.Failed {
+		t.Error("Check should fail")
+	}
+}
+
+func TestNoGoroutineLeaks(t *testing.T) {
+	NoGoroutineLeaks(t, func() {})
+
+	release := make(chan struct{})
+	defer close(release)
+	mockT := new(MockT)
+	NoGoroutineLeaksWith(mockT, assert.GoroutineLeakOptions{GracePeriod: time.Millisecond}, func() {
+		go func() { <-release }()
+	})
+	if !mockT.Failed {
+		t.Error("Check should fail")
+	}
+}
+
+func TestEventually(t *testing.T) {
+	Eventually(t, func() bool { return true }, time.Second, time.Millisecond)
+
+	mockT := new(MockT)
+	Eventually(mockT, func() bool { return false }, 10*time.Millisecond, time.Millisecond)
+	if !mockT.Failed {
+		t.Error("Check should fail")
+	}
+}
+
+func TestCollect(t *testing.T) {
+	Collect(t, func(c *assert.Collector) {
+		c.Equal(1, 1)
+	})
+
+	mockT := new(MockT)
+	Collect(mockT, func(c *assert.Collector) {
+		c.Equal(1, 2)
+		c.Equal(3, 4)
+	})
+	if !mockT.Failed {
+		t.Error("Check should fail")
+	}
+
+	mockT = new(MockT)
+	b := New(mockT).Batch()
+	b.Equal(1, 2)
+	if mockT.Failed {
+		t.Error("Check should not fail before Report")
+	}
+	b.Report()
+	if !mockT.Failed {
+		t.Error("Check should fail")
+	}
+}
@@ -0,0 +1,24 @@
+// Package snapshot provides snapshot testing: asserting that a value
+// matches a rendering of it stored by a previous run of the test.
+//
+// Example Usage
+//
+// The first run of the following test stores the rendering of user in
+// testdata/__snapshots__/TestUser.snap, and later runs compare the user
+// to it:
+//
+//    func TestUser(t *testing.T) {
+//      user := LoadUser(1)
+//      snapshot.Match(t, user)
+//      snapshot.MatchJSON(t, user)
+//    }
+//
+// When the value changes on purpose, running the tests with the
+// -testify.update flag, or with $TESTIFY_UPDATE set, rewrites the
+// snapshots.  The snapshot files should be committed with the tests, and
+// reviewed like code.
+//
+// Snapshots can be matched from suite methods by passing suite.T() or
+// suite.Runner() as the TestingT.  Obsolete snapshots, which no test
+// matches anymore, are reported by Run when it is called from TestMain.
+package snapshot
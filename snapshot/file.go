@@ -0,0 +1,133 @@
+package snapshot
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// headerPrefix starts the line preceding each snapshot in a file.  Lines
+// of snapshots starting with it or with escapePrefix are escaped with
+// escapePrefix.
+const (
+	headerPrefix = "--- snapshot: "
+	escapePrefix = `\`
+)
+
+// file is a file holding the snapshots of a test, keyed by the name of
+// the test and the index of the snapshot in the test.
+type file struct {
+	path    string
+	entries map[string]string
+	used    map[string]bool
+}
+
+// loadFile returns the snapshot file at path, reading it the first time,
+// or an empty one if it does not exist.  It must be called with the mutex
+// held.
+func loadFile(path string) (*file, error) {
+	if f, ok := files[path]; ok {
+		return f, nil
+	}
+	f := &file{
+		path:    path,
+		entries: make(map[string]string),
+		used:    make(map[string]bool),
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(data) > 0 {
+		f.entries = parse(string(data))
+	}
+	files[path] = f
+	return f, nil
+}
+
+// parse returns the snapshots stored in data.
+func parse(data string) map[string]string {
+	entries := make(map[string]string)
+	lines := strings.Split(strings.TrimSuffix(data, "\n"), "\n")
+	key := ""
+	var content []string
+	flush := func() {
+		if key != "" {
+			entries[key] = strings.Join(content, "\n")
+		}
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, headerPrefix) {
+			flush()
+			key, content = strings.TrimPrefix(line, headerPrefix), nil
+			continue
+		}
+		content = append(content, strings.TrimPrefix(line, escapePrefix))
+	}
+	flush()
+	return entries
+}
+
+// keys returns the keys of the snapshots of the file, ordered by test
+// name and index.
+func (f *file) keys() []string {
+	keys := make([]string, 0, len(f.entries))
+	for key := range f.entries {
+		keys = append(keys, key)
+	}
+	sort.Sort(byKey(keys))
+	return keys
+}
+
+// write writes the file, or removes it if it holds no snapshot.
+func (f *file) write() error {
+	if len(f.entries) == 0 {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	var b bytes.Buffer
+	for _, key := range f.keys() {
+		b.WriteString(headerPrefix + key + "\n")
+		for _, line := range strings.Split(f.entries[key], "\n") {
+			if strings.HasPrefix(line, headerPrefix) || strings.HasPrefix(line, escapePrefix) {
+				line = escapePrefix + line
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(f.path, []byte(b.String()), 0644)
+}
+
+// splitKey splits a key into the name of its test and its index.
+func splitKey(key string) (string, int) {
+	i := strings.LastIndex(key, " ")
+	if i < 0 {
+		return key, 0
+	}
+	index, _ := strconv.Atoi(key[i+1:])
+	return key[:i], index
+}
+
+// byKey sorts keys by test name and index.
+type byKey []string
+
+func (k byKey) Len() int      { return len(k) }
+func (k byKey) Swap(i, j int) { k[i], k[j] = k[j], k[i] }
+func (k byKey) Less(i, j int) bool {
+	nameI, indexI := splitKey(k[i])
+	nameJ, indexJ := splitKey(k[j])
+	if nameI != nameJ {
+		return nameI < nameJ
+	}
+	return indexI < indexJ
+}
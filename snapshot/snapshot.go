@@ -0,0 +1,262 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestingT is an interface wrapper around *testing.T, whose name keys the
+// snapshots of the test.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+	Name() string
+}
+
+// Serializer renders a value as the text stored in a snapshot.  It must
+// be deterministic.
+type Serializer func(value interface{}) (string, error)
+
+// spewConfig renders values as assert does in its diffs, without the
+// pointer addresses and capacities that change from one run to another.
+var spewConfig = spew.ConfigState{
+	Indent:                  " ",
+	SortKeys:                true,
+	DisablePointerAddresses: true,
+	DisableCapacities:       true,
+}
+
+// Spew is the default Serializer, rendering values with spew as assert
+// does in its diffs.
+func Spew(value interface{}) (string, error) {
+	return spewConfig.Sdump(value), nil
+}
+
+// JSON is a Serializer rendering values as indented JSON, with the keys
+// of maps sorted.
+func JSON(value interface{}) (string, error) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// dir is the directory holding the snapshot files.
+var dir = filepath.Join("testdata", "__snapshots__")
+
+var (
+	// mutex protects the snapshot files and the counters below, since
+	// parallel tests may match snapshots concurrently.
+	mutex sync.Mutex
+
+	// files holds the snapshot files loaded so far, by path.
+	files = make(map[string]*file)
+
+	// calls counts the snapshots matched by each test, until the test
+	// completes if it has a Cleanup method.
+	calls = make(map[TestingT]int)
+)
+
+// cleaner is the TestingT of tests that can register a function called
+// when they complete, as *testing.T does.
+type cleaner interface {
+	Cleanup(func())
+}
+
+// Match asserts that value, rendered by Spew, matches the snapshot stored
+// for this call of the test.
+//
+//  snapshot.Match(t, user)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func Match(t TestingT, value interface{}, msgAndArgs ...interface{}) bool {
+	return MatchWith(t, Spew, value, msgAndArgs...)
+}
+
+// MatchJSON asserts that value, rendered by JSON, matches the snapshot
+// stored for this call of the test.
+//
+//  snapshot.MatchJSON(t, response)
+//
+// Returns whether the assertion was successful (true) or not (false).
+func MatchJSON(t TestingT, value interface{}, msgAndArgs ...interface{}) bool {
+	return MatchWith(t, JSON, value, msgAndArgs...)
+}
+
+// MatchWith asserts that value, rendered by serialize, matches the
+// snapshot stored for this call of the test.
+//
+// The snapshots of a test are stored in
+// testdata/__snapshots__/<Test>.snap, where <Test> is the name of the
+// top-level test, and are keyed by the full name of the test and the
+// index of the call to Match in the test.  The methods of a suite are
+// named after the test running the suite, as in TestSuite/TestMethod.  A missing snapshot is
+// written, unless $CI is set in which case the assertion fails.  Running
+// the tests with -testify.update, or with $TESTIFY_UPDATE set, rewrites
+// the snapshots that do not match instead of failing.
+//
+// Returns whether the assertion was successful (true) or not (false).
+func MatchWith(t TestingT, serialize Serializer, value interface{}, msgAndArgs ...interface{}) bool {
+	actual, err := serialize(value)
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("Cannot serialize value for snapshot: %s", err), msgAndArgs...)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if _, ok := calls[t]; !ok {
+		if c, ok := t.(cleaner); ok {
+			c.Cleanup(func() {
+				mutex.Lock()
+				defer mutex.Unlock()
+				delete(calls, t)
+			})
+		}
+	}
+	calls[t]++
+	name := t.Name()
+	key := fmt.Sprintf("%s %d", name, calls[t])
+	path := filepath.Join(dir, fileName(name))
+	f, err := loadFile(path)
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("Cannot read snapshot file %s: %s", path, err), msgAndArgs...)
+	}
+	f.used[key] = true
+
+	expected, ok := f.entries[key]
+	switch {
+	case ok && expected == actual:
+		return true
+	case !ok && !updating() && os.Getenv("CI") != "":
+		return assert.Fail(t, fmt.Sprintf("Snapshot %q is missing from %s, run the tests with -testify.update to write it", key, path), msgAndArgs...)
+	case !ok || updating():
+		f.entries[key] = actual
+		if err := f.write(); err != nil {
+			return assert.Fail(t, fmt.Sprintf("Cannot write snapshot file %s: %s", path, err), msgAndArgs...)
+		}
+		return true
+	}
+
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(expected),
+		B:        difflib.SplitLines(actual),
+		FromFile: "Snapshot",
+		ToFile:   "Actual",
+		Context:  3,
+	})
+	return assert.Fail(t, fmt.Sprintf("Snapshot %q of %s does not match, run the tests with -testify.update to update it.\n\nDiff:\n%s", key, path, diff), msgAndArgs...)
+}
+
+// fileName returns the name of the file holding the snapshots of the
+// named test.
+func fileName(testName string) string {
+	if i := strings.Index(testName, "/"); i >= 0 {
+		testName = testName[:i]
+	}
+	return testName + ".snap"
+}
+
+// updating returns whether the snapshots should be rewritten, as set by
+// the -testify.update flag of assert.
+func updating() bool {
+	f := flag.Lookup("testify.update")
+	return f != nil && f.Value.String() == "true"
+}
+
+// Obsolete returns the snapshots that were not matched by the tests run
+// so far, described as "<file>: <key>", or as "<file>" for whole files.
+// Obsolete snapshots can only be detected when all the tests of the
+// package run, so Obsolete returns nil if the tests were selected with
+// -run.
+func Obsolete() []string {
+	if f := flag.Lookup("test.run"); f != nil && f.Value.String() != "" {
+		return nil
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	obsolete := []string{}
+	for _, path := range snapshotFiles() {
+		f, ok := files[path]
+		if !ok {
+			obsolete = append(obsolete, path)
+			continue
+		}
+		for _, key := range f.keys() {
+			if !f.used[key] {
+				obsolete = append(obsolete, fmt.Sprintf("%s: %s", path, key))
+			}
+		}
+	}
+	return obsolete
+}
+
+// removeObsolete removes the snapshots that were not matched by the tests
+// run so far.
+func removeObsolete() error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for _, path := range snapshotFiles() {
+		f, ok := files[path]
+		if !ok {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+			continue
+		}
+		for key := range f.entries {
+			if !f.used[key] {
+				delete(f.entries, key)
+			}
+		}
+		if err := f.write(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotFiles returns the paths of the snapshot files on disk.
+func snapshotFiles() []string {
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.snap"))
+	return paths
+}
+
+// Run runs the tests and reports the obsolete snapshots, or removes them
+// if the tests pass and are run with -testify.update.  It returns the
+// exit code of the tests, and is meant to be called from TestMain:
+//
+//    func TestMain(m *testing.M) {
+//      os.Exit(snapshot.Run(m))
+//    }
+func Run(m *testing.M) int {
+	code := m.Run()
+	obsolete := Obsolete()
+	if len(obsolete) == 0 {
+		return code
+	}
+
+	if updating() && code == 0 {
+		if err := removeObsolete(); err != nil {
+			fmt.Printf("testify: cannot remove obsolete snapshots: %s\n", err)
+			return 1
+		}
+		fmt.Printf("testify: removed %d obsolete snapshot(s)\n", len(obsolete))
+		return code
+	}
+	fmt.Printf("testify: %d obsolete snapshot(s), run the tests with -testify.update to remove them:\n  %s\n", len(obsolete), strings.Join(obsolete, "\n  "))
+	return code
+}
@@ -0,0 +1,215 @@
+package snapshot
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// bufferT is a TestingT with a name, recording the messages of failed
+// assertions.
+type bufferT struct {
+	name     string
+	messages []string
+}
+
+func (t *bufferT) Errorf(format string, args ...interface{}) {
+	t.messages = append(t.messages, fmt.Sprintf(format, args...))
+}
+
+func (t *bufferT) Name() string {
+	return t.name
+}
+
+// useTempDir stores the snapshots in a new temporary directory, and
+// forgets the snapshot files loaded so far.  It returns a function
+// restoring the previous state.
+func useTempDir(t *testing.T) func() {
+	tmp, err := ioutil.TempDir("", "testify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	previousDir, previousFiles, previousCalls := dir, files, calls
+	dir = tmp
+	files = make(map[string]*file)
+	calls = make(map[TestingT]int)
+	return func() {
+		os.RemoveAll(tmp)
+		dir, files, calls = previousDir, previousFiles, previousCalls
+	}
+}
+
+// forget makes the snapshot files be read again, as in a new run.
+func forget() {
+	files = make(map[string]*file)
+}
+
+type user struct {
+	Name  string
+	Age   int
+	Tags  map[string]bool
+	Admin *bool
+}
+
+func TestMatch(t *testing.T) {
+	defer useTempDir(t)()
+	admin := true
+	mat := user{Name: "Mat", Age: 30, Tags: map[string]bool{"b": true, "a": false}, Admin: &admin}
+
+	mockT := &bufferT{name: "TestUser/mat"}
+	assert.True(t, Match(mockT, mat))
+	assert.True(t, MatchJSON(mockT, mat))
+	assert.Empty(t, mockT.messages)
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "TestUser.snap"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "--- snapshot: TestUser/mat 1\n(snapshot.user) {\n Name: (string) (len=3) \"Mat\",\n")
+	assert.Contains(t, string(content), "--- snapshot: TestUser/mat 2\n{\n  \"Name\": \"Mat\",\n")
+
+	forget()
+	mockT = &bufferT{name: "TestUser/mat"}
+	assert.True(t, Match(mockT, mat))
+	mat.Age = 31
+	assert.False(t, MatchJSON(mockT, mat))
+	if assert.Len(t, mockT.messages, 1) {
+		assert.Contains(t, mockT.messages[0], `Snapshot "TestUser/mat 2" of `+filepath.Join(dir, "TestUser.snap")+" does not match")
+		assert.Contains(t, mockT.messages[0], `-  "Age": 30,`)
+		assert.Contains(t, mockT.messages[0], `+  "Age": 31,`)
+	}
+
+	assert.False(t, MatchWith(&bufferT{name: "TestUser"}, JSON, func() {}))
+}
+
+func TestMatchUpdate(t *testing.T) {
+	defer useTempDir(t)()
+	assert.True(t, Match(&bufferT{name: "TestValue"}, 1))
+
+	forget()
+	mockT := &bufferT{name: "TestValue"}
+	assert.False(t, Match(mockT, 2))
+
+	setUpdate(t, true)
+	defer setUpdate(t, false)
+
+	forget()
+	assert.True(t, Match(&bufferT{name: "TestValue"}, 2))
+	setUpdate(t, false)
+	forget()
+	assert.True(t, Match(&bufferT{name: "TestValue"}, 2))
+}
+
+func TestMatchMissingInCI(t *testing.T) {
+	defer useTempDir(t)()
+	os.Setenv("CI", "true")
+	defer os.Unsetenv("CI")
+
+	mockT := &bufferT{name: "TestMissing"}
+	assert.False(t, Match(mockT, 1))
+	if assert.Len(t, mockT.messages, 1) {
+		assert.Contains(t, mockT.messages[0], `Snapshot "TestMissing 1" is missing`)
+	}
+}
+
+func TestObsolete(t *testing.T) {
+	defer useTempDir(t)()
+	kept := &bufferT{name: "TestKept"}
+	Match(kept, 1)
+	Match(kept, 2)
+	Match(&bufferT{name: "TestKept/sub"}, 3)
+	Match(&bufferT{name: "TestRemoved"}, 4)
+
+	forget()
+	Match(&bufferT{name: "TestKept"}, 1)
+	Match(&bufferT{name: "TestKept/sub"}, 3)
+
+	keptFile := filepath.Join(dir, "TestKept.snap")
+	removedFile := filepath.Join(dir, "TestRemoved.snap")
+	assert.Equal(t, []string{keptFile + ": TestKept 2", removedFile}, Obsolete())
+
+	assert.NoError(t, removeObsolete())
+	assert.Empty(t, Obsolete())
+	_, err := os.Stat(removedFile)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestParse(t *testing.T) {
+	entries := map[string]string{
+		"TestA 1":  "--- snapshot: TestB 1\n\\escaped\n",
+		"TestA 2":  "",
+		"TestA 10": "no trailing newline",
+	}
+	f := &file{path: filepath.Join(os.TempDir(), "testify-parse.snap"), entries: entries}
+	defer os.Remove(f.path)
+	assert.NoError(t, f.write())
+
+	content, _ := ioutil.ReadFile(f.path)
+	assert.Equal(t, "--- snapshot: TestA 1\n\\--- snapshot: TestB 1\n\\\\escaped\n\n--- snapshot: TestA 2\n\n--- snapshot: TestA 10\nno trailing newline\n", string(content))
+	assert.Equal(t, entries, parse(string(content)))
+}
+
+// snapshotSuite matches snapshots from suite methods.
+type snapshotSuite struct {
+	suite.Suite
+}
+
+func (s *snapshotSuite) TestSnapshot() {
+	Match(s.Runner(), []string{"a", "b"})
+}
+
+func TestMatchInSuite(t *testing.T) {
+	defer useTempDir(t)()
+	r := suite.NewFakeRunner("TestSnapshotSuite")
+	suite.Run(r, new(snapshotSuite))
+	assert.False(t, r.Failed())
+
+	_, err := os.Stat(filepath.Join(dir, "TestSnapshotSuite.snap"))
+	assert.NoError(t, err)
+
+	forget()
+	r = suite.NewFakeRunner("TestSnapshotSuite")
+	suite.Run(r, new(snapshotSuite))
+	assert.False(t, r.Failed())
+}
+
+func setUpdate(t *testing.T, update bool) {
+	if err := flag.Set("testify.update", fmt.Sprint(update)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// valueSuite matches a snapshot of its value from a method named as in
+// other suites.
+type valueSuite struct {
+	suite.Suite
+	value string
+}
+
+func (s *valueSuite) TestValue() {
+	Match(s.T(), s.value)
+}
+
+func TestMatchInSuites(t *testing.T) {
+	defer useTempDir(t)()
+	for _, value := range []string{"from A", "from B"} {
+		s := &valueSuite{value: value}
+		t.Run(value, func(t *testing.T) {
+			suite.Run(t, s)
+			assert.False(t, t.Failed())
+		})
+	}
+
+	f, err := loadFile(filepath.Join(dir, "TestMatchInSuites.snap"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]string{
+			"TestMatchInSuites/from_A/TestValue 1": spewConfig.Sdump("from A"),
+			"TestMatchInSuites/from_B/TestValue 1": spewConfig.Sdump("from B"),
+		}, f.entries)
+	}
+	assert.Empty(t, calls, "the calls are forgotten once the tests complete")
+}
@@ -0,0 +1,80 @@
+package suite
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// RunBenchmarks takes a benchmarking suite and runs all of the
+// benchmarks attached to it as sub-benchmarks of b.  Benchmark methods
+// take no parameters and are expected to run their benchmarked code
+// B().N times.  SetupTest and TearDownTest are run around each
+// benchmark, excluded from its timing, and SetupSuite and TearDownSuite
+// around the whole suite.
+func RunBenchmarks(b *testing.B, suite BenchmarkingSuite) {
+	suite.SetB(b)
+
+	if setupAllSuite, ok := suite.(SetupAllSuite); ok {
+		setupAllSuite.SetupSuite()
+	}
+	defer func() {
+		if tearDownAllSuite, ok := suite.(TearDownAllSuite); ok {
+			tearDownAllSuite.TearDownSuite()
+		}
+	}()
+
+	methodFinder := reflect.TypeOf(suite)
+	for index := 0; index < methodFinder.NumMethod(); index++ {
+		method := methodFinder.Method(index)
+		ok, err := benchmarkFilter(method.Name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "testify: invalid regexp for -m: %s\n", err)
+			os.Exit(1)
+		}
+		if !ok {
+			continue
+		}
+		b.Run(method.Name, func(b *testing.B) {
+			runBenchmark(b, suite, method)
+		})
+	}
+}
+
+// runBenchmark runs a single suite method as the benchmark b, wrapping
+// it with the SetupTest and TearDownTest methods if the suite has them.
+func runBenchmark(b *testing.B, suite BenchmarkingSuite, method reflect.Method) {
+	if method.Type.NumIn() != 1 {
+		b.Fatalf("testify: benchmark method %s must not take parameters", method.Name)
+	}
+
+	parentB := suite.B()
+	suite.SetB(b)
+	defer suite.SetB(parentB)
+
+	b.StopTimer()
+	if setupTestSuite, ok := suite.(SetupTestSuite); ok {
+		setupTestSuite.SetupTest()
+	}
+	defer func() {
+		b.StopTimer()
+		if tearDownTestSuite, ok := suite.(TearDownTestSuite); ok {
+			tearDownTestSuite.TearDownTest()
+		}
+	}()
+
+	b.ResetTimer()
+	b.StartTimer()
+	method.Func.Call([]reflect.Value{reflect.ValueOf(suite)})
+}
+
+// benchmarkFilter filters benchmark methods according to the regular
+// expression specified by the command-line argument -m.
+func benchmarkFilter(name string) (bool, error) {
+	if ok, _ := regexp.MatchString("^Benchmark", name); !ok {
+		return false, nil
+	}
+	return regexp.MatchString(*matchMethod, name)
+}
@@ -0,0 +1,78 @@
+package suite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// SuiteBenchmarkTester has benchmark methods, and counts how many times
+// each of the setup and teardown methods is run.
+type SuiteBenchmarkTester struct {
+	Suite
+
+	SetupSuiteRunCount    int
+	TearDownSuiteRunCount int
+	SetupTestRunCount     int
+	TearDownTestRunCount  int
+	Iterations            int
+	TestRunCount          int
+}
+
+func (suite *SuiteBenchmarkTester) SetupSuite() {
+	suite.SetupSuiteRunCount++
+}
+
+func (suite *SuiteBenchmarkTester) TearDownSuite() {
+	suite.TearDownSuiteRunCount++
+}
+
+func (suite *SuiteBenchmarkTester) SetupTest() {
+	suite.SetupTestRunCount++
+}
+
+func (suite *SuiteBenchmarkTester) TearDownTest() {
+	suite.TearDownTestRunCount++
+}
+
+func (suite *SuiteBenchmarkTester) BenchmarkIterations() {
+	for i := 0; i < suite.B().N; i++ {
+		suite.Iterations++
+	}
+	suite.True(suite.Iterations > 0)
+}
+
+// TestNotABenchmark must not be run by RunBenchmarks.
+func (suite *SuiteBenchmarkTester) TestNotABenchmark() {
+	suite.TestRunCount++
+}
+
+func TestRunBenchmarks(t *testing.T) {
+	suiteTester := new(SuiteBenchmarkTester)
+	testing.Benchmark(func(b *testing.B) {
+		RunBenchmarks(b, suiteTester)
+	})
+
+	assert.Equal(t, 1, suiteTester.SetupSuiteRunCount)
+	assert.Equal(t, 1, suiteTester.TearDownSuiteRunCount)
+
+	// The benchmark is run at least once, and SetupTest and TearDownTest
+	// are run every time it is.
+	assert.True(t, suiteTester.SetupTestRunCount > 0)
+	assert.Equal(t, suiteTester.SetupTestRunCount, suiteTester.TearDownTestRunCount)
+	assert.True(t, suiteTester.Iterations > 0)
+	assert.Equal(t, 0, suiteTester.TestRunCount)
+}
+
+func TestSuiteBenchmarkGetters(t *testing.T) {
+	suite := new(SuiteBenchmarkTester)
+	b := new(testing.B)
+	suite.SetB(b)
+	assert.Equal(t, b, suite.B())
+	assert.NotNil(t, suite.Assert())
+	assert.NotNil(t, suite.Require())
+}
+
+func BenchmarkRunBenchmarks(b *testing.B) {
+	RunBenchmarks(b, new(SuiteBenchmarkTester))
+}
@@ -18,16 +18,64 @@
 // with "Test" will not be run by testify, and can safely be used as
 // helper methods.
 //
+// A test method may also take a single parameter, in which case it is
+// run once for every case returned by a provider method named after it
+// with the "Test" prefix replaced by a "Cases" suffix.  For example,
+// TestParse(tc ParseCase) is run for each case returned by either
+// ParseCases() []ParseCase, where cases are named by their index, or
+// ParseCases() map[string]ParseCase, where cases are named by their
+// key.  Each case is its own test, wrapped by SetupTest and
+// TearDownTest.
+//
 // Once you've built your testing suite, you need to run the suite
 // (using suite.Run from testify) inside any function that matches the
 // identity that "go test" is already looking for (i.e.
 // func(*testing.T)).
 //
+// suite.Run also accepts a Runner instead of a *testing.T, which allows
+// suites to be driven by custom harnesses.  FakeRunner is a Runner that
+// runs suites in memory and records their results, which is useful for
+// testing suites themselves.  Suites run this way have no *testing.T,
+// and should use the Runner() method of the suite rather than T().
+//
+// Methods that begin with "Benchmark" are benchmarks, and are run as
+// sub-benchmarks by passing the suite to suite.RunBenchmarks inside a
+// func(*testing.B).  The current *testing.B is returned by the B()
+// method of the suite, and while benchmarks run the assertion methods
+// of the suite report through it.  SetupTest and TearDownTest are run
+// around each benchmark without being timed.
+//
 // Regular expression to select test suites specified command-line
 // argument "-run". Regular expression to select the methods
 // of test suites specified command-line argument "-m".
 // Suite object has assertion methods.
 //
+// Test methods can be tagged by implementing the TaggedSuite interface.
+// The command-line argument "-testify.tags" (or the TESTIFY_TAGS
+// environment variable) selects the methods having any of the listed
+// tags, and skips those having any of the tags prefixed with "!", e.g.
+// "-testify.tags=slow,!db".  Methods tagged with "focus" are the only
+// ones run in their suite, and methods tagged with "flaky" are retried
+// up to "-testify.flaky-retries" times before failing.  Skipped and
+// retried methods are listed in the summary logged after the suite.
+//
+// Suites implementing the MethodTimeoutSuite interface fail any test
+// method that runs longer than the returned duration, and a method can
+// have its own timeout with a tag such as "timeout=30s".  Suites
+// implementing the SuiteTimeoutSuite interface likewise fail the method
+// running once the whole suite has run longer than the returned
+// duration, along with the methods left to run.  A timed out method
+// fails with the stacks of the goroutines running code from the suite's
+// package, its TearDownTest is run and the suite continues with the next
+// method, leaving the timed out method running in the background.  What
+// the timed out method reports through the Runner it was given is
+// dropped, but it shares the suite with the following methods, so it
+// should not use the suite, or its assertions, once it is unblocked.
+//
+// Suites implementing the GoroutineLeakSuite interface fail any test
+// method that leaves goroutines running once it and its SetupTest and
+// TearDownTest have returned, as assert.NoGoroutineLeaks does.
+//
 // A crude example:
 //     // Basic imports
 //     import (
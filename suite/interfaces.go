@@ -1,6 +1,11 @@
 package suite
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
 
 // TestingSuite can store and return the current *testing.T context
 // generated by 'go test'.
@@ -9,6 +14,13 @@ type TestingSuite interface {
 	SetT(*testing.T)
 }
 
+// RunnerSuite can store and return the current Runner context, which
+// allows it to be run by runners that are not backed by a *testing.T.
+type RunnerSuite interface {
+	Runner() Runner
+	SetRunner(Runner)
+}
+
 // SetupAllSuite has a SetupSuite method, which will run before the
 // tests in the suite are run.
 type SetupAllSuite interface {
@@ -32,3 +44,40 @@ type TearDownAllSuite interface {
 type TearDownTestSuite interface {
 	TearDownTest()
 }
+
+// TaggedSuite has a Tags method, which returns the tags of the test
+// methods in the suite, keyed by method name.  Tags are used to select
+// the methods to run with -testify.tags, and the FocusTag and FlakyTag
+// tags change how the tagged methods are run.
+type TaggedSuite interface {
+	Tags() map[string][]string
+}
+
+// MethodTimeoutSuite has a MethodTimeout method, which returns how long
+// each test in the suite may run before it is failed.  The timeout of
+// individual methods can be overridden with a "timeout=<duration>" tag.
+type MethodTimeoutSuite interface {
+	MethodTimeout() time.Duration
+}
+
+// SuiteTimeoutSuite has a SuiteTimeout method, which returns how long
+// the whole suite may run.  The test running when the suite times out
+// fails, and so do the tests left to run.
+type SuiteTimeoutSuite interface {
+	SuiteTimeout() time.Duration
+}
+
+// GoroutineLeakSuite has a GoroutineLeakOptions method, and each test in
+// the suite fails if goroutines started by the test, including by its
+// SetupTest and TearDownTest, are still running once it completes.  The
+// returned options configure how leaks are detected.
+type GoroutineLeakSuite interface {
+	GoroutineLeakOptions() assert.GoroutineLeakOptions
+}
+
+// BenchmarkingSuite can store and return the current *testing.B context
+// generated by 'go test -bench'.
+type BenchmarkingSuite interface {
+	B() *testing.B
+	SetB(*testing.B)
+}
@@ -0,0 +1,76 @@
+package suite
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// blockForever blocks until release is closed, and is the leaked
+// goroutine of SuiteLeakTester.
+func blockForever(release chan struct{}) {
+	<-release
+}
+
+// blockIgnored blocks until release is closed, and is ignored by the
+// options of SuiteLeakTester.
+func blockIgnored(release chan struct{}) {
+	<-release
+}
+
+// SuiteLeakTester has methods leaving goroutines running or not, and is
+// checked for goroutine leaks after each method.
+type SuiteLeakTester struct {
+	Suite
+	release chan struct{}
+}
+
+func (suite *SuiteLeakTester) GoroutineLeakOptions() assert.GoroutineLeakOptions {
+	return assert.GoroutineLeakOptions{
+		Ignore:      []*regexp.Regexp{regexp.MustCompile(`suite\.blockIgnored\(`)},
+		GracePeriod: 100 * time.Millisecond,
+	}
+}
+
+func (suite *SuiteLeakTester) TestLeaks() {
+	go blockForever(suite.release)
+}
+
+func (suite *SuiteLeakTester) TestStopsLate() {
+	go time.Sleep(20 * time.Millisecond)
+}
+
+func (suite *SuiteLeakTester) TestIgnored() {
+	go blockIgnored(suite.release)
+}
+
+func (suite *SuiteLeakTester) TestNoGoroutine() {}
+
+func TestRunSuiteWithLeakCheck(t *testing.T) {
+	suiteTester := &SuiteLeakTester{release: make(chan struct{})}
+	defer close(suiteTester.release)
+
+	runner := NewFakeRunner("TestRunSuiteWithLeakCheck")
+	Run(runner, suiteTester)
+
+	failed := map[string]bool{}
+	logs := map[string]string{}
+	for _, result := range runner.Results()[1:] {
+		failed[result.Name] = result.Failed
+		logs[result.Name] = strings.Join(result.Logs, "\n")
+	}
+	assert.Equal(t, map[string]bool{
+		"TestRunSuiteWithLeakCheck/TestIgnored":     false,
+		"TestRunSuiteWithLeakCheck/TestLeaks":       true,
+		"TestRunSuiteWithLeakCheck/TestNoGoroutine": false,
+		"TestRunSuiteWithLeakCheck/TestStopsLate":   false,
+	}, failed)
+
+	leaks := logs["TestRunSuiteWithLeakCheck/TestLeaks"]
+	assert.Contains(t, leaks, "testify: method TestLeaks leaked goroutines")
+	assert.Contains(t, leaks, "suite.blockForever(")
+	assert.Contains(t, leaks, "created by github.com/stretchr/testify/suite.(*SuiteLeakTester).TestLeaks")
+}
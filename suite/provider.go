@@ -0,0 +1,71 @@
+package suite
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// testCase is a single named argument for a parameterized suite method.
+type testCase struct {
+	name  string
+	value reflect.Value
+}
+
+// providerName returns the name of the method providing the cases for
+// a parameterized test method: the "Test" prefix is replaced by a
+// "Cases" suffix, so TestParse is provided for by ParseCases.
+func providerName(methodName string) string {
+	return strings.TrimPrefix(methodName, "Test") + "Cases"
+}
+
+// methodCases calls the provider of a parameterized suite method and
+// returns its cases.  A provider returning a slice yields cases named
+// by their index, and one returning a map with string keys yields cases
+// named by their key, in sorted order.
+func methodCases(suite TestingSuite, method reflect.Method) ([]testCase, error) {
+	paramType := method.Type.In(1)
+	name := providerName(method.Name)
+
+	provider := reflect.ValueOf(suite).MethodByName(name)
+	if !provider.IsValid() {
+		return nil, fmt.Errorf("method %s takes a %s parameter, but the suite has no %s() method providing its cases", method.Name, paramType, name)
+	}
+
+	providerType := provider.Type()
+	if providerType.NumIn() != 0 || providerType.NumOut() != 1 {
+		return nil, fmt.Errorf("%s must take no parameters and return []%s or map[string]%s", name, paramType, paramType)
+	}
+
+	resultType := providerType.Out(0)
+	switch {
+	case resultType.Kind() == reflect.Slice && resultType.Elem().AssignableTo(paramType):
+		result := provider.Call(nil)[0]
+		cases := make([]testCase, result.Len())
+		for i := range cases {
+			cases[i] = testCase{name: strconv.Itoa(i), value: result.Index(i)}
+		}
+		return cases, nil
+
+	case resultType.Kind() == reflect.Map && resultType.Key().Kind() == reflect.String && resultType.Elem().AssignableTo(paramType):
+		result := provider.Call(nil)[0]
+		keys := result.MapKeys()
+		sort.Sort(byString(keys))
+		cases := make([]testCase, len(keys))
+		for i, key := range keys {
+			cases[i] = testCase{name: key.String(), value: result.MapIndex(key)}
+		}
+		return cases, nil
+	}
+
+	return nil, fmt.Errorf("%s returns %s, but must return []%s or map[string]%s for method %s", name, resultType, paramType, paramType, method.Name)
+}
+
+// byString sorts string values.
+type byString []reflect.Value
+
+func (s byString) Len() int           { return len(s) }
+func (s byString) Less(i, j int) bool { return s[i].String() < s[j].String() }
+func (s byString) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
@@ -0,0 +1,104 @@
+package suite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ParseCase struct {
+	Input    string
+	Expected int
+}
+
+// SuiteProviderTester has parameterized test methods whose cases are
+// returned by slice and map providers.
+type SuiteProviderTester struct {
+	Suite
+
+	SetupTestRunCount    int
+	TearDownTestRunCount int
+	ParsedInputs         []string
+	NamedInputs          []string
+}
+
+func (suite *SuiteProviderTester) SetupTest() {
+	suite.SetupTestRunCount++
+}
+
+func (suite *SuiteProviderTester) TearDownTest() {
+	suite.TearDownTestRunCount++
+}
+
+func (suite *SuiteProviderTester) ParseCases() []ParseCase {
+	return []ParseCase{
+		{Input: "one", Expected: 3},
+		{Input: "three", Expected: 5},
+	}
+}
+
+func (suite *SuiteProviderTester) TestParse(tc ParseCase) {
+	suite.ParsedInputs = append(suite.ParsedInputs, tc.Input)
+	suite.Equal(tc.Expected, len(tc.Input))
+}
+
+func (suite *SuiteProviderTester) NamedCases() map[string]ParseCase {
+	return map[string]ParseCase{
+		"b": {Input: "bb", Expected: 2},
+		"a": {Input: "a", Expected: 1},
+	}
+}
+
+func (suite *SuiteProviderTester) TestNamed(tc ParseCase) {
+	suite.NamedInputs = append(suite.NamedInputs, tc.Input)
+	suite.Equal(tc.Expected, len(tc.Input))
+}
+
+func TestRunSuiteWithProviders(t *testing.T) {
+	suiteTester := new(SuiteProviderTester)
+	Run(t, suiteTester)
+
+	assert.Equal(t, []string{"one", "three"}, suiteTester.ParsedInputs)
+	assert.Equal(t, []string{"a", "bb"}, suiteTester.NamedInputs)
+
+	// Every case is a test of its own, so SetupTest and TearDownTest run
+	// once per case.
+	assert.Equal(t, 4, suiteTester.SetupTestRunCount)
+	assert.Equal(t, 4, suiteTester.TearDownTestRunCount)
+}
+
+// SuiteMissingProviderTester has a parameterized test method without a
+// provider, which must fail rather than panic.
+type SuiteMissingProviderTester struct {
+	Suite
+	TestRunCount int
+}
+
+func (suite *SuiteMissingProviderTester) TestMissing(tc ParseCase) {
+	suite.TestRunCount++
+}
+
+func TestRunSuiteWithMissingProvider(t *testing.T) {
+	suiteTester := new(SuiteMissingProviderTester)
+	runner := NewFakeRunner("TestRunSuiteWithMissingProvider")
+	Run(runner, suiteTester)
+	assert.True(t, runner.Failed())
+	assert.Equal(t, 0, suiteTester.TestRunCount)
+}
+
+func TestMethodCases(t *testing.T) {
+	suite := new(SuiteProviderTester)
+	method, _ := reflect.TypeOf(suite).MethodByName("TestNamed")
+
+	cases, err := methodCases(suite, method)
+	if assert.NoError(t, err) && assert.Len(t, cases, 2) {
+		assert.Equal(t, "a", cases[0].name)
+		assert.Equal(t, "b", cases[1].name)
+	}
+
+	missing := new(SuiteMissingProviderTester)
+	method, _ = reflect.TypeOf(missing).MethodByName("TestMissing")
+	_, err = methodCases(missing, method)
+	assert.EqualError(t, err, "method TestMissing takes a suite.ParseCase parameter, but the suite has no MissingCases() method providing its cases")
+}
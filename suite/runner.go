@@ -0,0 +1,303 @@
+package suite
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestingT is an interface wrapper around *testing.T, covering the
+// methods that suites use to report their results.
+type TestingT interface {
+	Error(args ...interface{})
+	Errorf(format string, args ...interface{})
+	Fail()
+	FailNow()
+	Failed() bool
+	Fatal(args ...interface{})
+	Fatalf(format string, args ...interface{})
+	Log(args ...interface{})
+	Logf(format string, args ...interface{})
+	Name() string
+	Skip(args ...interface{})
+	SkipNow()
+	Skipf(format string, args ...interface{})
+	Skipped() bool
+	Helper()
+	Cleanup(func())
+}
+
+// Runner runs the tests of a suite.  Suites are usually run by the
+// *testing.T of a test function, through the Runner returned by
+// NewRunner, but other runners allow suites to be driven by custom
+// harnesses.
+type Runner interface {
+	TestingT
+
+	// Run runs f as a test named name, and reports whether it passed.
+	// The failure of the test is not propagated to the receiver.
+	Run(name string, f func(Runner)) bool
+}
+
+// testingRunner is a Runner backed by a *testing.T.
+type testingRunner struct {
+	*testing.T
+}
+
+// NewRunner returns a Runner backed by t.  Tests are run with
+// testing.RunTests, so that their failures are reported by the go test
+// tool without failing t.
+func NewRunner(t *testing.T) Runner {
+	if t == nil {
+		return nil
+	}
+	return &testingRunner{t}
+}
+
+// Run runs f as a test named name under the test of r, as t.Run names
+// subtests, and reports whether it passed.
+func (r *testingRunner) Run(name string, f func(Runner)) bool {
+	return testing.RunTests(matchAll, []testing.InternalTest{{
+		Name: r.T.Name() + "/" + name,
+		F: func(t *testing.T) {
+			f(NewRunner(t))
+		},
+	}})
+}
+
+// matchAll is a match function for testing.RunTests that selects every
+// test, as the selection has already been made by testify.
+func matchAll(_, _ string) (bool, error) {
+	return true, nil
+}
+
+// suiteRunner returns the current Runner context of suite.
+func suiteRunner(suite TestingSuite) Runner {
+	if runnerSuite, ok := suite.(RunnerSuite); ok {
+		if s := suiteSwitchRunner(suite); s != nil {
+			return s.current()
+		}
+		return runnerSuite.Runner()
+	}
+	return NewRunner(suite.T())
+}
+
+// setRunner sets the current Runner context of suite, or the Runner its
+// switchRunner forwards to.  Suites that do not implement RunnerSuite can
+// only be run by a *testing.T.
+func setRunner(suite TestingSuite, r Runner) error {
+	if runnerSuite, ok := suite.(RunnerSuite); ok {
+		if s := suiteSwitchRunner(suite); s != nil {
+			s.set(r)
+			return nil
+		}
+		runnerSuite.SetRunner(r)
+		return nil
+	}
+	switch r := r.(type) {
+	case nil:
+		suite.SetT(nil)
+	case *testingRunner:
+		suite.SetT(r.T)
+	default:
+		return fmt.Errorf("suite %T must implement RunnerSuite to be run by %T", suite, r)
+	}
+	return nil
+}
+
+// FakeRunner is a Runner that runs tests in memory and records their
+// results, which makes it possible to test suites and to inspect their
+// results programmatically.
+//
+// As with *testing.T, FailNow, Fatal, SkipNow and Skip stop the test
+// by calling runtime.Goexit.  Tests run with Run are run in their own
+// goroutine, but calling these methods on the FakeRunner returned by
+// NewFakeRunner stops the calling goroutine.
+type FakeRunner struct {
+	name     string
+	mutex    sync.Mutex
+	failed   bool
+	skipped  bool
+	logs     []string
+	cleanups []func()
+	children []*FakeRunner
+}
+
+// NewFakeRunner returns a FakeRunner for a test named name.
+func NewFakeRunner(name string) *FakeRunner {
+	return &FakeRunner{name: name}
+}
+
+// Run runs f as a test named name in a new goroutine, waits for it to
+// return, and reports whether it passed.  A panic in f fails the test.
+func (r *FakeRunner) Run(name string, f func(Runner)) bool {
+	child := NewFakeRunner(r.name + "/" + name)
+	r.mutex.Lock()
+	r.children = append(r.children, child)
+	r.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer child.runCleanups()
+		defer func() {
+			if p := recover(); p != nil {
+				child.Errorf("panic: %v", p)
+			}
+		}()
+		f(child)
+	}()
+	<-done
+
+	return !child.Failed()
+}
+
+// runCleanups calls the functions registered with Cleanup, last added
+// first called.
+func (r *FakeRunner) runCleanups() {
+	r.mutex.Lock()
+	cleanups := r.cleanups
+	r.cleanups = nil
+	r.mutex.Unlock()
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+}
+
+// Name returns the name of the test, including the names of its parents
+// separated by slashes.
+func (r *FakeRunner) Name() string {
+	return r.name
+}
+
+// Log records args formatted as by fmt.Sprintln.
+func (r *FakeRunner) Log(args ...interface{}) {
+	r.log(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+// Logf records args formatted according to format.
+func (r *FakeRunner) Logf(format string, args ...interface{}) {
+	r.log(fmt.Sprintf(format, args...))
+}
+
+func (r *FakeRunner) log(s string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.logs = append(r.logs, s)
+}
+
+// Fail marks the test as failed.
+func (r *FakeRunner) Fail() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.failed = true
+}
+
+// Failed reports whether the test has failed.
+func (r *FakeRunner) Failed() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.failed
+}
+
+// FailNow marks the test as failed and stops it.
+func (r *FakeRunner) FailNow() {
+	r.Fail()
+	runtime.Goexit()
+}
+
+// Error is equivalent to Log followed by Fail.
+func (r *FakeRunner) Error(args ...interface{}) {
+	r.Log(args...)
+	r.Fail()
+}
+
+// Errorf is equivalent to Logf followed by Fail.
+func (r *FakeRunner) Errorf(format string, args ...interface{}) {
+	r.Logf(format, args...)
+	r.Fail()
+}
+
+// Fatal is equivalent to Log followed by FailNow.
+func (r *FakeRunner) Fatal(args ...interface{}) {
+	r.Log(args...)
+	r.FailNow()
+}
+
+// Fatalf is equivalent to Logf followed by FailNow.
+func (r *FakeRunner) Fatalf(format string, args ...interface{}) {
+	r.Logf(format, args...)
+	r.FailNow()
+}
+
+// SkipNow marks the test as skipped and stops it.
+func (r *FakeRunner) SkipNow() {
+	r.mutex.Lock()
+	r.skipped = true
+	r.mutex.Unlock()
+	runtime.Goexit()
+}
+
+// Skip is equivalent to Log followed by SkipNow.
+func (r *FakeRunner) Skip(args ...interface{}) {
+	r.Log(args...)
+	r.SkipNow()
+}
+
+// Skipf is equivalent to Logf followed by SkipNow.
+func (r *FakeRunner) Skipf(format string, args ...interface{}) {
+	r.Logf(format, args...)
+	r.SkipNow()
+}
+
+// Skipped reports whether the test was skipped.
+func (r *FakeRunner) Skipped() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.skipped
+}
+
+// Helper does nothing, as FakeRunner does not report caller locations.
+func (r *FakeRunner) Helper() {}
+
+// Cleanup registers f to be called when the test run by Run completes.
+func (r *FakeRunner) Cleanup(f func()) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.cleanups = append(r.cleanups, f)
+}
+
+// FakeResult is the recorded result of a test run by a FakeRunner.
+type FakeResult struct {
+	// Name is the full name of the test.
+	Name string
+
+	// Failed and Skipped report whether the test failed or was skipped.
+	Failed  bool
+	Skipped bool
+
+	// Logs holds everything logged by the test, including its errors.
+	Logs []string
+}
+
+// Results returns the results of the test and of all the tests run
+// under it, in the order they were run.
+func (r *FakeRunner) Results() []FakeResult {
+	r.mutex.Lock()
+	result := FakeResult{
+		Name:    r.name,
+		Failed:  r.failed,
+		Skipped: r.skipped,
+		Logs:    append([]string{}, r.logs...),
+	}
+	children := append([]*FakeRunner{}, r.children...)
+	r.mutex.Unlock()
+
+	results := []FakeResult{result}
+	for _, child := range children {
+		results = append(results, child.Results()...)
+	}
+	return results
+}
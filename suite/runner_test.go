@@ -0,0 +1,132 @@
+package suite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeRunner(t *testing.T) {
+	runner := NewFakeRunner("TestFake")
+	cleanups := []string{}
+
+	assert.True(t, runner.Run("pass", func(r Runner) {
+		r.Cleanup(func() { cleanups = append(cleanups, "first") })
+		r.Cleanup(func() { cleanups = append(cleanups, "second") })
+		r.Logf("hello %s", "world")
+	}))
+	assert.False(t, runner.Run("fail", func(r Runner) {
+		r.Fatal("stop")
+		r.Log("not reached")
+	}))
+	assert.True(t, runner.Run("skip", func(r Runner) {
+		r.Skipf("skipping %d", 1)
+	}))
+	assert.False(t, runner.Run("panic", func(r Runner) {
+		panic("boom")
+	}))
+
+	// Failures of the tests are not propagated to the runner itself.
+	assert.False(t, runner.Failed())
+	assert.Equal(t, []string{"second", "first"}, cleanups)
+
+	assert.Equal(t, []FakeResult{
+		{Name: "TestFake", Logs: []string{}},
+		{Name: "TestFake/pass", Logs: []string{"hello world"}},
+		{Name: "TestFake/fail", Failed: true, Logs: []string{"stop"}},
+		{Name: "TestFake/skip", Skipped: true, Logs: []string{"skipping 1"}},
+		{Name: "TestFake/panic", Failed: true, Logs: []string{"panic: boom"}},
+	}, runner.Results())
+}
+
+// SuiteRunnerTester uses its Runner context rather than its *testing.T,
+// so it can be run by any Runner.
+type SuiteRunnerTester struct {
+	Suite
+	SetupTestRunCount int
+	TestOneRunCount   int
+}
+
+func (suite *SuiteRunnerTester) SetupTest() {
+	suite.SetupTestRunCount++
+}
+
+func (suite *SuiteRunnerTester) TestOne() {
+	suite.TestOneRunCount++
+	suite.Runner().Log("TestOne ran")
+}
+
+func (suite *SuiteRunnerTester) TestFail() {
+	suite.Equal(1, 2)
+}
+
+func (suite *SuiteRunnerTester) TestSkip() {
+	suite.Runner().Skip()
+}
+
+func TestRunSuiteWithFakeRunner(t *testing.T) {
+	suiteTester := new(SuiteRunnerTester)
+	runner := NewFakeRunner("TestRunSuite")
+	Run(runner, suiteTester)
+
+	assert.True(t, runner.Failed())
+	assert.Equal(t, 3, suiteTester.SetupTestRunCount)
+	assert.Equal(t, 1, suiteTester.TestOneRunCount)
+
+	// The suite is bound back to the runner it was run by.
+	assert.Equal(t, runner, suiteTester.Runner())
+	assert.Nil(t, suiteTester.T())
+
+	results := map[string]FakeResult{}
+	for _, result := range runner.Results() {
+		results[result.Name] = result
+	}
+	assert.Equal(t, []string{"TestOne ran"}, results["TestRunSuite/TestOne"].Logs)
+	assert.True(t, results["TestRunSuite/TestFail"].Failed)
+	assert.True(t, results["TestRunSuite/TestSkip"].Skipped)
+}
+
+func TestRunSuiteRequireWithFakeRunner(t *testing.T) {
+	runner := NewFakeRunner("TestSuiteRequireTwice")
+	Run(runner, new(SuiteRequireTwice))
+
+	assert.True(t, runner.Failed())
+	for _, result := range runner.Results()[1:] {
+		assert.True(t, result.Failed, result.Name)
+	}
+}
+
+// plainSuite implements TestingSuite but not RunnerSuite.
+type plainSuite struct {
+	t *testing.T
+}
+
+func (s *plainSuite) T() *testing.T     { return s.t }
+func (s *plainSuite) SetT(t *testing.T) { s.t = t }
+
+func TestRunPlainSuiteWithFakeRunner(t *testing.T) {
+	runner := NewFakeRunner("TestPlain")
+	runner.Run("suite", func(r Runner) {
+		Run(r, new(plainSuite))
+	})
+
+	results := runner.Results()
+	if assert.Len(t, results, 2) {
+		assert.True(t, results[1].Failed)
+		assert.Equal(t, []string{"testify: suite *suite.plainSuite must implement RunnerSuite to be run by *suite.FakeRunner"}, results[1].Logs)
+	}
+}
+
+func TestSuiteRunnerGetters(t *testing.T) {
+	suite := new(SuiteTester)
+	suite.SetT(t)
+	assert.Equal(t, NewRunner(t), suite.Runner())
+
+	runner := NewFakeRunner("TestGetters")
+	suite.SetRunner(runner)
+	assert.Equal(t, runner, suite.Runner())
+	assert.Nil(t, suite.T())
+
+	suite.SetRunner(NewRunner(t))
+	assert.Equal(t, t, suite.T())
+}
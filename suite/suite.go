@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,25 +16,73 @@ import (
 var matchMethod = flag.String("m", "", "regular expression to select tests of the suite to run")
 
 // Suite is a basic testing suite with methods for storing and
-// retrieving the current *testing.T context.
+// retrieving the current Runner, *testing.T and *testing.B contexts.
 type Suite struct {
 	*assert.Assertions
 	require *require.Assertions
+	runner  Runner
 	t       *testing.T
+	b       *testing.B
 }
 
-// T retrieves the current *testing.T context.
+// T retrieves the current *testing.T context.  It is nil while the
+// suite is run by a Runner that is not backed by a *testing.T.
 func (suite *Suite) T() *testing.T {
+	if s, ok := suite.runner.(*switchRunner); ok {
+		return s.T()
+	}
 	return suite.t
 }
 
 // SetT sets the current *testing.T context.
 func (suite *Suite) SetT(t *testing.T) {
 	suite.t = t
+	suite.runner = NewRunner(t)
 	suite.Assertions = assert.New(t)
 	suite.require = require.New(t)
 }
 
+// Runner retrieves the current Runner context.
+func (suite *Suite) Runner() Runner {
+	if s, ok := suite.runner.(*switchRunner); ok {
+		return s.current()
+	}
+	return suite.runner
+}
+
+// boundSwitchRunner returns the switchRunner the suite is bound to, if
+// any, which Runner does not return.
+func (suite *Suite) boundSwitchRunner() *switchRunner {
+	s, _ := suite.runner.(*switchRunner)
+	return s
+}
+
+// SetRunner sets the current Runner context, binding the assert and
+// require contexts of the suite to it.
+func (suite *Suite) SetRunner(r Runner) {
+	if tr, ok := r.(*testingRunner); ok {
+		suite.SetT(tr.T)
+		return
+	}
+	suite.t = nil
+	suite.runner = r
+	suite.Assertions = assert.New(r)
+	suite.require = require.New(r)
+}
+
+// B retrieves the current *testing.B context.
+func (suite *Suite) B() *testing.B {
+	return suite.b
+}
+
+// SetB sets the current *testing.B context, binding the assert and
+// require contexts of the suite to it.
+func (suite *Suite) SetB(b *testing.B) {
+	suite.b = b
+	suite.Assertions = assert.New(b)
+	suite.require = require.New(b)
+}
+
 // Require returns a require context for suite.
 func (suite *Suite) Require() *require.Assertions {
 	if suite.require == nil {
@@ -55,9 +104,25 @@ func (suite *Suite) Assert() *assert.Assertions {
 }
 
 // Run takes a testing suite and runs all of the tests attached
-// to it.
-func Run(t *testing.T, suite TestingSuite) {
-	suite.SetT(t)
+// to it.  t is either the *testing.T of the calling test, or a Runner
+// driving the suite by other means, such as a FakeRunner.  Suites run
+// by a Runner that is not backed by a *testing.T must implement
+// RunnerSuite.
+func Run(t TestingT, suite TestingSuite) {
+	r, ok := t.(Runner)
+	if !ok {
+		tt, ok := t.(*testing.T)
+		if !ok {
+			t.Fatalf("testify: suite.Run needs a *testing.T or a Runner, not %T", t)
+			return
+		}
+		r = NewRunner(tt)
+	}
+	if err := setRunner(suite, r); err != nil {
+		r.Fatalf("testify: %s", err)
+		return
+	}
+	w := newWatchdog(suite)
 
 	if setupAllSuite, ok := suite.(SetupAllSuite); ok {
 		setupAllSuite.SetupSuite()
@@ -69,7 +134,7 @@ func Run(t *testing.T, suite TestingSuite) {
 	}()
 
 	methodFinder := reflect.TypeOf(suite)
-	tests := []testing.InternalTest{}
+	methods := []reflect.Method{}
 	for index := 0; index < methodFinder.NumMethod(); index++ {
 		method := methodFinder.Method(index)
 		ok, err := methodFilter(method.Name)
@@ -78,30 +143,142 @@ func Run(t *testing.T, suite TestingSuite) {
 			os.Exit(1)
 		}
 		if ok {
-			test := testing.InternalTest{
-				Name: method.Name,
-				F: func(t *testing.T) {
-					parentT := suite.T()
-					suite.SetT(t)
-					if setupTestSuite, ok := suite.(SetupTestSuite); ok {
-						setupTestSuite.SetupTest()
-					}
-					defer func() {
-						if tearDownTestSuite, ok := suite.(TearDownTestSuite); ok {
-							tearDownTestSuite.TearDownTest()
-						}
-						suite.SetT(parentT)
-					}()
-					method.Func.Call([]reflect.Value{reflect.ValueOf(suite)})
-				},
+			methods = append(methods, method)
+		}
+	}
+
+	tagFilter := parseTagFilter(*matchTags)
+	focused := hasFocusedMethod(suite, methods)
+	summary := new(runSummary)
+	tests := []suiteTest{}
+	for _, method := range methods {
+		tags := methodTags(suite, method.Name)
+		switch {
+		case !tagFilter.match(tags):
+			summary.excluded = append(summary.excluded, method.Name)
+			tests = append(tests, skippedTest(method.Name, "excluded by -testify.tags"))
+		case focused && !hasTag(tags, FocusTag):
+			summary.unfocused = append(summary.unfocused, method.Name)
+			tests = append(tests, skippedTest(method.Name, "other methods of the suite are focused"))
+		case hasTag(tags, FlakyTag):
+			for _, test := range methodTests(suite, w, method) {
+				tests = append(tests, retryTest(test, *flakyRetries, summary))
 			}
-			tests = append(tests, test)
+		default:
+			tests = append(tests, methodTests(suite, w, method)...)
+		}
+	}
+
+	passed := true
+	for _, test := range tests {
+		if !r.Run(test.name, test.f) {
+			passed = false
+		}
+	}
+	if !passed {
+		r.Fail()
+	}
+	summary.log(r)
+}
+
+// suiteTest is a single test run by Run.
+type suiteTest struct {
+	name string
+	f    func(Runner)
+}
+
+// methodTests returns the tests to run for a single suite method.  A
+// method without parameters is a single test, while a parameterized
+// method yields one test per case returned by its provider.
+func methodTests(suite TestingSuite, w *watchdog, method reflect.Method) []suiteTest {
+	timeout, err := methodTimeout(suite, method.Name)
+	if err != nil {
+		return []suiteTest{failingTest(method.Name, err)}
+	}
+
+	switch method.Type.NumIn() {
+	case 1:
+		return []suiteTest{{
+			name: method.Name,
+			f: func(r Runner) {
+				runTest(r, suite, w, method, timeout)
+			},
+		}}
+	case 2:
+		cases, err := methodCases(suite, method)
+		if err != nil {
+			return []suiteTest{failingTest(method.Name, err)}
+		}
+		tests := make([]suiteTest, 0, len(cases))
+		for _, c := range cases {
+			value := c.value
+			tests = append(tests, suiteTest{
+				name: method.Name + "/" + c.name,
+				f: func(r Runner) {
+					runTest(r, suite, w, method, timeout, value)
+				},
+			})
+		}
+		return tests
+	}
+	err = fmt.Errorf("method %s must take at most one parameter, but takes %d", method.Name, method.Type.NumIn()-1)
+	return []suiteTest{failingTest(method.Name, err)}
+}
+
+// runTest runs a single suite method as the test r, wrapping it with
+// the SetupTest and TearDownTest methods if the suite has them.  If
+// timeout is positive, or the suite has a timeout, the test fails once
+// the method has run for too long, as callWithTimeout does, and
+// TearDownTest is run without waiting for the method to return.  If the
+// suite is a GoroutineLeakSuite, the test fails if it leaves goroutines
+// running.
+func runTest(r Runner, suite TestingSuite, w *watchdog, method reflect.Method, timeout time.Duration, args ...reflect.Value) {
+	if err := w.check(); err != nil {
+		r.Errorf("testify: %s", err)
+		return
+	}
+	leakSuite, ok := suite.(GoroutineLeakSuite)
+	if !ok {
+		runMethod(r, suite, w, method, timeout, args...)
+		return
+	}
+	assert.NoGoroutineLeaksWith(r, leakSuite.GoroutineLeakOptions(), func() {
+		runMethod(r, suite, w, method, timeout, args...)
+	}, "testify: method %s leaked goroutines", method.Name)
+}
+
+// runMethod runs a single suite method as the test r, as runTest does,
+// without checking for leaked goroutines.
+func runMethod(r Runner, suite TestingSuite, w *watchdog, method reflect.Method, timeout time.Duration, args ...reflect.Value) {
+	parent := suiteRunner(suite)
+	setRunner(suite, r)
+	if setupTestSuite, ok := suite.(SetupTestSuite); ok {
+		setupTestSuite.SetupTest()
+	}
+	defer func() {
+		if tearDownTestSuite, ok := suite.(TearDownTestSuite); ok {
+			tearDownTestSuite.TearDownTest()
 		}
+		setRunner(suite, parent)
+	}()
+	call := func() {
+		method.Func.Call(append([]reflect.Value{reflect.ValueOf(suite)}, args...))
+	}
+	if timeout, message := w.limit(method.Name, timeout); timeout > 0 {
+		callWithTimeout(r, suite, method.Name, timeout, message, call)
+	} else {
+		call()
 	}
+}
 
-	if !testing.RunTests(func(_, _ string) (bool, error) { return true, nil },
-		tests) {
-		t.Fail()
+// failingTest returns a test that reports err, used for suite methods
+// that testify is unable to run.
+func failingTest(name string, err error) suiteTest {
+	return suiteTest{
+		name: name,
+		f: func(r Runner) {
+			r.Errorf("testify: %s", err)
+		},
 	}
 }
 
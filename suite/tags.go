@@ -0,0 +1,171 @@
+package suite
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// FocusTag marks a test method as focused.  When any method of a
+	// suite is focused, only the focused methods are run.
+	FocusTag = "focus"
+
+	// FlakyTag marks a test method as known to be flaky.  A failing
+	// flaky method is retried up to -testify.flaky-retries times, and
+	// only fails if every attempt fails.
+	FlakyTag = "flaky"
+)
+
+var matchTags = flag.String("testify.tags", os.Getenv("TESTIFY_TAGS"), "comma-separated tags of the suite methods to run, prefix a tag with ! to exclude it (defaults to $TESTIFY_TAGS)")
+
+var flakyRetries = flag.Int("testify.flaky-retries", envInt("TESTIFY_FLAKY_RETRIES", 2), "number of times to retry failing suite methods tagged as flaky (defaults to $TESTIFY_FLAKY_RETRIES or 2)")
+
+// envInt returns the integer value of the named environment variable,
+// or def if it is unset or not an integer.
+func envInt(name string, def int) int {
+	if n, err := strconv.Atoi(os.Getenv(name)); err == nil {
+		return n
+	}
+	return def
+}
+
+// tagFilter selects test methods by their tags.
+type tagFilter struct {
+	include []string
+	exclude []string
+}
+
+// parseTagFilter parses a comma-separated list of tags, where tags
+// prefixed with ! are excluded.
+func parseTagFilter(s string) tagFilter {
+	var filter tagFilter
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.TrimSpace(tag)
+		switch {
+		case tag == "" || tag == "!":
+		case strings.HasPrefix(tag, "!"):
+			filter.exclude = append(filter.exclude, tag[1:])
+		default:
+			filter.include = append(filter.include, tag)
+		}
+	}
+	return filter
+}
+
+// match returns whether a method with the given tags is selected.  It
+// is selected when it has none of the excluded tags and, if any tags are
+// included, at least one of them.
+func (f tagFilter) match(tags []string) bool {
+	for _, tag := range f.exclude {
+		if hasTag(tags, tag) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, tag := range f.include {
+		if hasTag(tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// methodTags returns the tags of the named method of suite.
+func methodTags(suite TestingSuite, name string) []string {
+	if taggedSuite, ok := suite.(TaggedSuite); ok {
+		return taggedSuite.Tags()[name]
+	}
+	return nil
+}
+
+// hasTag returns whether tags contains tag.
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFocusedMethod returns whether any of the methods of suite is
+// tagged with FocusTag.
+func hasFocusedMethod(suite TestingSuite, methods []reflect.Method) bool {
+	for _, method := range methods {
+		if hasTag(methodTags(suite, method.Name), FocusTag) {
+			return true
+		}
+	}
+	return false
+}
+
+// skippedTest returns a test that is skipped with the given reason.
+func skippedTest(name, reason string) suiteTest {
+	return suiteTest{
+		name: name,
+		f: func(r Runner) {
+			r.Skip("testify: " + reason)
+		},
+	}
+}
+
+// retryTest returns a test that runs test up to retries more times for
+// as long as it fails, and only fails if the last attempt fails.
+func retryTest(test suiteTest, retries int, summary *runSummary) suiteTest {
+	return suiteTest{
+		name: test.name,
+		f: func(r Runner) {
+			for attempt := 1; ; attempt++ {
+				if r.Run(fmt.Sprintf("%s#%d", test.name, attempt), test.f) {
+					if attempt > 1 {
+						summary.retried = append(summary.retried, fmt.Sprintf("%s (passed on attempt %d)", test.name, attempt))
+					}
+					return
+				}
+				if attempt > retries {
+					summary.retried = append(summary.retried, fmt.Sprintf("%s (failed %d attempts)", test.name, attempt))
+					r.Errorf("testify: flaky method %s failed %d attempts", test.name, attempt)
+					return
+				}
+				r.Logf("testify: flaky method %s failed attempt %d, retrying", test.name, attempt)
+			}
+		},
+	}
+}
+
+// runSummary records how the methods of a suite were selected and
+// retried, to be reported once the suite has run.
+type runSummary struct {
+	excluded  []string
+	unfocused []string
+	retried   []string
+}
+
+// log reports the summary through t, if there is anything to report.
+func (s *runSummary) log(t TestingT) {
+	lines := []string{}
+	for _, group := range []struct {
+		label string
+		names []string
+	}{
+		{"excluded by tags", s.excluded},
+		{"skipped for focused methods", s.unfocused},
+		{"retried as flaky", s.retried},
+	} {
+		if len(group.names) > 0 {
+			names := append([]string{}, group.names...)
+			sort.Strings(names)
+			lines = append(lines, fmt.Sprintf("%d %s: %s", len(names), group.label, strings.Join(names, ", ")))
+		}
+	}
+	if len(lines) > 0 {
+		t.Logf("testify: suite summary:\n\t%s", strings.Join(lines, "\n\t"))
+	}
+}
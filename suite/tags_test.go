@@ -0,0 +1,136 @@
+package suite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// SuiteTagsTester has tagged test methods, and counts how many times
+// each of them is run.
+type SuiteTagsTester struct {
+	Suite
+	RunCounts map[string]int
+}
+
+func (suite *SuiteTagsTester) Tags() map[string][]string {
+	return map[string][]string{
+		"TestSlow": {"slow"},
+		"TestDB":   {"slow", "db"},
+	}
+}
+
+func (suite *SuiteTagsTester) SetupSuite() {
+	suite.RunCounts = map[string]int{}
+}
+
+func (suite *SuiteTagsTester) TestFast() {
+	suite.RunCounts["TestFast"]++
+}
+
+func (suite *SuiteTagsTester) TestSlow() {
+	suite.RunCounts["TestSlow"]++
+}
+
+func (suite *SuiteTagsTester) TestDB() {
+	suite.RunCounts["TestDB"]++
+}
+
+func TestRunSuiteWithTags(t *testing.T) {
+	defer func(tags string) { *matchTags = tags }(*matchTags)
+
+	for _, c := range []struct {
+		tags     string
+		expected map[string]int
+	}{
+		{"", map[string]int{"TestFast": 1, "TestSlow": 1, "TestDB": 1}},
+		{"slow", map[string]int{"TestSlow": 1, "TestDB": 1}},
+		{"slow,!db", map[string]int{"TestSlow": 1}},
+		{"!slow", map[string]int{"TestFast": 1}},
+	} {
+		*matchTags = c.tags
+		suiteTester := new(SuiteTagsTester)
+		Run(t, suiteTester)
+		assert.Equal(t, c.expected, suiteTester.RunCounts, "tags %q", c.tags)
+	}
+}
+
+// SuiteFocusTester has a focused test method, so it is the only one run.
+type SuiteFocusTester struct {
+	Suite
+	RunCounts map[string]int
+}
+
+func (suite *SuiteFocusTester) Tags() map[string][]string {
+	return map[string][]string{
+		"TestFocused": {FocusTag},
+	}
+}
+
+func (suite *SuiteFocusTester) SetupSuite() {
+	suite.RunCounts = map[string]int{}
+}
+
+func (suite *SuiteFocusTester) TestFocused() {
+	suite.RunCounts["TestFocused"]++
+}
+
+func (suite *SuiteFocusTester) TestOther() {
+	suite.RunCounts["TestOther"]++
+}
+
+func TestRunSuiteWithFocus(t *testing.T) {
+	suiteTester := new(SuiteFocusTester)
+	Run(t, suiteTester)
+	assert.Equal(t, map[string]int{"TestFocused": 1}, suiteTester.RunCounts)
+}
+
+// SuiteFlakyTester has flaky test methods, one failing until its third
+// attempt and one always failing.
+type SuiteFlakyTester struct {
+	Suite
+	EventuallyRunCount int
+	AlwaysRunCount     int
+}
+
+func (suite *SuiteFlakyTester) Tags() map[string][]string {
+	return map[string][]string{
+		"TestEventually": {FlakyTag},
+		"TestAlways":     {FlakyTag},
+	}
+}
+
+func (suite *SuiteFlakyTester) TestEventually() {
+	suite.EventuallyRunCount++
+	suite.Equal(3, suite.EventuallyRunCount)
+}
+
+func (suite *SuiteFlakyTester) TestAlways() {
+	suite.AlwaysRunCount++
+	suite.Fail("always fails")
+}
+
+func TestRunSuiteWithFlakyMethods(t *testing.T) {
+	defer func(retries int) { *flakyRetries = retries }(*flakyRetries)
+	*flakyRetries = 2
+
+	suiteTester := new(SuiteFlakyTester)
+	runner := NewFakeRunner("TestRunSuiteWithFlakyMethods")
+	Run(runner, suiteTester)
+
+	// TestAlways fails every attempt, which fails the suite.
+	assert.True(t, runner.Failed())
+	assert.Equal(t, 3, suiteTester.EventuallyRunCount)
+	assert.Equal(t, 3, suiteTester.AlwaysRunCount)
+}
+
+func TestTagFilter(t *testing.T) {
+	filter := parseTagFilter(" slow, !db ,,")
+	assert.Equal(t, []string{"slow"}, filter.include)
+	assert.Equal(t, []string{"db"}, filter.exclude)
+
+	assert.True(t, filter.match([]string{"slow"}))
+	assert.False(t, filter.match([]string{"slow", "db"}))
+	assert.False(t, filter.match(nil))
+	assert.True(t, parseTagFilter("").match(nil))
+}
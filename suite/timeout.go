@@ -0,0 +1,373 @@
+package suite
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// timeoutTagPrefix prefixes the tag setting the timeout of a method,
+// e.g. "timeout=10s".
+const timeoutTagPrefix = "timeout="
+
+// methodTimeout returns how long the named method of suite may run, or
+// zero if it may run indefinitely.  A "timeout=<duration>" tag on the
+// method takes precedence over the MethodTimeout of the suite.
+func methodTimeout(suite TestingSuite, name string) (time.Duration, error) {
+	for _, tag := range methodTags(suite, name) {
+		if strings.HasPrefix(tag, timeoutTagPrefix) {
+			timeout, err := time.ParseDuration(strings.TrimPrefix(tag, timeoutTagPrefix))
+			if err != nil {
+				return 0, fmt.Errorf("invalid timeout tag %q on method %s: %s", tag, name, err)
+			}
+			return timeout, nil
+		}
+	}
+	if timeoutSuite, ok := suite.(MethodTimeoutSuite); ok {
+		return timeoutSuite.MethodTimeout(), nil
+	}
+	return 0, nil
+}
+
+// watchdog enforces the timeouts of the methods of a suite run by Run.
+type watchdog struct {
+	// timeout is the timeout of the suite, and deadline the time it
+	// ends, or zero if the suite may run indefinitely.
+	timeout  time.Duration
+	deadline time.Time
+}
+
+// newWatchdog returns a watchdog starting the timeout of suite, if it is
+// a SuiteTimeoutSuite.
+func newWatchdog(suite TestingSuite) *watchdog {
+	w := new(watchdog)
+	if timeoutSuite, ok := suite.(SuiteTimeoutSuite); ok && timeoutSuite.SuiteTimeout() > 0 {
+		w.timeout = timeoutSuite.SuiteTimeout()
+		w.deadline = time.Now().Add(w.timeout)
+	}
+	return w
+}
+
+// check returns an error if a method of the suite cannot run, because
+// the suite timed out.
+func (w *watchdog) check() error {
+	if !w.deadline.IsZero() && !time.Now().Before(w.deadline) {
+		return fmt.Errorf("not run, suite timed out after %v", w.timeout)
+	}
+	return nil
+}
+
+// limit returns how long a method with the specified timeout may run
+// before the suite times out, and the message reporting that it timed
+// out.
+func (w *watchdog) limit(name string, timeout time.Duration) (time.Duration, string) {
+	message := fmt.Sprintf("method %s timed out after %v", name, timeout)
+	if !w.deadline.IsZero() {
+		if left := time.Until(w.deadline); timeout <= 0 || left < timeout {
+			if left <= 0 {
+				// The suite timed out since the method was checked.
+				left = time.Nanosecond
+			}
+			return left, fmt.Sprintf("suite timed out after %v while running method %s", w.timeout, name)
+		}
+	}
+	return timeout, message
+}
+
+// callWithTimeout calls the suite method f in a new goroutine and waits
+// for at most timeout for it to return, with the suite reporting to a
+// timeoutRunner of r meanwhile, unless it can only be run by a
+// *testing.T.  If f does not return in time, the test
+// fails with message and the stacks of the goroutines running code from
+// the package of suite, and f is left running in the background without
+// reporting to r.  The suite reports to r again once callWithTimeout
+// returns.
+func callWithTimeout(r Runner, suite TestingSuite, name string, timeout time.Duration, message string, f func()) {
+	guard := &timeoutRunner{Runner: r}
+	if useSwitchRunner(suite) {
+		setRunner(suite, guard)
+		defer setRunner(suite, r)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer guard.recoverPanic(name)
+		f()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		guard.timeOut()
+		pkgPath := reflect.Indirect(reflect.ValueOf(suite)).Type().PkgPath()
+		r.Errorf("testify: %s\n\nGoroutines in %s:\n\n%s", message, pkgPath, goroutineStacks(pkgPath))
+	}
+}
+
+// switchRunner is a Runner forwarding to another one, which can be
+// switched safely while methods that timed out still use the suite.  The
+// suites run methods with a timeout with a switchRunner, so that the
+// runner of their tests is set without writing to the suite.
+type switchRunner struct {
+	mutex  sync.Mutex
+	target Runner
+}
+
+// useSwitchRunner binds suite to a switchRunner forwarding to its
+// current Runner, unless it is already bound to one, and returns whether
+// it is.  Suites that do not implement RunnerSuite cannot be bound.
+func useSwitchRunner(suite TestingSuite) bool {
+	runnerSuite, ok := suite.(RunnerSuite)
+	if !ok {
+		return false
+	}
+	if suiteSwitchRunner(suite) == nil {
+		runnerSuite.SetRunner(&switchRunner{target: runnerSuite.Runner()})
+	}
+	return true
+}
+
+// suiteSwitchRunner returns the switchRunner suite is bound to, if any.
+// Suite does not return it from Runner, but the Runner it forwards to.
+func suiteSwitchRunner(suite TestingSuite) *switchRunner {
+	if s, ok := suite.(interface{ boundSwitchRunner() *switchRunner }); ok {
+		return s.boundSwitchRunner()
+	}
+	if runnerSuite, ok := suite.(RunnerSuite); ok {
+		r, _ := runnerSuite.Runner().(*switchRunner)
+		return r
+	}
+	return nil
+}
+
+// current returns the Runner forwarded to.
+func (r *switchRunner) current() Runner {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.target
+}
+
+// set sets the Runner forwarded to.
+func (r *switchRunner) set(target Runner) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.target = target
+}
+
+// T returns the *testing.T of the Runner forwarded to, if any.
+func (r *switchRunner) T() *testing.T {
+	target := r.current()
+	if guard, ok := target.(*timeoutRunner); ok {
+		target = guard.Runner
+	}
+	if tr, ok := target.(*testingRunner); ok {
+		return tr.T
+	}
+	return nil
+}
+
+// Run and the methods of TestingT forward to the current Runner.
+func (r *switchRunner) Run(name string, f func(Runner)) bool {
+	return r.current().Run(name, f)
+}
+
+func (r *switchRunner) Error(args ...interface{}) {
+	r.current().Error(args...)
+}
+
+func (r *switchRunner) Errorf(format string, args ...interface{}) {
+	r.current().Errorf(format, args...)
+}
+
+func (r *switchRunner) Fail() {
+	r.current().Fail()
+}
+
+func (r *switchRunner) FailNow() {
+	r.current().FailNow()
+}
+
+func (r *switchRunner) Failed() bool {
+	return r.current().Failed()
+}
+
+func (r *switchRunner) Fatal(args ...interface{}) {
+	r.current().Fatal(args...)
+}
+
+func (r *switchRunner) Fatalf(format string, args ...interface{}) {
+	r.current().Fatalf(format, args...)
+}
+
+func (r *switchRunner) Log(args ...interface{}) {
+	r.current().Log(args...)
+}
+
+func (r *switchRunner) Logf(format string, args ...interface{}) {
+	r.current().Logf(format, args...)
+}
+
+func (r *switchRunner) Name() string {
+	return r.current().Name()
+}
+
+func (r *switchRunner) Skip(args ...interface{}) {
+	r.current().Skip(args...)
+}
+
+func (r *switchRunner) SkipNow() {
+	r.current().SkipNow()
+}
+
+func (r *switchRunner) Skipf(format string, args ...interface{}) {
+	r.current().Skipf(format, args...)
+}
+
+func (r *switchRunner) Skipped() bool {
+	return r.current().Skipped()
+}
+
+func (r *switchRunner) Helper() {
+	r.current().Helper()
+}
+
+func (r *switchRunner) Cleanup(f func()) {
+	r.current().Cleanup(f)
+}
+
+// timeoutRunner is the Runner of a suite method run with a timeout.  It
+// reports to the Runner of the test until the method times out, and
+// drops what the method reports afterwards, as the test is over.  As
+// the method runs in its own goroutine, FailNow and SkipNow stop that
+// goroutine rather than the test.
+type timeoutRunner struct {
+	Runner
+
+	mutex    sync.Mutex
+	timedOut bool
+}
+
+// timeOut stops the reports of the method to the test.
+func (r *timeoutRunner) timeOut() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.timedOut = true
+}
+
+// report calls f with the Runner of the test, unless the method timed
+// out.
+func (r *timeoutRunner) report(f func(Runner)) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.timedOut {
+		f(r.Runner)
+	}
+}
+
+// recoverPanic fails the test if the method panicked, rather than
+// crashing the test binary from the goroutine of the method.
+func (r *timeoutRunner) recoverPanic(name string) {
+	if p := recover(); p != nil {
+		r.Errorf("testify: method %s panicked: %v\n%s", name, p, debug.Stack())
+	}
+}
+
+// Log forwards to the Runner of the test until the method times out.
+func (r *timeoutRunner) Log(args ...interface{}) {
+	r.report(func(t Runner) { t.Log(args...) })
+}
+
+// Logf forwards to the Runner of the test until the method times out.
+func (r *timeoutRunner) Logf(format string, args ...interface{}) {
+	r.report(func(t Runner) { t.Logf(format, args...) })
+}
+
+// Fail forwards to the Runner of the test until the method times out.
+func (r *timeoutRunner) Fail() {
+	r.report(func(t Runner) { t.Fail() })
+}
+
+// Error is equivalent to Log followed by Fail.
+func (r *timeoutRunner) Error(args ...interface{}) {
+	r.report(func(t Runner) { t.Error(args...) })
+}
+
+// Errorf is equivalent to Logf followed by Fail.
+func (r *timeoutRunner) Errorf(format string, args ...interface{}) {
+	r.report(func(t Runner) { t.Errorf(format, args...) })
+}
+
+// FailNow marks the test as failed and stops the method.
+func (r *timeoutRunner) FailNow() {
+	r.Fail()
+	runtime.Goexit()
+}
+
+// Fatal is equivalent to Log followed by FailNow.
+func (r *timeoutRunner) Fatal(args ...interface{}) {
+	r.Log(args...)
+	r.FailNow()
+}
+
+// Fatalf is equivalent to Logf followed by FailNow.
+func (r *timeoutRunner) Fatalf(format string, args ...interface{}) {
+	r.Logf(format, args...)
+	r.FailNow()
+}
+
+// SkipNow marks the test as skipped and stops the method.
+func (r *timeoutRunner) SkipNow() {
+	r.report(func(t Runner) { t.SkipNow() })
+	runtime.Goexit()
+}
+
+// Skip is equivalent to Log followed by SkipNow.
+func (r *timeoutRunner) Skip(args ...interface{}) {
+	r.Log(args...)
+	r.SkipNow()
+}
+
+// Skipf is equivalent to Logf followed by SkipNow.
+func (r *timeoutRunner) Skipf(format string, args ...interface{}) {
+	r.Logf(format, args...)
+	r.SkipNow()
+}
+
+// Cleanup registers f with the Runner of the test until the method
+// times out.
+func (r *timeoutRunner) Cleanup(f func()) {
+	r.report(func(t Runner) { t.Cleanup(f) })
+}
+
+// goroutineStacks returns the stacks of all goroutines that are running
+// code from the package pkgPath.
+func goroutineStacks(pkgPath string) string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	stacks := []string{}
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		if strings.Contains(stack, "\n"+pkgPath+".") {
+			stacks = append(stacks, strings.TrimSpace(stack))
+		}
+	}
+	if len(stacks) == 0 {
+		return "(none)"
+	}
+	return strings.Join(stacks, "\n\n")
+}
@@ -0,0 +1,241 @@
+package suite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// SuiteTimeoutTester has a method that hangs until released, and is run
+// with a short timeout for each method.
+type SuiteTimeoutTester struct {
+	Suite
+	release  chan struct{}
+	returned chan struct{}
+	tornDown chan string
+
+	TearDownSuiteRunCount int
+	TestAfterRunCount     int
+}
+
+func (suite *SuiteTimeoutTester) MethodTimeout() time.Duration {
+	return 10 * time.Millisecond
+}
+
+func (suite *SuiteTimeoutTester) Tags() map[string][]string {
+	return map[string][]string{
+		"TestSlowButAllowed": {"timeout=1s"},
+	}
+}
+
+func (suite *SuiteTimeoutTester) TearDownSuite() {
+	suite.TearDownSuiteRunCount++
+}
+
+func (suite *SuiteTimeoutTester) TearDownTest() {
+	suite.tornDown <- suite.Runner().Name()
+}
+
+func (suite *SuiteTimeoutTester) TestAfter() {
+	suite.TestAfterRunCount++
+}
+
+func (suite *SuiteTimeoutTester) TestHang() {
+	defer close(suite.returned)
+	r := suite.Runner()
+	<-suite.release
+	r.Errorf("reported after timing out")
+}
+
+func (suite *SuiteTimeoutTester) TestSlowButAllowed() {
+	time.Sleep(20 * time.Millisecond)
+}
+
+func TestRunSuiteWithTimeout(t *testing.T) {
+	suiteTester := &SuiteTimeoutTester{
+		release:  make(chan struct{}),
+		returned: make(chan struct{}),
+		tornDown: make(chan string, 3),
+	}
+
+	runner := NewFakeRunner("TestRunSuiteWithTimeout")
+	Run(runner, suiteTester)
+
+	// TestHang times out and is torn down, and the suite carries on
+	// with the next method.
+	results := runner.Results()
+	if assert.Len(t, results, 4) {
+		assert.True(t, results[0].Failed)
+		assert.False(t, results[1].Failed)
+		assert.True(t, results[2].Failed)
+		if assert.Len(t, results[2].Logs, 1) {
+			assert.Contains(t, results[2].Logs[0], "testify: method TestHang timed out after 10ms")
+			assert.Contains(t, results[2].Logs[0], "suite.(*SuiteTimeoutTester).TestHang")
+		}
+		assert.False(t, results[3].Failed)
+	}
+	for _, name := range []string{"TestAfter", "TestHang", "TestSlowButAllowed"} {
+		assert.Equal(t, "TestRunSuiteWithTimeout/"+name, <-suiteTester.tornDown)
+	}
+	assert.Equal(t, 1, suiteTester.TestAfterRunCount)
+	assert.Equal(t, 1, suiteTester.TearDownSuiteRunCount)
+
+	// Once released, TestHang reports to nothing.
+	close(suiteTester.release)
+	<-suiteTester.returned
+	assert.Equal(t, results, runner.Results())
+}
+
+// SuitePanicTimeoutTester panics and calls require assertions in methods
+// run with a timeout.
+type SuitePanicTimeoutTester struct {
+	Suite
+
+	TearDownTestRunCount int
+	TestRequireRunCount  int
+}
+
+func (suite *SuitePanicTimeoutTester) MethodTimeout() time.Duration {
+	return time.Minute
+}
+
+func (suite *SuitePanicTimeoutTester) TearDownTest() {
+	suite.TearDownTestRunCount++
+}
+
+func (suite *SuitePanicTimeoutTester) TestPanic() {
+	panic("oops")
+}
+
+func (suite *SuitePanicTimeoutTester) TestRequire() {
+	suite.Require().Fail("stop")
+	suite.TestRequireRunCount++
+}
+
+func TestRunSuiteWithTimeoutPanic(t *testing.T) {
+	suiteTester := new(SuitePanicTimeoutTester)
+
+	runner := NewFakeRunner("TestRunSuiteWithTimeoutPanic")
+	Run(runner, suiteTester)
+
+	results := runner.Results()
+	if assert.Len(t, results, 3) {
+		assert.True(t, results[1].Failed)
+		if assert.Len(t, results[1].Logs, 1) {
+			assert.Contains(t, results[1].Logs[0], "testify: method TestPanic panicked: oops")
+		}
+		assert.True(t, results[2].Failed)
+	}
+	assert.Equal(t, 2, suiteTester.TearDownTestRunCount)
+	assert.Equal(t, 0, suiteTester.TestRequireRunCount)
+}
+
+// SuiteDeadlineTester has a timeout for the whole suite, which times out
+// while its second method is running.
+type SuiteDeadlineTester struct {
+	Suite
+	release chan struct{}
+	done    chan struct{}
+}
+
+func (suite *SuiteDeadlineTester) SuiteTimeout() time.Duration {
+	return 50 * time.Millisecond
+}
+
+func (suite *SuiteDeadlineTester) TestA() {}
+
+func (suite *SuiteDeadlineTester) TestB() {
+	defer close(suite.done)
+	<-suite.release
+}
+
+func (suite *SuiteDeadlineTester) TestC() {}
+
+func TestRunSuiteWithSuiteTimeout(t *testing.T) {
+	suiteTester := &SuiteDeadlineTester{release: make(chan struct{}), done: make(chan struct{})}
+	defer func() {
+		close(suiteTester.release)
+		<-suiteTester.done
+	}()
+
+	runner := NewFakeRunner("TestRunSuiteWithSuiteTimeout")
+	Run(runner, suiteTester)
+
+	results := runner.Results()
+	if assert.Len(t, results, 4) {
+		assert.False(t, results[1].Failed)
+		assert.True(t, results[2].Failed)
+		if assert.Len(t, results[2].Logs, 1) {
+			assert.Contains(t, results[2].Logs[0], "testify: suite timed out after 50ms while running method TestB")
+		}
+		assert.True(t, results[3].Failed)
+		assert.Equal(t, []string{"testify: not run, suite timed out after 50ms"}, results[3].Logs)
+	}
+}
+
+func TestWatchdog(t *testing.T) {
+	w := newWatchdog(new(SuiteDeadlineTester))
+	assert.NoError(t, w.check())
+
+	timeout, message := w.limit("TestA", time.Minute)
+	assert.True(t, timeout > 0 && timeout <= 50*time.Millisecond)
+	assert.Equal(t, "suite timed out after 50ms while running method TestA", message)
+
+	w.deadline = time.Now()
+	assert.EqualError(t, w.check(), "not run, suite timed out after 50ms")
+
+	w = newWatchdog(new(SuiteTimeoutTester))
+	timeout, message = w.limit("TestHang", 10*time.Millisecond)
+	assert.Equal(t, 10*time.Millisecond, timeout)
+	assert.Equal(t, "method TestHang timed out after 10ms", message)
+}
+
+func TestMethodTimeout(t *testing.T) {
+	suite := new(SuiteTimeoutTester)
+
+	timeout, err := methodTimeout(suite, "TestHang")
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Millisecond, timeout)
+
+	timeout, err = methodTimeout(suite, "TestSlowButAllowed")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, timeout)
+
+	timeout, err = methodTimeout(new(SuiteTester), "TestOne")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), timeout)
+}
+
+func TestGoroutineStacks(t *testing.T) {
+	stacks := goroutineStacks("github.com/stretchr/testify/suite")
+	assert.Contains(t, stacks, "suite.TestGoroutineStacks")
+	assert.Equal(t, "(none)", goroutineStacks("example.com/nothing"))
+}
+
+// SuiteTimeoutTTester checks that its methods run with a timeout have
+// the *testing.T of their test.
+type SuiteTimeoutTTester struct {
+	Suite
+	names []string
+}
+
+func (suite *SuiteTimeoutTTester) MethodTimeout() time.Duration {
+	return time.Minute
+}
+
+func (suite *SuiteTimeoutTTester) TestOne() {
+	suite.names = append(suite.names, suite.T().Name())
+	suite.Equal(1, 1)
+}
+
+func (suite *SuiteTimeoutTTester) TestTwo() {
+	suite.names = append(suite.names, suite.T().Name())
+}
+
+func TestRunSuiteWithTimeoutT(t *testing.T) {
+	suiteTester := new(SuiteTimeoutTTester)
+	Run(t, suiteTester)
+	assert.Equal(t, []string{"TestRunSuiteWithTimeoutT/TestOne", "TestRunSuiteWithTimeoutT/TestTwo"}, suiteTester.names)
+	assert.Equal(t, t, suiteTester.T())
+}